@@ -0,0 +1,104 @@
+// Command cli fetches tweets from the command line and prints them with the
+// same JSON fields as the API.
+//
+//	sumariza-cli --url https://x.com/user/status/123
+//	cat urls.txt | sumariza-cli --format ndjson --concurrency 4 | jq -r .text
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	"sumariza-ai/internal/adapters/scraper"
+	"sumariza-ai/internal/adapters/web"
+	"sumariza-ai/internal/usecases"
+)
+
+// fetchTimeout bounds each tweet, like API requests.
+const fetchTimeout = 30 * time.Second
+
+// urlList collects a repeated --url flag.
+type urlList []string
+
+func (u *urlList) String() string {
+	return strings.Join(*u, ",")
+}
+
+func (u *urlList) Set(value string) error {
+	*u = append(*u, value)
+	return nil
+}
+
+func main() {
+	var urls urlList
+	flag.Var(&urls, "url", "tweet URL to fetch; repeatable (default: one URL per line on stdin)")
+	format := flag.String("format", formatJSON, "output format: json (one array) or ndjson (one tweet per line)")
+	concurrency := flag.Int("concurrency", 2, "URLs fetched at once; output keeps the input order")
+	continueOnError := flag.Bool("continue-on-error", false, "keep fetching after a failed URL (the exit code is still 1)")
+	selectorsFlag := flag.String("selectors", "", "path to the selectors YAML file (default "+scraper.DefaultSelectorsPath+")")
+	flag.Parse()
+
+	out, err := newTweetWriter(*format, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if len(urls) == 0 {
+		if urls, err = readURLs(os.Stdin); err != nil {
+			fmt.Fprintf(os.Stderr, "reading URLs from stdin: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	// Same CHROME_PATH, SELECTORS_PATH and BROWSER_LANG as the server
+	_ = godotenv.Load()
+
+	selectors, err := scraper.LoadSelectors(scraper.SelectorsPath(*selectorsFlag))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "loading selectors: %v\n", err)
+		os.Exit(1)
+	}
+
+	browserPool, err := scraper.NewBrowserPool(nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "starting browser: %v\n", err)
+		os.Exit(1)
+	}
+	browserPool.SetLang(os.Getenv("BROWSER_LANG"))
+	scrapeUC := usecases.NewScrapeTweetUseCase(scraper.NewTwitterScraper(browserPool, selectors))
+
+	fetch := func(ctx context.Context, url string) (any, error) {
+		username, tweetID, err := web.ParseTweetURL(url)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+		defer cancel()
+
+		tweet, err := scrapeUC.Execute(ctx, tweetID, username)
+		if err != nil {
+			return nil, err
+		}
+		return web.TweetJSON(tweet), nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, urls, fetch, out, os.Stderr, runOptions{
+		concurrency:     *concurrency,
+		continueOnError: *continueOnError,
+	})
+	stop()
+
+	// os.Exit skips deferred calls, so Chrome is stopped first
+	browserPool.Close()
+	os.Exit(code)
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Output formats accepted by --format.
+const (
+	formatJSON   = "json"   // One indented JSON array holding every tweet
+	formatNDJSON = "ndjson" // One compact JSON object per line, for jq and friends
+)
+
+// fetchFunc fetches the tweet behind a URL as its JSON value.
+type fetchFunc func(ctx context.Context, url string) (any, error)
+
+// tweetWriter writes fetched tweets in one output format.
+type tweetWriter interface {
+	WriteTweet(tweet any) error
+	// Close finishes the output, e.g. the closing bracket of a JSON array.
+	Close() error
+}
+
+// newTweetWriter returns the writer of format, writing to w.
+func newTweetWriter(format string, w io.Writer) (tweetWriter, error) {
+	switch strings.ToLower(format) {
+	case formatJSON:
+		return &jsonWriter{w: w}, nil
+	case formatNDJSON:
+		return newNDJSONWriter(w), nil
+	default:
+		return nil, fmt.Errorf("invalid format %q (want %s or %s)", format, formatJSON, formatNDJSON)
+	}
+}
+
+// ndjsonWriter writes each tweet as soon as it is fetched, one per line.
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func newNDJSONWriter(w io.Writer) *ndjsonWriter {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false) // Keep & in URLs readable
+	return &ndjsonWriter{enc: enc}
+}
+
+// WriteTweet writes tweet as compact JSON followed by a newline.
+func (n *ndjsonWriter) WriteTweet(tweet any) error {
+	return n.enc.Encode(tweet)
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
+
+// jsonWriter buffers the tweets and writes them as one array on Close.
+type jsonWriter struct {
+	w      io.Writer
+	tweets []any
+}
+
+func (j *jsonWriter) WriteTweet(tweet any) error {
+	j.tweets = append(j.tweets, tweet)
+	return nil
+}
+
+// Close writes the array, which is empty ([]) if nothing was fetched.
+func (j *jsonWriter) Close() error {
+	enc := json.NewEncoder(j.w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if j.tweets == nil {
+		return enc.Encode([]any{})
+	}
+	return enc.Encode(j.tweets)
+}
+
+// readURLs returns the non-blank lines of r, trimmed.
+func readURLs(r io.Reader) ([]string, error) {
+	var urls []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			urls = append(urls, line)
+		}
+	}
+	return urls, scanner.Err()
+}
+
+// runOptions controls how run fetches the URLs.
+type runOptions struct {
+	// concurrency is how many URLs are fetched at once; below 1 means 1.
+	concurrency int
+
+	// continueOnError keeps fetching after a failed URL instead of stopping.
+	continueOnError bool
+}
+
+// fetchResult is the outcome of fetching one URL.
+type fetchResult struct {
+	tweet any
+	err   error
+}
+
+// fetchInOrder fetches urls with at most concurrency fetches in flight. It
+// returns one channel per URL, in input order, that receives its result
+// whatever the completion order. URLs not started once ctx is done receive
+// ctx's error. wait blocks until every started fetch has returned.
+func fetchInOrder(ctx context.Context, urls []string, fetch fetchFunc, concurrency int) (results []chan fetchResult, wait func()) {
+	results = make([]chan fetchResult, len(urls))
+	for i := range results {
+		results[i] = make(chan fetchResult, 1)
+	}
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if concurrency > len(urls) {
+		concurrency = len(urls)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				tweet, err := fetch(ctx, urls[i])
+				results[i] <- fetchResult{tweet: tweet, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for i := range urls {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				for ; i < len(urls); i++ {
+					results[i] <- fetchResult{err: ctx.Err()}
+				}
+				return
+			}
+		}
+	}()
+
+	return results, wg.Wait
+}
+
+// run fetches the URLs and writes each tweet to out in input order, as soon
+// as it and every URL before it are done. Failures are reported on stderr;
+// the first one stops the run unless opts.continueOnError is set. It returns
+// the exit code: 0 if every URL was written, 1 otherwise.
+func run(ctx context.Context, urls []string, fetch fetchFunc, out tweetWriter, stderr io.Writer, opts runOptions) int {
+	ctx, cancel := context.WithCancel(ctx)
+	results, wait := fetchInOrder(ctx, urls, fetch, opts.concurrency)
+	defer wait()
+	defer cancel() // Runs first: in-flight fetches stop before waiting on them
+
+	failed := false
+	for i, url := range urls {
+		result := <-results[i]
+		err := result.err
+		if err == nil {
+			err = out.WriteTweet(result.tweet)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", url, err)
+			failed = true
+			if !opts.continueOnError {
+				break
+			}
+		}
+	}
+
+	if err := out.Close(); err != nil {
+		fmt.Fprintf(stderr, "writing output: %v\n", err)
+		failed = true
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
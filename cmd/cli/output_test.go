@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeFetch returns {"url": url} for every URL except those in failing.
+func fakeFetch(failing ...string) (fetchFunc, func() []string) {
+	var mu sync.Mutex
+	var fetched []string
+	fetchedURLs := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), fetched...)
+	}
+	return func(ctx context.Context, url string) (any, error) {
+		mu.Lock()
+		fetched = append(fetched, url)
+		mu.Unlock()
+		for _, f := range failing {
+			if url == f {
+				return nil, errors.New("scrape failed")
+			}
+		}
+		return map[string]string{"url": url}, nil
+	}, fetchedURLs
+}
+
+func TestNDJSONWriter_OneCompactObjectPerLine(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	w := newNDJSONWriter(&buf)
+
+	// Act
+	for _, text := range []string{"first", "second & last"} {
+		if err := w.WriteTweet(map[string]string{"text": text}); err != nil {
+			t.Fatalf("WriteTweet() error = %v", err)
+		}
+	}
+
+	// Assert
+	want := "{\"text\":\"first\"}\n{\"text\":\"second & last\"}\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestJSONWriter_NoTweets_WritesEmptyArray(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	w, _ := newTweetWriter(formatJSON, &buf)
+
+	// Act
+	err := w.Close()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "[]" {
+		t.Errorf("got %q, want []", got)
+	}
+}
+
+func TestNewTweetWriter_UnknownFormat_ReturnsError(t *testing.T) {
+	if _, err := newTweetWriter("yaml", &bytes.Buffer{}); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestReadURLs_SkipsBlankLines(t *testing.T) {
+	// Act
+	urls, err := readURLs(strings.NewReader("https://x.com/a/status/1\n\n  https://x.com/b/status/2  \n"))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("readURLs() error = %v", err)
+	}
+	if len(urls) != 2 || urls[0] != "https://x.com/a/status/1" || urls[1] != "https://x.com/b/status/2" {
+		t.Errorf("got %q", urls)
+	}
+}
+
+func TestRun_AllSucceed_ExitsZero(t *testing.T) {
+	// Arrange
+	var stdout, stderr bytes.Buffer
+	fetch, _ := fakeFetch()
+
+	// Act
+	code := run(context.Background(), []string{"a", "b"}, fetch, newNDJSONWriter(&stdout), &stderr, runOptions{})
+
+	// Assert
+	if code != 0 {
+		t.Errorf("exit code = %d, want 0 (stderr %q)", code, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), stdout.String())
+	}
+	for _, line := range lines {
+		if !json.Valid([]byte(line)) {
+			t.Errorf("line is not JSON: %q", line)
+		}
+	}
+}
+
+func TestRun_Failure_StopsAndExitsNonZero(t *testing.T) {
+	// Arrange
+	var stdout, stderr bytes.Buffer
+	fetch, _ := fakeFetch("b")
+
+	// Act
+	code := run(context.Background(), []string{"a", "b", "c"}, fetch, newNDJSONWriter(&stdout), &stderr, runOptions{})
+
+	// Assert
+	if code != 1 {
+		t.Errorf("exit code = %d, want 1", code)
+	}
+	if got := stdout.String(); got != "{\"url\":\"a\"}\n" {
+		t.Errorf("stdout = %q, want only a", got)
+	}
+	if !strings.Contains(stderr.String(), "b: scrape failed") {
+		t.Errorf("stderr = %q, want the failed URL and its error", stderr.String())
+	}
+}
+
+func TestRun_ContinueOnError_FetchesAllButExitsNonZero(t *testing.T) {
+	// Arrange
+	var stdout, stderr bytes.Buffer
+	fetch, fetched := fakeFetch("b")
+
+	// Act
+	code := run(context.Background(), []string{"a", "b", "c"}, fetch, newNDJSONWriter(&stdout), &stderr, runOptions{continueOnError: true})
+
+	// Assert
+	if code != 1 {
+		t.Errorf("exit code = %d, want 1", code)
+	}
+	if got := fetched(); len(got) != 3 {
+		t.Errorf("fetched %q, want every URL", got)
+	}
+	if got := stdout.String(); got != "{\"url\":\"a\"}\n{\"url\":\"c\"}\n" {
+		t.Errorf("stdout = %q, want a and c", got)
+	}
+}
+
+func TestRun_Concurrent_OutputKeepsInputOrder(t *testing.T) {
+	// Arrange - earlier URLs take longer, so they complete last
+	urls := []string{"a", "b", "c", "d", "e", "f"}
+	delays := map[string]time.Duration{}
+	for i, url := range urls {
+		delays[url] = time.Duration(len(urls)-i) * 5 * time.Millisecond
+	}
+	var completed []string
+	var mu sync.Mutex
+	fetch := func(ctx context.Context, url string) (any, error) {
+		time.Sleep(delays[url])
+		mu.Lock()
+		completed = append(completed, url)
+		mu.Unlock()
+		return map[string]string{"url": url}, nil
+	}
+	var stdout, stderr bytes.Buffer
+
+	// Act
+	code := run(context.Background(), urls, fetch, newNDJSONWriter(&stdout), &stderr, runOptions{concurrency: 3})
+
+	// Assert
+	if code != 0 {
+		t.Fatalf("exit code = %d, want 0 (stderr %q)", code, stderr.String())
+	}
+	if completed[0] == "a" {
+		t.Fatalf("completion order %q: expected out-of-order completion", completed)
+	}
+	var want strings.Builder
+	for _, url := range urls {
+		want.WriteString("{\"url\":\"" + url + "\"}\n")
+	}
+	if got := stdout.String(); got != want.String() {
+		t.Errorf("stdout = %q, want input order %q", got, want.String())
+	}
+}
+
+func TestRun_Concurrency_BoundsFetchesInFlight(t *testing.T) {
+	// Arrange
+	var inFlight, maxInFlight atomic.Int32
+	fetch := func(ctx context.Context, url string) (any, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return url, nil
+	}
+	urls := make([]string, 12)
+	for i := range urls {
+		urls[i] = strconv.Itoa(i)
+	}
+
+	// Act
+	code := run(context.Background(), urls, fetch, newNDJSONWriter(io.Discard), io.Discard, runOptions{concurrency: 3})
+
+	// Assert
+	if code != 0 {
+		t.Fatalf("exit code = %d, want 0", code)
+	}
+	if got := maxInFlight.Load(); got > 3 || got < 2 {
+		t.Errorf("max fetches in flight = %d, want up to 3", got)
+	}
+}
+
+func TestRun_CanceledContext_ReportsRemainingURLs(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	fetch := func(ctx context.Context, url string) (any, error) {
+		return nil, ctx.Err()
+	}
+	var stderr bytes.Buffer
+
+	// Act
+	code := run(ctx, []string{"a", "b"}, fetch, newNDJSONWriter(io.Discard), &stderr, runOptions{continueOnError: true})
+
+	// Assert
+	if code != 1 {
+		t.Errorf("exit code = %d, want 1", code)
+	}
+	if got := strings.Count(stderr.String(), context.Canceled.Error()); got != 2 {
+		t.Errorf("stderr = %q, want both URLs canceled", stderr.String())
+	}
+}
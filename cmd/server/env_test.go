@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+
+	"sumariza-ai/internal/adapters/web"
+	"sumariza-ai/internal/config"
+)
+
+func TestRateLimiter_UsesParsedLimit(t *testing.T) {
+	// Arrange
+	t.Setenv("RATE_LIMIT", "2")
+	t.Setenv("RATE_WINDOW", "1h")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	rl := web.NewRateLimiter(cfg.RateLimit, cfg.RateWindow)
+
+	// Act
+	rl.RecordScrape("1.2.3.4")
+	allowedAfterOne := rl.CanScrape("1.2.3.4")
+	rl.RecordScrape("1.2.3.4")
+	allowedAfterTwo := rl.CanScrape("1.2.3.4")
+
+	// Assert
+	if !allowedAfterOne {
+		t.Error("expected a second scrape to be allowed with RATE_LIMIT=2")
+	}
+	if allowedAfterTwo {
+		t.Error("expected a third scrape to be denied with RATE_LIMIT=2")
+	}
+}
+
+func TestScrapePolicy_DisabledNotFoundPolicy_NoNegativeCacheTTL(t *testing.T) {
+	// Arrange
+	t.Setenv("NOT_FOUND_MAX_FAILURES", "0")
+	t.Setenv("NOT_FOUND_COOLDOWN_MINUTES", "60")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	// Act
+	policy := scrapePolicy(cfg)
+
+	// Assert
+	if policy.NegativeCacheTTL != 0 {
+		t.Errorf("NegativeCacheTTL: got %v, want 0", policy.NegativeCacheTTL)
+	}
+}
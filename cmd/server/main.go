@@ -1,35 +1,57 @@
 package main
 
 import (
+	"flag"
 	"os"
-	"strconv"
-	"time"
+	_ "time/tzdata" // DISPLAY_TZ must resolve in images without zoneinfo
 
 	"github.com/chromedp/chromedp"
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"github.com/joho/godotenv"
 
+	"sumariza-ai/internal/adapters/alert"
 	"sumariza-ai/internal/adapters/cache"
 	"sumariza-ai/internal/adapters/scraper"
 	"sumariza-ai/internal/adapters/web"
+	"sumariza-ai/internal/config"
 	"sumariza-ai/internal/usecases"
+	"sumariza-ai/pkg/httpclient"
 	"sumariza-ai/pkg/log"
 	"sumariza-ai/pkg/log/transporters"
+	"sumariza-ai/pkg/stats"
 )
 
 func main() {
+	// Load .env file if it exists (development only, ignored in production)
+	_ = godotenv.Load()
+
 	// Initialize logger
-	appLogger := log.New(log.Info, transporters.NewStdout())
+	// SERVICE_NAME tags every entry, along with hostname and pid
+	appLogger := log.NewWithDefaults(log.Info, config.ServiceName(), transporters.NewStdout())
 	log.SetDefault(appLogger)
 	defer appLogger.Close()
 
-	// Load .env file if it exists (development only, ignored in production)
-	_ = godotenv.Load()
+	// Every setting is read once here; invalid values are logged and
+	// replaced by their defaults, impossible ones stop the server (see .env.example)
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.GlobalFatal("invalid configuration, refusing to start", "error", err)
+		os.Exit(1)
+	}
+	logEnvironment(cfg.IsLocal)
+
+	// LOG_LEVEL, else Debug locally and Info in production
+	appLogger.SetLevel(cfg.LogLevel)
+
+	// STATS_ENABLED=1 collects process counters and serves them at /stats
+	statsRegistry := newStats(cfg, appLogger)
+
+	// Load selector configuration (--selectors flag, then SELECTORS_PATH)
+	selectorsFlag := flag.String("selectors", "", "path to the selectors YAML file (default "+scraper.DefaultSelectorsPath+")")
+	flag.Parse()
 
-	// Load selector configuration
-	selectors, err := scraper.LoadSelectors("config/selectors.yaml")
+	selectors, err := scraper.LoadSelectors(scraper.SelectorsPath(*selectorsFlag))
 	if err != nil {
 		log.GlobalFatal("failed to load selectors", "error", err)
 		os.Exit(1)
@@ -37,80 +59,206 @@ func main() {
 
 	// Initialize browser pool (single persistent browser)
 	var options []chromedp.ExecAllocatorOption
-	// if !getIsLocalEnv() {
+	// if !cfg.IsLocal {
 	// 	options = append(options, chromedp.Flag("single-process", true))
 	// }
+	if cfg.Proxy != "" {
+		options = append(options, chromedp.ProxyServer(cfg.Proxy))
+	}
 	browserPool, err := scraper.NewBrowserPool(options)
 	if err != nil {
 		log.GlobalFatal("failed to initialize browser", "error", err)
 		os.Exit(1)
 	}
 	defer browserPool.Close()
+	browserPool.SetIdleTimeout(cfg.IdleTimeout)
+	browserPool.SetStartAttempts(cfg.BrowserStartAttempts)
+	browserPool.SetRestartAfter(cfg.BrowserRestartAfter)
+	browserPool.SetLang(cfg.BrowserLang)
+	browserPool.SetStats(statsRegistry)
 
-	// Get cache TTL from environment (default 5 minutes)
-	cacheTTL := getCacheTTL()
+	// One HTTP client for every outbound call (HTTP_CLIENT_TIMEOUT; the
+	// proxy comes from HTTPS_PROXY, HTTP_PROXY and NO_PROXY)
+	httpClient, err := httpclient.New(httpclient.Options{Timeout: cfg.HTTPTimeout})
+	if err != nil {
+		log.GlobalFatal("failed to configure the HTTP client", "error", err)
+		os.Exit(1)
+	}
 
 	// Initialize adapters
 	tweetScraper := scraper.NewTwitterScraper(browserPool, selectors)
-	tweetCache := cache.NewMemoryCache(cacheTTL)
+	emojiMode, _ := scraper.ParseEmojiMode(cfg.EmojiMode) // validated by LoadConfig
+	tweetScraper.SetEmojiMode(emojiMode)
+	tweetScraper.SetSettleDelay(cfg.ScrapeSettle)
+	tweetScraper.SetLinkBlocklist(newLinkBlocklist(cfg))
+	tweetScraper.SetMaxLinks(cfg.MaxLinks)
+	tweetScraper.SetTrackingParams(trackingParams(cfg))
+	tweetCache := cache.NewMemoryCache(cfg.CacheTTL)
+	tweetCache.SetStaleGrace(cfg.CacheStaleGrace)
 
 	// Initialize use cases
 	scrapeUC := usecases.NewScrapeTweetUseCase(tweetScraper)
+	scrapeUC.SetThrottle(newScrapeThrottle(cfg))
+	scrapeUC.SetSpacer(newScrapeSpacer(cfg))
+	scrapeUC.SetStats(statsRegistry)
+	if cfg.AlertWebhookURL != "" {
+		webhook := alert.NewWebhook(cfg.AlertWebhookURL)
+		webhook.SetClient(httpClient)
+		scrapeUC.SetFailureAlerter(webhook, failureAlertPolicy(cfg))
+	}
 	getTweetUC := usecases.NewGetTweetUseCase(tweetCache, scrapeUC)
+	getTweetUC.SetPolicy(newContentPolicy(cfg))
+	getTweetUC.SetNotFoundPolicy(tweetCache, notFoundPolicy(cfg))
+	if cfg.CacheStaleGrace > 0 {
+		getTweetUC.SetStaleFallback(tweetCache)
+	}
+	getTweetUC.SetStats(statsRegistry)
+
+	batchGetTweetsUC := usecases.NewBatchGetTweetsUseCase(getTweetUC, cfg.BatchConcurrency)
 
 	// Initialize web handlers
-	handlers := web.NewHandlers(getTweetUC)
-	rateLimiter := web.NewRateLimiter(10, time.Minute) // 10 scrapes/min
+	handlers := web.NewHandlers(getTweetUC, batchGetTweetsUC)
+	handlers.SetHealthChecks(browserPool, tweetScraper)
+	handlers.SetParser(tweetScraper)
+	handlers.SetAdminToken(cfg.AdminToken)
+	handlers.SetIndexing(cfg.RobotsTxt, cfg.NoIndex)
+	handlers.SetDisplayTimezone(cfg.DisplayTZ)
+	handlers.SetStats(statsRegistry)
+	handlers.SetScrapePolicy(scrapePolicy(cfg))
+	rateLimiter := web.NewRateLimiter(cfg.RateLimit, cfg.RateWindow)
+	rateLimiter.SetMaxTrackedIPs(cfg.RateLimitMaxIPs)
+	rateAlgorithm, _ := web.ParseRateAlgorithm(cfg.RateAlgorithm) // validated by LoadConfig
+	rateLimiter.SetAlgorithm(rateAlgorithm)
+	rateLimiter.SetCleanupInterval(cfg.RateLimitCleanupInterval)
 
 	// Setup Fiber
-	app := fiber.New(fiber.Config{
+	// TRUSTED_PROXIES: comma-separated IPs/CIDRs allowed to set X-Forwarded-For
+	app := fiber.New(web.WithTrustedProxies(fiber.Config{
 		AppName: "Sumariza AI",
-	})
+	}, cfg.TrustedProxies))
 
 	// Middleware (order matters!)
-	app.Use(recover.New())                        // 1. Panic recovery
-	app.Use(requestid.New(web.RequestIDConfig())) // 2. Generate/extract request ID (Fiber managed)
-	app.Use(web.RequestIDToContextMiddleware())   // 3. Bridge request ID to pkg/log context
-	app.Use(web.RequestLoggerMiddleware())        // 4. Structured JSON request logging
+	app.Use(requestid.New(web.RequestIDConfig())) // 1. Generate/extract request ID (Fiber managed)
+	app.Use(web.RequestIDToContextMiddleware())   // 2. Bridge request ID to pkg/log context
+	app.Use(web.RequestLoggerMiddleware())        // 3. Structured JSON request logging
+	app.Use(web.RecoverMiddleware())              // 4. Panic recovery, logged with the request ID
+	app.Use(web.StatsMiddleware(statsRegistry))   // 5. Request and in-flight counters for /stats
 
 	// Setup routes
-	web.SetupRoutes(app, handlers, rateLimiter)
+	// STATIC_DIR: assets directory, defaults to ./static
+	web.SetupRoutes(app, handlers, rateLimiter, web.ResolveStaticDir(cfg.StaticDir))
 
-	// Start server
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "3000"
-	}
+	// Summarize the effective configuration once every adapter is ready
+	log.GlobalInfo("startup configuration", startupConfig{
+		Port:         cfg.Port,
+		CacheBackend: "memory",
+		CacheTTL:     cfg.CacheTTL,
+		IdleTimeout:  browserPool.IdleTimeout(),
+		LogLevel:     cfg.LogLevel,
+		Proxy:        cfg.Proxy,
+		MaxTabs:      browserPool.MaxTabs(),
+		AdminToken:   cfg.AdminToken,
+	}.summaryFields()...)
 
-	log.GlobalInfo("starting server", "port", port)
-	if err := app.Listen(":" + port); err != nil {
+	// Start server
+	log.GlobalInfo("starting server", "port", cfg.Port)
+	if err := app.Listen(":" + cfg.Port); err != nil {
 		log.GlobalFatal("server failed", "error", err)
 		os.Exit(1)
 	}
 }
 
-// getCacheTTL returns the cache TTL from environment variable or default.
-func getCacheTTL() time.Duration {
-	ttlMinutes := os.Getenv("CACHE_TTL_MINUTES")
-	if ttlMinutes == "" {
-		return 5 * time.Minute
+// scrapePolicy returns the configuration disclosed at /api/v1/policy.
+// Scrapes are anonymous and never take screenshots, so both features are off.
+func scrapePolicy(cfg config.Config) web.ScrapePolicy {
+	policy := web.ScrapePolicy{
+		RateLimit:  cfg.RateLimit,
+		RateWindow: cfg.RateWindow,
+		CacheTTL:   cfg.CacheTTL,
+	}
+	if cfg.NotFoundMaxFailures > 0 {
+		policy.NegativeCacheTTL = cfg.NotFoundCoolDown
 	}
+	return policy
+}
 
-	minutes, err := strconv.Atoi(ttlMinutes)
-	if err != nil {
-		log.GlobalWarn("invalid CACHE_TTL_MINUTES, using default", "value", ttlMinutes)
-		return 5 * time.Minute
+// newScrapeThrottle returns the global scrape rate limit shared by all
+// clients, or nil when GLOBAL_SCRAPE_RPS is 0.
+func newScrapeThrottle(cfg config.Config) *usecases.Throttle {
+	if cfg.GlobalScrapeRPS == 0 {
+		return nil
+	}
+	return usecases.NewThrottle(cfg.GlobalScrapeRPS, cfg.GlobalScrapeBurst)
+}
+
+// newScrapeSpacer returns the minimum delay between consecutive scrapes, or
+// nil when SCRAPE_MIN_INTERVAL and SCRAPE_JITTER are both 0.
+func newScrapeSpacer(cfg config.Config) *usecases.Spacer {
+	if cfg.ScrapeMinInterval == 0 && cfg.ScrapeJitter == 0 {
+		return nil
 	}
 
-	return time.Duration(minutes) * time.Minute
+	log.GlobalInfo("scrape spacing enabled", "min_interval", cfg.ScrapeMinInterval, "jitter", cfg.ScrapeJitter)
+	return usecases.NewSpacer(cfg.ScrapeMinInterval, cfg.ScrapeJitter)
+}
+
+// notFoundPolicy returns when to stop re-scraping tweets whose text is missing.
+func notFoundPolicy(cfg config.Config) usecases.NotFoundPolicy {
+	return usecases.NotFoundPolicy{MaxFailures: cfg.NotFoundMaxFailures, CoolDown: cfg.NotFoundCoolDown}
+}
+
+// failureAlertPolicy returns when consecutive scrape failures are alerted.
+func failureAlertPolicy(cfg config.Config) usecases.FailureAlertPolicy {
+	policy := usecases.FailureAlertPolicy{Threshold: cfg.AlertFailureThreshold, CoolDown: cfg.AlertCoolDown}
+	log.GlobalInfo("failure alerts enabled", "threshold", policy.Threshold, "cool_down", policy.CoolDown)
+	return policy
+}
+
+// newContentPolicy builds the allow/deny policy of handles and tweet IDs.
+func newContentPolicy(cfg config.Config) *usecases.ContentPolicy {
+	allow, deny := cfg.ContentAllowlist, cfg.ContentDenylist
+	if len(allow) > 0 || len(deny) > 0 {
+		log.GlobalInfo("content policy enabled", "allow_entries", len(allow), "deny_entries", len(deny))
+	}
+	return usecases.NewContentPolicy(allow, deny)
+}
+
+// newLinkBlocklist returns the domains whose links are removed from tweet
+// text, or nil if there are none. Subdomains are blocked too.
+func newLinkBlocklist(cfg config.Config) *scraper.LinkBlocklist {
+	if len(cfg.BlockedLinkDomains) == 0 {
+		return nil
+	}
+	log.GlobalInfo("link blocklist enabled", "domains", len(cfg.BlockedLinkDomains))
+	return scraper.NewLinkBlocklist(cfg.BlockedLinkDomains)
+}
+
+// trackingParams returns the query parameter prefixes stripped from links
+// in tweet text; none keeps links as they are.
+func trackingParams(cfg config.Config) []string {
+	if len(cfg.StripLinkParams) > 0 {
+		log.GlobalInfo("link tracking params stripped", "prefixes", cfg.StripLinkParams)
+	}
+	return cfg.StripLinkParams
+}
+
+// newStats returns the registry of process counters served at /stats, or
+// nil unless STATS_ENABLED=1. Dropped log entries are read from logger.
+func newStats(cfg config.Config, logger *log.Logger) *stats.Registry {
+	if !cfg.StatsEnabled {
+		return nil
+	}
+	registry := stats.New()
+	registry.SetDroppedLogs(logger.DroppedCount)
+	log.GlobalInfo("stats endpoint enabled", "path", "/stats")
+	return registry
 }
 
-func getIsLocalEnv() bool {
-	value := os.Getenv("IS_LOCAL")
-	if value == "1" {
+// logEnvironment logs whether this is a local or production environment.
+func logEnvironment(isLocal bool) {
+	if isLocal {
 		log.GlobalInfo("environment detected", "env", "LOCAL")
-		return true
+		return
 	}
 	log.GlobalInfo("environment detected", "env", "PRODUCTION")
-	return false
 }
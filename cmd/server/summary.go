@@ -0,0 +1,63 @@
+package main
+
+import (
+	"net/url"
+	"time"
+
+	"sumariza-ai/pkg/log"
+)
+
+// redacted replaces secret values in the startup summary.
+const redacted = "[REDACTED]"
+
+// startupConfig is the effective configuration logged once on boot.
+type startupConfig struct {
+	Port         string
+	CacheBackend string
+	CacheTTL     time.Duration
+	IdleTimeout  time.Duration
+	LogLevel     log.Level
+	Proxy        string
+	MaxTabs      int
+	AdminToken   string
+}
+
+// summaryFields returns the configuration as log key/value pairs,
+// with proxy credentials and tokens redacted.
+func (c startupConfig) summaryFields() []any {
+	return []any{
+		"port", c.Port,
+		"cache_backend", c.CacheBackend,
+		"cache_ttl", c.CacheTTL.String(),
+		"idle_timeout", c.IdleTimeout.String(),
+		"log_level", c.LogLevel.String(),
+		"proxy", c.Proxy != "",
+		"proxy_url", redactURL(c.Proxy),
+		"max_tabs", c.MaxTabs,
+		"admin_token", redactSecret(c.AdminToken),
+	}
+}
+
+// redactURL hides the user info of a URL, keeping scheme and host.
+func redactURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return redacted
+	}
+	if u.User != nil {
+		u.User = url.User(redacted)
+	}
+	return u.String()
+}
+
+// redactSecret reports whether a secret is set without revealing it.
+func redactSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return redacted
+}
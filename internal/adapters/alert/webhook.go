@@ -0,0 +1,83 @@
+// Package alert delivers operator alerts to external services.
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"sumariza-ai/internal/usecases"
+)
+
+// webhookTimeout bounds a single webhook delivery.
+const webhookTimeout = 10 * time.Second
+
+// ErrWebhookStatus is returned when the webhook answers with a non-2xx status.
+var ErrWebhookStatus = errors.New("unexpected webhook status")
+
+// Webhook POSTs failure alerts as JSON to a URL (e.g. a Slack or
+// PagerDuty incoming webhook, or any custom receiver).
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhook creates a webhook alerter posting to url.
+func NewWebhook(url string) *Webhook {
+	return &Webhook{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// SetClient replaces the HTTP client used to deliver alerts, e.g. with the
+// shared client honoring the configured proxy. A nil client is ignored.
+func (w *Webhook) SetClient(client *http.Client) {
+	if client != nil {
+		w.client = client
+	}
+}
+
+// webhookPayload is the JSON body sent for a failure alert.
+type webhookPayload struct {
+	Event               string    `json:"event"`
+	Text                string    `json:"text"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastError           string    `json:"last_error"`
+	At                  time.Time `json:"at"`
+}
+
+// Alert posts the alert to the webhook.
+func (w *Webhook) Alert(ctx context.Context, alert usecases.FailureAlert) error {
+	body, err := json.Marshal(webhookPayload{
+		Event:               "scrape_failures",
+		Text:                fmt.Sprintf("Scraping failed %d times in a row: %s", alert.ConsecutiveFailures, alert.LastError),
+		ConsecutiveFailures: alert.ConsecutiveFailures,
+		LastError:           alert.LastError,
+		At:                  alert.At.UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%w: %d", ErrWebhookStatus, resp.StatusCode)
+	}
+	return nil
+}
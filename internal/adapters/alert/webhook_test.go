@@ -0,0 +1,149 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"sumariza-ai/internal/domain"
+	"sumariza-ai/internal/usecases"
+	"sumariza-ai/pkg/httpclient"
+)
+
+// failingScraper always fails with domain.ErrScrapingFailed.
+type failingScraper struct{}
+
+func (failingScraper) Scrape(ctx context.Context, tweetID string) (*domain.Tweet, error) {
+	return nil, domain.ErrScrapingFailed
+}
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newReceiver starts a webhook receiver that forwards every payload.
+func newReceiver(t *testing.T) (*httptest.Server, <-chan webhookPayload) {
+	t.Helper()
+	received := make(chan webhookPayload, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload webhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		received <- payload
+	}))
+	t.Cleanup(server.Close)
+	return server, received
+}
+
+func TestWebhook_Alert_PostsJSON(t *testing.T) {
+	// Arrange
+	server, received := newReceiver(t)
+	webhook := NewWebhook(server.URL)
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// Act
+	err := webhook.Alert(context.Background(), usecases.FailureAlert{
+		ConsecutiveFailures: 5,
+		LastError:           "failed to scrape tweet",
+		At:                  at,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	payload := <-received
+	if payload.Event != "scrape_failures" || payload.ConsecutiveFailures != 5 ||
+		payload.LastError != "failed to scrape tweet" || !payload.At.Equal(at) {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+}
+
+func TestWebhook_SetClient_UsesInjectedTransport(t *testing.T) {
+	// Arrange
+	var requests []*http.Request
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req)
+		return httptest.NewRecorder().Result(), nil
+	})
+	client, err := httpclient.New(httpclient.Options{Transport: transport})
+	if err != nil {
+		t.Fatalf("httpclient.New() error = %v", err)
+	}
+	webhook := NewWebhook("https://hooks.example.com/alerts")
+	webhook.SetClient(client)
+
+	// Act
+	err = webhook.Alert(context.Background(), usecases.FailureAlert{ConsecutiveFailures: 5})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(requests) != 1 || requests[0].URL.String() != "https://hooks.example.com/alerts" {
+		t.Errorf("expected the alert to go through the injected transport, got %v", requests)
+	}
+}
+
+func TestWebhook_Alert_NonOKStatus_ReturnsErrWebhookStatus(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	// Act
+	err := NewWebhook(server.URL).Alert(context.Background(), usecases.FailureAlert{})
+
+	// Assert
+	if !errors.Is(err, ErrWebhookStatus) {
+		t.Errorf("expected ErrWebhookStatus, got %v", err)
+	}
+}
+
+func TestWebhook_ScrapeFailures_AlertsOnThresholdThenCoolsDown(t *testing.T) {
+	// Arrange
+	server, received := newReceiver(t)
+	uc := usecases.NewScrapeTweetUseCase(failingScraper{})
+	uc.SetFailureAlerter(NewWebhook(server.URL), usecases.FailureAlertPolicy{Threshold: 3, CoolDown: time.Hour})
+
+	// Act - two failures stay below the threshold
+	for i := 0; i < 2; i++ {
+		_, _ = uc.Execute(context.Background(), "123", "user")
+	}
+
+	// Assert
+	select {
+	case payload := <-received:
+		t.Fatalf("alert sent below threshold: %+v", payload)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Act - the third failure crosses it, later ones fall in the cool-down
+	for i := 0; i < 5; i++ {
+		_, _ = uc.Execute(context.Background(), "123", "user")
+	}
+
+	// Assert
+	select {
+	case payload := <-received:
+		if payload.ConsecutiveFailures != 3 {
+			t.Errorf("consecutive failures: got %d, want 3", payload.ConsecutiveFailures)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no alert sent when the threshold was crossed")
+	}
+	select {
+	case payload := <-received:
+		t.Errorf("alert sent within the cool-down: %+v", payload)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -6,12 +6,34 @@ import (
 	"time"
 
 	"sumariza-ai/internal/domain"
+	"sumariza-ai/internal/usecases"
+	"sumariza-ai/pkg/clock"
 )
 
+// failureRetention is how long failure counts are kept after the last failure.
+// Longer not-found cool-downs are cut short by it.
+const failureRetention = 24 * time.Hour
+
 // MemoryCache is an in-memory cache with TTL support.
+// It also tracks per-tweet scrape failures for negative caching.
 type MemoryCache struct {
 	tweets sync.Map
 	ttl    time.Duration
+
+	// settingsMu guards the fields below, also read by the cleanup goroutine
+	settingsMu sync.RWMutex
+	clock      clock.Clock
+	// staleGrace keeps expired entries around for GetStale (see SetStaleGrace)
+	staleGrace time.Duration
+
+	failuresMu sync.Mutex
+	failures   map[string]*failureEntry
+}
+
+// failureEntry holds the failure count of a tweet ID.
+type failureEntry struct {
+	count       int
+	lastFailure time.Time
 }
 
 // cacheEntry holds a cached tweet with expiration metadata.
@@ -23,11 +45,52 @@ type cacheEntry struct {
 
 // NewMemoryCache creates a new in-memory cache with the specified TTL.
 func NewMemoryCache(ttl time.Duration) *MemoryCache {
-	cache := &MemoryCache{ttl: ttl}
+	cache := &MemoryCache{
+		ttl:      ttl,
+		clock:    clock.Real(),
+		failures: make(map[string]*failureEntry),
+	}
 	go cache.cleanup()
 	return cache
 }
 
+// SetClock replaces the clock used for expiry (for tests).
+func (c *MemoryCache) SetClock(clk clock.Clock) {
+	c.settingsMu.Lock()
+	defer c.settingsMu.Unlock()
+
+	c.clock = clk
+}
+
+// SetStaleGrace keeps expired entries for grace past their expiry so GetStale
+// can still return them. Get never serves them.
+func (c *MemoryCache) SetStaleGrace(grace time.Duration) {
+	c.settingsMu.Lock()
+	defer c.settingsMu.Unlock()
+
+	c.staleGrace = grace
+}
+
+// settings returns the current time and the stale grace.
+func (c *MemoryCache) settings() (now time.Time, staleGrace time.Duration) {
+	c.settingsMu.RLock()
+	defer c.settingsMu.RUnlock()
+
+	return c.clock.Now(), c.staleGrace
+}
+
+// now returns the current time of the cache's clock.
+func (c *MemoryCache) now() time.Time {
+	now, _ := c.settings()
+	return now
+}
+
+// expired reports whether an entry expiring at expiresAt is no longer served.
+// An entry expires exactly at expiresAt: it lives for [scrapedAt, scrapedAt+ttl).
+func expired(now, expiresAt time.Time) bool {
+	return !now.Before(expiresAt)
+}
+
 // NormalizedKey returns the cache key for a tweet: /{username}/status/{id}
 func NormalizedKey(username, tweetID string) string {
 	return fmt.Sprintf("/%s/status/%s", username, tweetID)
@@ -36,25 +99,57 @@ func NormalizedKey(username, tweetID string) string {
 // Get retrieves a tweet from the cache.
 // Returns the tweet and true if found and not expired, otherwise nil and false.
 func (c *MemoryCache) Get(username, tweetID string) (*domain.Tweet, bool) {
+	tweet, _, found := c.GetWithMeta(username, tweetID)
+	return tweet, found
+}
+
+// GetWithMeta is like Get but also returns when the entry was scraped and expires.
+func (c *MemoryCache) GetWithMeta(username, tweetID string) (*domain.Tweet, usecases.CacheMeta, bool) {
 	key := NormalizedKey(username, tweetID)
 	value, ok := c.tweets.Load(key)
 	if !ok {
-		return nil, false
+		return nil, usecases.CacheMeta{}, false
 	}
 
 	entry := value.(*cacheEntry)
-	if time.Now().After(entry.expiresAt) {
-		c.tweets.Delete(key)
-		return nil, false
+	if now, staleGrace := c.settings(); expired(now, entry.expiresAt) {
+		if expired(now, entry.expiresAt.Add(staleGrace)) {
+			c.tweets.Delete(key)
+		}
+		return nil, usecases.CacheMeta{}, false
 	}
 
-	return entry.tweet, true
+	return entry.tweet, usecases.CacheMeta{
+		ScrapedAt: entry.scrapedAt,
+		ExpiresAt: entry.expiresAt,
+	}, true
+}
+
+// GetStale returns the cached tweet even if it has expired, as long as it is
+// still within the stale grace. The meta is marked Stale once expired.
+func (c *MemoryCache) GetStale(username, tweetID string) (*domain.Tweet, usecases.CacheMeta, bool) {
+	value, ok := c.tweets.Load(NormalizedKey(username, tweetID))
+	if !ok {
+		return nil, usecases.CacheMeta{}, false
+	}
+
+	entry := value.(*cacheEntry)
+	now, staleGrace := c.settings()
+	if expired(now, entry.expiresAt.Add(staleGrace)) {
+		return nil, usecases.CacheMeta{}, false
+	}
+
+	return entry.tweet, usecases.CacheMeta{
+		ScrapedAt: entry.scrapedAt,
+		ExpiresAt: entry.expiresAt,
+		Stale:     expired(now, entry.expiresAt),
+	}, true
 }
 
 // Set stores a tweet in the cache with the configured TTL.
 func (c *MemoryCache) Set(username, tweetID string, tweet *domain.Tweet) {
 	key := NormalizedKey(username, tweetID)
-	now := time.Now()
+	now := c.now()
 	c.tweets.Store(key, &cacheEntry{
 		tweet:     tweet,
 		expiresAt: now.Add(c.ttl),
@@ -62,17 +157,62 @@ func (c *MemoryCache) Set(username, tweetID string, tweet *domain.Tweet) {
 	})
 }
 
-// cleanup periodically removes expired entries from the cache.
+// RecordFailure counts a failure for tweetID and returns the new count.
+func (c *MemoryCache) RecordFailure(tweetID string) int {
+	c.failuresMu.Lock()
+	defer c.failuresMu.Unlock()
+
+	entry, ok := c.failures[tweetID]
+	if !ok {
+		entry = &failureEntry{}
+		c.failures[tweetID] = entry
+	}
+	entry.count++
+	entry.lastFailure = c.now()
+
+	return entry.count
+}
+
+// Failures returns the failure count of tweetID and when it last failed.
+func (c *MemoryCache) Failures(tweetID string) (int, time.Time) {
+	c.failuresMu.Lock()
+	defer c.failuresMu.Unlock()
+
+	entry, ok := c.failures[tweetID]
+	if !ok {
+		return 0, time.Time{}
+	}
+	return entry.count, entry.lastFailure
+}
+
+// ClearFailures forgets the failures of tweetID.
+func (c *MemoryCache) ClearFailures(tweetID string) {
+	c.failuresMu.Lock()
+	defer c.failuresMu.Unlock()
+
+	delete(c.failures, tweetID)
+}
+
+// cleanup periodically removes expired entries from the cache, once past
+// their stale grace.
 func (c *MemoryCache) cleanup() {
 	ticker := time.NewTicker(1 * time.Minute)
 	for range ticker.C {
-		now := time.Now()
+		now, staleGrace := c.settings()
 		c.tweets.Range(func(key, value interface{}) bool {
 			entry := value.(*cacheEntry)
-			if now.After(entry.expiresAt) {
+			if expired(now, entry.expiresAt.Add(staleGrace)) {
 				c.tweets.Delete(key)
 			}
 			return true
 		})
+
+		c.failuresMu.Lock()
+		for tweetID, entry := range c.failures {
+			if now.Sub(entry.lastFailure) > failureRetention {
+				delete(c.failures, tweetID)
+			}
+		}
+		c.failuresMu.Unlock()
 	}
 }
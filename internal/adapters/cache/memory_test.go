@@ -6,6 +6,7 @@ import (
 
 	"sumariza-ai/internal/adapters/cache"
 	"sumariza-ai/internal/domain"
+	"sumariza-ai/pkg/clock"
 )
 
 func TestNormalizedKey_ReturnsCorrectFormat(t *testing.T) {
@@ -65,7 +66,9 @@ func TestMemoryCache_GetNonExistent_ReturnsNotFound(t *testing.T) {
 
 func TestMemoryCache_ExpiredEntry_ReturnsNotFound(t *testing.T) {
 	// Arrange
-	c := cache.NewMemoryCache(10 * time.Millisecond)
+	fake := clock.NewFake(time.Now())
+	c := cache.NewMemoryCache(10 * time.Minute)
+	c.SetClock(fake)
 	tweet := &domain.Tweet{
 		ID:       "123",
 		Username: "testuser",
@@ -73,7 +76,7 @@ func TestMemoryCache_ExpiredEntry_ReturnsNotFound(t *testing.T) {
 
 	// Act
 	c.Set("testuser", "123", tweet)
-	time.Sleep(20 * time.Millisecond) // Wait for expiration
+	fake.Advance(11 * time.Minute) // Past expiration
 	_, found := c.Get("testuser", "123")
 
 	// Assert
@@ -82,6 +85,71 @@ func TestMemoryCache_ExpiredEntry_ReturnsNotFound(t *testing.T) {
 	}
 }
 
+func TestMemoryCache_JustBeforeExpiry_ReturnsTweet(t *testing.T) {
+	// Arrange
+	fake := clock.NewFake(time.Now())
+	c := cache.NewMemoryCache(10 * time.Minute)
+	c.SetClock(fake)
+	c.Set("testuser", "123", &domain.Tweet{ID: "123"})
+
+	// Act
+	fake.Advance(10*time.Minute - time.Nanosecond)
+	_, found := c.Get("testuser", "123")
+
+	// Assert
+	if !found {
+		t.Error("expected tweet to be served until its expiry instant")
+	}
+}
+
+func TestMemoryCache_ExactlyAtExpiry_ReturnsNotFound(t *testing.T) {
+	// Arrange - an entry expires precisely at scrapedAt+TTL
+	fake := clock.NewFake(time.Now())
+	c := cache.NewMemoryCache(10 * time.Minute)
+	c.SetClock(fake)
+	c.Set("testuser", "123", &domain.Tweet{ID: "123"})
+
+	// Act
+	fake.Advance(10 * time.Minute)
+	_, meta, found := c.GetWithMeta("testuser", "123")
+
+	// Assert
+	if found {
+		t.Errorf("expected entry expiring now to not be found, got meta %+v", meta)
+	}
+}
+
+func TestMemoryCache_GetStale_ServesExpiredEntryWithinGrace(t *testing.T) {
+	// Arrange
+	fake := clock.NewFake(time.Now())
+	c := cache.NewMemoryCache(10 * time.Minute)
+	c.SetClock(fake)
+	c.SetStaleGrace(30 * time.Minute)
+	c.Set("testuser", "123", &domain.Tweet{ID: "123"})
+	fake.Advance(20 * time.Minute)
+
+	// Act
+	_, found := c.Get("testuser", "123")
+	tweet, meta, stale := c.GetStale("testuser", "123")
+
+	// Assert
+	if found {
+		t.Error("expected Get to skip the expired entry")
+	}
+	if !stale || tweet == nil || tweet.ID != "123" {
+		t.Fatalf("expected the expired tweet, got %v (found=%v)", tweet, stale)
+	}
+	if !meta.Stale {
+		t.Error("expected meta to be marked stale")
+	}
+
+	// Past the grace the entry is gone
+	fake.Advance(20 * time.Minute)
+	if _, _, stale := c.GetStale("testuser", "123"); stale {
+		t.Error("expected entry past its grace to not be found")
+	}
+}
+
 func TestMemoryCache_DifferentUsers_SameTweetID_AreSeparate(t *testing.T) {
 	// Arrange
 	c := cache.NewMemoryCache(5 * time.Minute)
@@ -139,3 +207,96 @@ func TestMemoryCache_OverwriteExisting_UpdatesTweet(t *testing.T) {
 		t.Errorf("got %v, want 'Updated'", result.Content.Text)
 	}
 }
+
+func TestMemoryCache_GetWithMeta_ReturnsAccurateTimestamps(t *testing.T) {
+	// Arrange
+	ttl := 5 * time.Minute
+	c := cache.NewMemoryCache(ttl)
+	before := time.Now()
+
+	// Act
+	c.Set("user", "123", &domain.Tweet{ID: "123"})
+	after := time.Now()
+	tweet, meta, found := c.GetWithMeta("user", "123")
+
+	// Assert
+	if !found {
+		t.Fatal("expected tweet to be found")
+	}
+	if tweet.ID != "123" {
+		t.Errorf("ID: got %v, want 123", tweet.ID)
+	}
+	if meta.ScrapedAt.Before(before) || meta.ScrapedAt.After(after) {
+		t.Errorf("ScrapedAt %v not within Set call [%v, %v]", meta.ScrapedAt, before, after)
+	}
+	if got := meta.ExpiresAt.Sub(meta.ScrapedAt); got != ttl {
+		t.Errorf("ExpiresAt - ScrapedAt: got %v, want %v", got, ttl)
+	}
+}
+
+func TestMemoryCache_GetWithMeta_NotFound_ReturnsZeroMeta(t *testing.T) {
+	// Arrange
+	c := cache.NewMemoryCache(5 * time.Minute)
+
+	// Act
+	_, meta, found := c.GetWithMeta("nobody", "1")
+
+	// Assert
+	if found {
+		t.Error("expected tweet to not be found")
+	}
+	if !meta.ScrapedAt.IsZero() || !meta.ExpiresAt.IsZero() {
+		t.Errorf("expected zero meta, got %+v", meta)
+	}
+}
+
+func TestMemoryCache_Failures_CountAndClear(t *testing.T) {
+	// Arrange
+	c := cache.NewMemoryCache(time.Minute)
+
+	// Act
+	c.RecordFailure("123")
+	count := c.RecordFailure("123")
+	gotCount, lastFailure := c.Failures("123")
+
+	// Assert
+	if count != 2 || gotCount != 2 {
+		t.Errorf("count: got %d/%d, want 2", count, gotCount)
+	}
+	if time.Since(lastFailure) > time.Second {
+		t.Errorf("lastFailure: got %v, want about now", lastFailure)
+	}
+	if other, _ := c.Failures("456"); other != 0 {
+		t.Errorf("unrelated tweet failures: got %d, want 0", other)
+	}
+
+	c.ClearFailures("123")
+	if cleared, _ := c.Failures("123"); cleared != 0 {
+		t.Errorf("after clear: got %d, want 0", cleared)
+	}
+}
+
+func TestMemoryCache_SetClock_SafeWithConcurrentReads(t *testing.T) {
+	// Arrange
+	c := cache.NewMemoryCache(time.Minute)
+	c.Set("user", "123", &domain.Tweet{ID: "123"})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			c.Get("user", "123")
+		}
+	}()
+
+	// Act - run with -race to catch unguarded writes
+	for i := 0; i < 100; i++ {
+		c.SetClock(clock.NewFake(time.Now()))
+		c.SetStaleGrace(time.Duration(i) * time.Second)
+	}
+	<-done
+
+	// Assert
+	if _, found := c.Get("user", "123"); !found {
+		t.Error("expected the entry to still be served")
+	}
+}
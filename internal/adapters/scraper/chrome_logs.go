@@ -0,0 +1,66 @@
+package scraper
+
+import "sync"
+
+// maxChromeLogTail bounds the Chrome output kept for failure logs. Chrome
+// can print megabytes over a long run; only the most recent part helps
+// diagnose a crash.
+const maxChromeLogTail = 8 << 10
+
+// truncatedChromeLogPrefix marks a tail whose start was dropped.
+const truncatedChromeLogPrefix = "[truncated] "
+
+// chromeLogTail keeps the last max bytes written to it. It is the
+// CombinedOutput writer of Chrome, so it is written from chromedp's
+// goroutine while scrapes read it; all methods are safe for concurrent use.
+type chromeLogTail struct {
+	mu        sync.Mutex
+	buf       []byte
+	max       int
+	truncated bool
+}
+
+// newChromeLogTail creates a tail keeping up to max bytes.
+func newChromeLogTail(max int) *chromeLogTail {
+	return &chromeLogTail{max: max}
+}
+
+// Write appends p, dropping the oldest bytes beyond the limit.
+func (t *chromeLogTail) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.buf = append(t.buf, p...)
+	if excess := len(t.buf) - t.max; excess > 0 {
+		t.buf = append(t.buf[:0], t.buf[excess:]...)
+		t.truncated = true
+	}
+	return len(p), nil
+}
+
+// String returns the kept output, prefixed with truncatedChromeLogPrefix
+// when older output was dropped. A nil tail returns "".
+func (t *chromeLogTail) String() string {
+	if t == nil {
+		return ""
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.truncated {
+		return truncatedChromeLogPrefix + string(t.buf)
+	}
+	return string(t.buf)
+}
+
+// Reset drops the kept output, e.g. before Chrome is started again.
+func (t *chromeLogTail) Reset() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.buf = t.buf[:0]
+	t.truncated = false
+}
@@ -0,0 +1,121 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/chromedp/chromedp"
+
+	"sumariza-ai/internal/domain"
+	"sumariza-ai/pkg/log"
+	"sumariza-ai/pkg/log/transporters"
+)
+
+// loggingTestPool is a TestBrowserPool with Chrome output to report.
+type loggingTestPool struct {
+	*TestBrowserPool
+	tail string
+}
+
+func (p *loggingTestPool) ChromeLogTail() string {
+	return p.tail
+}
+
+// captureLogs routes the global logger to a buffer until the test ends.
+// The returned function flushes the logger and returns the output.
+func captureLogs(t *testing.T) func() string {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := log.Default()
+	logger := log.New(log.Info, transporters.NewStdoutWithWriter(&buf))
+	log.SetDefault(logger)
+	t.Cleanup(func() { log.SetDefault(previous) })
+	return func() string {
+		logger.Close()
+		return buf.String()
+	}
+}
+
+func TestChromeLogTail_KeepsMostRecentOutput(t *testing.T) {
+	// Arrange
+	tail := newChromeLogTail(10)
+
+	// Act
+	tail.Write([]byte("0123456789"))
+	tail.Write([]byte("abcd"))
+
+	// Assert
+	if got, want := tail.String(), truncatedChromeLogPrefix+"456789abcd"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestChromeLogTail_Reset_ClearsTruncation(t *testing.T) {
+	// Arrange
+	tail := newChromeLogTail(4)
+	tail.Write([]byte("too long"))
+
+	// Act
+	tail.Reset()
+	tail.Write([]byte("ok"))
+
+	// Assert
+	if got := tail.String(); got != "ok" {
+		t.Errorf("got %q, want %q", got, "ok")
+	}
+}
+
+func TestScrapeResult_Failure_LogsChromeTail(t *testing.T) {
+	// Arrange
+	output := captureLogs(t)
+	s := newMockedScraper(func(ctx context.Context, actions ...chromedp.Action) error {
+		return errors.New("websocket closed")
+	})
+	s.pool = &loggingTestPool{
+		TestBrowserPool: NewTestBrowserPool(1),
+		tail:            "[0101/120000.000:FATAL:render_process_host.cc] renderer crashed",
+	}
+
+	// Act
+	s.ScrapeResult(context.Background(), "1")
+
+	// Assert
+	logs := output()
+	if !strings.Contains(logs, `"chrome_logs"`) || !strings.Contains(logs, "renderer crashed") {
+		t.Errorf("expected the failure log to carry the chrome output, got: %s", logs)
+	}
+}
+
+func TestScrapeResult_BrowserUnavailable_OmitsChromeTail(t *testing.T) {
+	// Arrange
+	output := captureLogs(t)
+	s := newMockedScraper(nil)
+	s.pool = unavailablePool{&loggingTestPool{
+		TestBrowserPool: NewTestBrowserPool(1),
+		tail:            "launch output, already logged by the pool",
+	}}
+
+	// Act
+	s.ScrapeResult(context.Background(), "1")
+
+	// Assert
+	logs := output()
+	if !strings.Contains(logs, "scrape failed") {
+		t.Fatalf("expected a failure log, got: %s", logs)
+	}
+	if strings.Contains(logs, "already logged by the pool") {
+		t.Errorf("launch failures should not repeat the chrome output, got: %s", logs)
+	}
+}
+
+// unavailablePool fails every scrape as if Chrome could not be launched.
+type unavailablePool struct {
+	*loggingTestPool
+}
+
+func (unavailablePool) WithTabCtx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return domain.ErrBrowserUnavailable
+}
@@ -2,22 +2,41 @@ package scraper
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"strings"
 	"sync"
 	"time"
 
+	"sumariza-ai/internal/domain"
+	"sumariza-ai/pkg/clock"
 	"sumariza-ai/pkg/log"
+	"sumariza-ai/pkg/stats"
 
 	// "github.com/chromedp/cdproto/network"
 	// "github.com/chromedp/cdproto/storage"
 	"github.com/chromedp/chromedp"
 )
 
-const defaultIdleTimeout = 5 * time.Minute
+const (
+	defaultIdleTimeout  = 5 * time.Minute
+	defaultCloseTimeout = 10 * time.Second
+
+	// defaultStartAttempts and defaultStartBackoff retry transient launch
+	// failures (port races, slow CI); the backoff grows with each attempt.
+	defaultStartAttempts = 3
+	defaultStartBackoff  = 500 * time.Millisecond
+
+	// defaultBrowserLang pins the browser locale unless SetLang changes it.
+	// Twitter localizes timestamps and UI strings, and the parser expects en-US.
+	defaultBrowserLang = "en-US"
+)
 
 // BrowserPool manages a single Chrome instance with a single reusable tab.
 // Chrome is started lazily on first request and stopped after idle timeout.
+//
+// The mutex only guards pool state transitions (start/stop/health/idle timer).
+// Work on the tab runs outside the mutex and is serialized by tabSem instead.
 type BrowserPool struct {
 	allocCtx   context.Context
 	browserCtx context.Context
@@ -27,18 +46,53 @@ type BrowserPool struct {
 	opts       []chromedp.ExecAllocatorOption
 
 	mu         sync.Mutex
-	chromeLogs *strings.Builder
+	chromeLogs *chromeLogTail
+
+	// Tab access: one slot per tab, inUse counts active holders
+	tabSem chan struct{}
+	inUse  int
+
+	// ensureRunning starts Chrome if needed; defaults to ensureBrowserRunning.
+	// Must be called with mutex held.
+	ensureRunning func() error
+
+	// start makes one attempt to launch Chrome; defaults to launchBrowserLocked.
+	// Must be called with mutex held.
+	start func() error
+
+	// startAttempts bounds launch attempts, startBackoff is the wait after the
+	// first failure (doubled after each further one).
+	startAttempts int
+	startBackoff  time.Duration
+
+	// startErr is the last launch failure, cleared once Chrome starts.
+	startErr error
+
+	// restartAfter recycles Chrome once this many scrapes used the current
+	// instance, bounding memory leaked across navigations. Zero disables it.
+	restartAfter int
+	scrapes      int
+
+	// run executes chromedp actions; defaults to chromedp.Run.
+	run func(ctx context.Context, actions ...chromedp.Action) error
+
+	// lang is the browser locale applied at launch (see SetLang)
+	lang string
 
 	// Idle timeout management
+	clock       clock.Clock
 	idleTimeout time.Duration
-	idleTimer   *time.Timer
+	idleTimer   clock.Timer
 	running     bool
+
+	// stats counts browser launches; nil disables counting.
+	stats *stats.Registry
 }
 
 // NewBrowserPool creates a browser pool with one Chrome instance and one reusable tab.
 // Chrome starts lazily on first request and stops after 5 minutes of inactivity.
 func NewBrowserPool(options []chromedp.ExecAllocatorOption) (*BrowserPool, error) {
-	chromeLogs := &strings.Builder{}
+	chromeLogs := newChromeLogTail(maxChromeLogTail)
 
 	opts := append(chromedp.DefaultExecAllocatorOptions[:],
 		chromedp.Flag("headless", true),
@@ -72,9 +126,18 @@ func NewBrowserPool(options []chromedp.ExecAllocatorOption) (*BrowserPool, error
 	bp := &BrowserPool{
 		opts:        opts,
 		chromeLogs:  chromeLogs,
+		tabSem:      make(chan struct{}, 1),
+		clock:       clock.Real(),
 		idleTimeout: defaultIdleTimeout,
+		lang:        defaultBrowserLang,
 		running:     false,
+
+		startAttempts: defaultStartAttempts,
+		startBackoff:  defaultStartBackoff,
 	}
+	bp.ensureRunning = bp.ensureBrowserRunning
+	bp.start = bp.launchBrowserLocked
+	bp.run = chromedp.Run
 
 	// Lazy start - Chrome will start on first request
 	log.GlobalInfo("browser pool initialized (lazy start)", "idle_timeout", defaultIdleTimeout)
@@ -82,6 +145,108 @@ func NewBrowserPool(options []chromedp.ExecAllocatorOption) (*BrowserPool, error
 	return bp, nil
 }
 
+// SetLang sets the browser locale (default en-US), applied the next time
+// Chrome starts. A blank lang keeps the current locale.
+func (bp *BrowserPool) SetLang(lang string) {
+	lang = strings.TrimSpace(lang)
+	if lang == "" {
+		return
+	}
+
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	bp.lang = lang
+}
+
+// langOptions returns the allocator options applying lang to Chrome.
+func langOptions(lang string) []chromedp.ExecAllocatorOption {
+	var opts []chromedp.ExecAllocatorOption
+	for name, value := range langFlags(lang) {
+		opts = append(opts, chromedp.Flag(name, value))
+	}
+	return append(opts, chromedp.Env(langEnv(lang)...))
+}
+
+// langFlags returns the Chrome flags setting the UI locale (--lang) and the
+// Accept-Language header sent with every request (--accept-lang).
+func langFlags(lang string) map[string]interface{} {
+	return map[string]interface{}{
+		"lang":        lang,
+		"accept-lang": lang,
+	}
+}
+
+// langEnv returns the environment for the Chrome process. Linux builds ignore
+// --lang and read the locale from LANGUAGE, which uses "en_US" spelling.
+func langEnv(lang string) []string {
+	return []string{"LANGUAGE=" + strings.ReplaceAll(lang, "-", "_")}
+}
+
+// SetClock replaces the clock driving the idle timeout (for tests).
+func (bp *BrowserPool) SetClock(c clock.Clock) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	bp.clock = c
+}
+
+// SetStartAttempts sets how many times a Chrome launch is attempted before
+// giving up. Values below 1 mean a single attempt.
+func (bp *BrowserPool) SetStartAttempts(attempts int) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	bp.startAttempts = attempts
+}
+
+// SetRestartAfter makes the pool stop Chrome once n scrapes used the current
+// instance; the next scrape starts a fresh one. Zero disables recycling.
+func (bp *BrowserPool) SetRestartAfter(n int) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	bp.restartAfter = n
+}
+
+// SetStats sets the registry counting browser launches. A nil registry
+// disables counting.
+func (bp *BrowserPool) SetStats(registry *stats.Registry) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	bp.stats = registry
+}
+
+// SetIdleTimeout sets how long Chrome may sit idle before it is stopped
+// (default 5 minutes). Non-positive values keep the current timeout.
+func (bp *BrowserPool) SetIdleTimeout(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	bp.idleTimeout = d
+}
+
+// IdleTimeout returns how long Chrome may sit idle before it is stopped.
+func (bp *BrowserPool) IdleTimeout() time.Duration {
+	return bp.idleTimeout
+}
+
+// ChromeLogTail returns the most recent output of the current Chrome
+// instance, at most maxChromeLogTail bytes, for failure logs.
+func (bp *BrowserPool) ChromeLogTail() string {
+	return bp.chromeLogs.String()
+}
+
+// MaxTabs returns how many scrapes can use the browser at the same time.
+func (bp *BrowserPool) MaxTabs() int {
+	return cap(bp.tabSem)
+}
+
 // startBrowser initializes Chrome and creates the persistent tab.
 // Must be called with mutex NOT held.
 func (bp *BrowserPool) startBrowser() error {
@@ -91,34 +256,60 @@ func (bp *BrowserPool) startBrowser() error {
 	return bp.startBrowserLocked()
 }
 
-// startBrowserLocked initializes Chrome. Must be called with mutex held.
+// startBrowserLocked initializes Chrome, retrying transient launch failures
+// with a growing backoff. The last error is returned once every attempt failed.
+// Must be called with mutex held.
 func (bp *BrowserPool) startBrowserLocked() error {
+	attempts := max(bp.startAttempts, 1)
+	backoff := bp.startBackoff
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = bp.start(); err == nil {
+			bp.stats.BrowserStarted()
+			return nil
+		}
+		if attempt == attempts {
+			break
+		}
+
+		log.GlobalWarn("browser pool chrome launch failed, retrying",
+			"attempt", attempt,
+			"max_attempts", attempts,
+			"backoff", backoff,
+			"error", err)
+		<-bp.clock.After(backoff)
+		backoff *= 2
+	}
+
+	log.GlobalError("browser pool chrome launch gave up", "attempts", attempts, "error", err)
+	return err
+}
+
+// launchBrowserLocked makes one attempt to start Chrome and its tab.
+// Must be called with mutex held.
+func (bp *BrowserPool) launchBrowserLocked() error {
 	// Cleanup previous instance if any
 	if bp.cancel != nil {
 		bp.cancel()
 	}
-	if bp.chromeLogs != nil {
-		bp.chromeLogs.Reset()
-	}
+	bp.chromeLogs.Reset()
 
-	log.GlobalDebug("browser pool starting chrome")
+	log.GlobalDebug("browser pool starting chrome", "lang", bp.lang)
 
-	// Create allocator
-	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), bp.opts...)
+	// Create allocator; caller options come last so they can override the locale
+	opts := append(langOptions(bp.lang), bp.opts...)
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), opts...)
 
 	// Create browser context
 	browserCtx, _ := chromedp.NewContext(allocCtx)
 
 	// Start Chrome
 	if err := chromedp.Run(browserCtx); err != nil {
-		chromeLogs := ""
-		if bp.chromeLogs != nil && bp.chromeLogs.Len() > 0 {
-			chromeLogs = bp.chromeLogs.String()
-		}
 		allocCancel()
 		log.GlobalError("browser pool chrome startup failed",
 			"error", err,
-			"chrome_logs", chromeLogs)
+			"chrome_logs", bp.chromeLogs.String())
 		return err
 	}
 
@@ -127,15 +318,11 @@ func (bp *BrowserPool) startBrowserLocked() error {
 
 	// Initialize the tab
 	if err := chromedp.Run(tabCtx, chromedp.Navigate("about:blank")); err != nil {
-		chromeLogs := ""
-		if bp.chromeLogs != nil && bp.chromeLogs.Len() > 0 {
-			chromeLogs = bp.chromeLogs.String()
-		}
 		tabCancel()
 		allocCancel()
 		log.GlobalError("browser pool tab initialization failed",
 			"error", err,
-			"chrome_logs", chromeLogs)
+			"chrome_logs", bp.chromeLogs.String())
 		return err
 	}
 
@@ -145,13 +332,14 @@ func (bp *BrowserPool) startBrowserLocked() error {
 	bp.tabCancel = tabCancel
 	bp.cancel = allocCancel
 	bp.running = true
+	bp.scrapes = 0
 
 	log.GlobalInfo("browser pool chrome started")
 	return nil
 }
 
-// stopBrowserLocked stops Chrome. Must be called with mutex held.
-func (bp *BrowserPool) stopBrowserLocked() {
+// stopBrowserLocked stops Chrome, logging why. Must be called with mutex held.
+func (bp *BrowserPool) stopBrowserLocked(reason string) {
 	if !bp.running {
 		return
 	}
@@ -169,8 +357,9 @@ func (bp *BrowserPool) stopBrowserLocked() {
 	bp.browserCtx = nil
 	bp.allocCtx = nil
 	bp.running = false
+	bp.scrapes = 0
 
-	log.GlobalInfo("browser pool chrome stopped (idle timeout)")
+	log.GlobalInfo("browser pool chrome stopped", "reason", reason)
 }
 
 // resetIdleTimer resets the idle timeout timer.
@@ -182,13 +371,14 @@ func (bp *BrowserPool) resetIdleTimer() {
 	}
 
 	// Start new timer
-	bp.idleTimer = time.AfterFunc(bp.idleTimeout, func() {
+	bp.idleTimer = bp.clock.AfterFunc(bp.idleTimeout, func() {
 		bp.mu.Lock()
 		defer bp.mu.Unlock()
 
-		if bp.running {
+		// A tab in use is never idle, even if the timer raced with acquisition
+		if bp.running && bp.inUse == 0 {
 			log.GlobalInfo("browser pool idle timeout reached", "timeout", bp.idleTimeout)
-			bp.stopBrowserLocked()
+			bp.stopBrowserLocked("idle timeout")
 		}
 	})
 }
@@ -243,37 +433,52 @@ func (bp *BrowserPool) ensureBrowserRunning() error {
 	}
 
 	log.GlobalDebug("browser pool ensuring chrome is running")
-	return bp.startBrowserLocked()
+	if err := bp.startBrowserLocked(); err != nil {
+		// Missing binary, sandbox issue...: report a typed error instead of
+		// the raw chromedp one
+		bp.startErr = fmt.Errorf("%w: %w", domain.ErrBrowserUnavailable, err)
+		return bp.startErr
+	}
+	bp.startErr = nil
+	return nil
 }
 
-// Execute runs chromedp actions with proper locking and health management.
-// This is the main entry point for scraping operations.
-func (bp *BrowserPool) Execute(ctx context.Context, actions ...chromedp.Action) error {
+// Ping checks that Chrome responds by navigating the tab to about:blank,
+// without touching Twitter. A stopped pool is healthy (Chrome starts on demand)
+// unless its last launch failed, and a tab busy scraping is proof of life, so
+// Ping never starts Chrome, waits for the tab, or resets the idle timer.
+func (bp *BrowserPool) Ping(ctx context.Context) error {
+	select {
+	case bp.tabSem <- struct{}{}:
+	default:
+		return nil
+	}
+	defer func() { <-bp.tabSem }()
+
 	bp.mu.Lock()
 	defer bp.mu.Unlock()
 
-	// Check caller context
-	if ctx.Err() != nil {
-		return ctx.Err()
+	if !bp.running {
+		return bp.startErr
 	}
-
-	// Ensure browser is running
-	if err := bp.ensureBrowserRunning(); err != nil {
-		return err
+	if bp.tabCtx.Err() != nil {
+		return bp.tabCtx.Err()
 	}
 
-	// Execute the actions
-	err := chromedp.Run(bp.tabCtx, actions...)
+	runCtx, cancel := context.WithCancel(bp.tabCtx)
+	defer cancel()
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
 
-	// Clean up after use (best effort)
-	if cleanErr := bp.cleanTab(); cleanErr != nil {
-		log.GlobalDebug("browser pool clean tab failed", "error", cleanErr)
-	}
-
-	// Reset idle timer
-	bp.resetIdleTimer()
+	return bp.run(runCtx, chromedp.Navigate("about:blank"))
+}
 
-	return err
+// Execute runs chromedp actions with proper locking and health management.
+// This is the main entry point for scraping operations.
+func (bp *BrowserPool) Execute(ctx context.Context, actions ...chromedp.Action) error {
+	return bp.WithTabCtx(ctx, func(tabCtx context.Context) error {
+		return chromedp.Run(tabCtx, actions...)
+	})
 }
 
 // WithTab provides backward compatibility - executes a function with tab access.
@@ -283,41 +488,128 @@ func (bp *BrowserPool) WithTab(fn func(ctx context.Context) error) error {
 }
 
 // WithTabCtx executes a function with tab access, respecting context cancellation.
+// The pool mutex is held only while acquiring and releasing the tab, so health
+// checks and start/stop are not blocked for the duration of fn. The context
+// passed to fn is canceled when either the tab or the caller context ends.
 func (bp *BrowserPool) WithTabCtx(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Wait for a free tab while respecting caller cancellation
+	select {
+	case bp.tabSem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-bp.tabSem }()
+
+	tabCtx, err := bp.acquireTab(ctx)
+	if err != nil {
+		return err
+	}
+	defer bp.releaseTab()
+
+	runCtx, cancel := context.WithCancel(tabCtx)
+	defer cancel()
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+
+	return fn(runCtx)
+}
+
+// acquireTab ensures Chrome is running and marks the tab as in use.
+func (bp *BrowserPool) acquireTab(ctx context.Context) (context.Context, error) {
 	bp.mu.Lock()
 	defer bp.mu.Unlock()
 
 	// Check caller context
 	if ctx.Err() != nil {
-		return ctx.Err()
+		return nil, ctx.Err()
 	}
 
 	// Ensure browser is running
-	if err := bp.ensureBrowserRunning(); err != nil {
-		return err
+	if err := bp.ensureRunning(); err != nil {
+		return nil, err
 	}
 
-	// Execute the function
-	err := fn(bp.tabCtx)
+	bp.inUse++
+	bp.stopIdleTimer()
+
+	return bp.tabCtx, nil
+}
+
+// releaseTab cleans the tab and restarts the idle timer once no tab is in use.
+func (bp *BrowserPool) releaseTab() {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	bp.inUse--
 
 	// Clean up after use (best effort)
 	if cleanErr := bp.cleanTab(); cleanErr != nil {
 		log.GlobalDebug("browser pool clean tab failed", "error", cleanErr)
 	}
 
-	// Reset idle timer
-	bp.resetIdleTimer()
+	// Recycle a long-lived Chrome once no tab is in use
+	if bp.running {
+		bp.scrapes++
+	}
+	if bp.restartAfter > 0 && bp.scrapes >= bp.restartAfter && bp.inUse == 0 && bp.running {
+		log.GlobalInfo("browser pool restarting chrome after scrape limit",
+			"scrapes", bp.scrapes,
+			"restart_after", bp.restartAfter)
+		bp.stopIdleTimer()
+		bp.stopBrowserLocked("scrape limit")
+		return
+	}
 
-	return err
+	// Reset idle timer (not after Close, which already stopped Chrome)
+	if bp.inUse == 0 && bp.running {
+		bp.resetIdleTimer()
+	}
 }
 
-// Close shuts down the browser and stops all timers.
+// Close shuts down the browser and stops all timers, waiting up to 10 seconds
+// for in-flight scrapes to finish.
 func (bp *BrowserPool) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultCloseTimeout)
+	defer cancel()
+
+	_ = bp.CloseCtx(ctx)
+}
+
+// CloseCtx shuts down the browser once in-flight scrapes release their tab.
+// If ctx ends first, the allocator is canceled anyway, aborting any stuck
+// scrape, and ctx.Err() is returned.
+func (bp *BrowserPool) CloseCtx(ctx context.Context) error {
+	// Take every tab slot so no new scrape starts while draining
+	var err error
+	acquired := 0
+drain:
+	for acquired < cap(bp.tabSem) {
+		select {
+		case bp.tabSem <- struct{}{}:
+			acquired++
+		case <-ctx.Done():
+			err = ctx.Err()
+			break drain
+		}
+	}
+	defer func() {
+		for i := 0; i < acquired; i++ {
+			<-bp.tabSem
+		}
+	}()
+
 	bp.mu.Lock()
 	defer bp.mu.Unlock()
 
+	if err != nil {
+		log.GlobalWarn("browser pool close timed out, forcing chrome termination",
+			"in_use", bp.inUse,
+			"error", err)
+	}
+
 	bp.stopIdleTimer()
-	bp.stopBrowserLocked()
+	bp.stopBrowserLocked("close")
 
 	log.GlobalInfo("browser pool closed")
+	return err
 }
@@ -393,3 +393,107 @@ func TestIntegration_BrowserPool_ExtractHTML(t *testing.T) {
 
 	t.Logf("Successfully extracted %d bytes of HTML", len(html))
 }
+
+func TestIntegration_Scraper_SettleDelay_CapturesLateContent(t *testing.T) {
+	ctx := context.Background()
+
+	// Start Chrome container
+	chrome, err := setupChromeContainer(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup Chrome container: %v", err)
+	}
+	defer chrome.Terminate(ctx)
+
+	// Create browser pool
+	pool, err := NewTestBrowserPoolIntegration(chrome.wsURL)
+	if err != nil {
+		t.Fatalf("Failed to create browser pool: %v", err)
+	}
+	defer pool.Close()
+
+	// The page renders its container at once and hydrates more content 300ms later
+	page := `data:text/html,<html><body><article id="tweet">tweet</article><script>` +
+		`setTimeout(function(){document.body.insertAdjacentHTML('beforeend','<p>'+'late '.repeat(500)+'</p>')},300)` +
+		`</script></body></html>`
+
+	extract := func(settle time.Duration) int {
+		s := &TwitterScraper{run: chromedp.Run}
+		s.SetSettleDelay(settle)
+
+		var html string
+		err := pool.WithTab(func(tabCtx context.Context) error {
+			if err := chromedp.Run(tabCtx,
+				chromedp.Navigate(page),
+				chromedp.WaitVisible("#tweet", chromedp.ByQuery),
+			); err != nil {
+				return err
+			}
+			if err := s.settle(tabCtx); err != nil {
+				return err
+			}
+			return chromedp.Run(tabCtx, chromedp.OuterHTML("html", &html))
+		})
+		if err != nil {
+			t.Fatalf("Failed to extract HTML (settle %v): %v", settle, err)
+		}
+		return len(html)
+	}
+
+	withoutSettle := extract(0)
+	withSettle := extract(time.Second)
+
+	if withSettle <= withoutSettle {
+		t.Errorf("expected more HTML with settle step: got %d bytes, without %d bytes", withSettle, withoutSettle)
+	}
+	t.Logf("HTML length without settle: %d, with settle: %d", withoutSettle, withSettle)
+}
+
+func TestIntegration_Scraper_ShowMore_ExpandsLongTweet(t *testing.T) {
+	ctx := context.Background()
+
+	// Start Chrome container
+	chrome, err := setupChromeContainer(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup Chrome container: %v", err)
+	}
+	defer chrome.Terminate(ctx)
+
+	// Create browser pool
+	pool, err := NewTestBrowserPoolIntegration(chrome.wsURL)
+	if err != nil {
+		t.Fatalf("Failed to create browser pool: %v", err)
+	}
+	defer pool.Close()
+
+	// Clicking "Show more" swaps in the full text and removes the link, as X does
+	page := `data:text/html,<html><body><article data-testid="tweet">` +
+		`<div data-testid="tweetText"><span id="text">Long post prefix</span></div>` +
+		`<button data-testid="tweet-text-show-more-link" onclick="` +
+		`document.getElementById('text').textContent='Long post prefix and the rest of it';this.remove()">Show more</button>` +
+		`</article></body></html>`
+
+	s := &TwitterScraper{run: chromedp.Run, selectors: DefaultSelectors()}
+
+	var html string
+	err = pool.WithTab(func(tabCtx context.Context) error {
+		if err := chromedp.Run(tabCtx,
+			chromedp.Navigate(page),
+			chromedp.WaitVisible("[data-testid='tweetText']", chromedp.ByQuery),
+		); err != nil {
+			return err
+		}
+		s.expandTruncated(tabCtx, "1")
+		return chromedp.Run(tabCtx, chromedp.OuterHTML("html", &html))
+	})
+	if err != nil {
+		t.Fatalf("Failed to extract HTML: %v", err)
+	}
+
+	tweet, _ := s.parseHTML(html, "1")
+	if tweet.Content.Text != "Long post prefix and the rest of it" {
+		t.Errorf("Text: got %q, want the expanded text", tweet.Content.Text)
+	}
+	if tweet.Content.Truncated {
+		t.Error("expected Truncated to be false after Show more was clicked")
+	}
+}
@@ -7,6 +7,11 @@ import (
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"sumariza-ai/internal/domain"
+	"sumariza-ai/pkg/clock"
+
+	"github.com/chromedp/chromedp"
 )
 
 // TestBrowserPool is a testable version of BrowserPool that doesn't require Chrome.
@@ -408,3 +413,544 @@ func TestWithTab_ContextPropagated_ToFunction(t *testing.T) {
 		t.Errorf("deadline mismatch: parent=%v, received=%v", deadline, receivedDeadline)
 	}
 }
+
+// --- Tests for BrowserPool lock scope (no Chrome required) ---
+
+// newLockTestPool creates a BrowserPool that pretends Chrome is running.
+// Like the real pool, it has a single tab.
+func newLockTestPool() *BrowserPool {
+	bp := &BrowserPool{
+		tabSem:      make(chan struct{}, 1),
+		clock:       clock.Real(),
+		idleTimeout: time.Hour,
+	}
+	bp.ensureRunning = func() error {
+		bp.running = true
+		bp.tabCtx = context.Background()
+		return nil
+	}
+	bp.run = func(ctx context.Context, actions ...chromedp.Action) error { return nil }
+	return bp
+}
+
+func TestBrowserPool_WithTabCtx_MutexFreeWhileFnRuns(t *testing.T) {
+	// Arrange
+	bp := newLockTestPool()
+	lockFree := false
+
+	// Act
+	err := bp.WithTabCtx(context.Background(), func(ctx context.Context) error {
+		if bp.mu.TryLock() {
+			lockFree = true
+			bp.mu.Unlock()
+		}
+		return nil
+	})
+
+	// Assert
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !lockFree {
+		t.Error("pool mutex was held while fn was running")
+	}
+}
+
+func TestBrowserPool_WithTabCtx_SecondScrapeWaitsForTabNotMutex(t *testing.T) {
+	// Arrange - the first scrape holds the pool's only tab
+	bp := newLockTestPool()
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	go func() {
+		_ = bp.WithTabCtx(context.Background(), func(ctx context.Context) error {
+			close(entered)
+			<-release
+			return nil
+		})
+	}()
+	<-entered
+
+	// Act - a second scrape queues for the tab
+	secondDone := make(chan error, 1)
+	go func() {
+		secondDone <- bp.WithTabCtx(context.Background(), func(ctx context.Context) error { return nil })
+	}()
+
+	// Assert - it waits on the tab while the pool mutex stays free
+	select {
+	case <-secondDone:
+		t.Fatal("second scrape ran while the first held the tab")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if !bp.mu.TryLock() {
+		t.Error("pool mutex should be free while a scrape is running")
+	} else {
+		bp.mu.Unlock()
+	}
+
+	close(release)
+	select {
+	case err := <-secondDone:
+		if err != nil {
+			t.Errorf("second scrape: unexpected error %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("second scrape did not run once the tab was released")
+	}
+}
+
+func TestBrowserPool_WithTabCtx_CallerCancelPropagates(t *testing.T) {
+	// Arrange
+	bp := newLockTestPool()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Act
+	err := bp.WithTabCtx(ctx, func(tabCtx context.Context) error {
+		cancel()
+		<-tabCtx.Done()
+		return tabCtx.Err()
+	})
+
+	// Assert
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error: got %v, want context.Canceled", err)
+	}
+}
+
+func TestBrowserPool_IdleTimer_DoesNotStopTabInUse(t *testing.T) {
+	// Arrange
+	bp := newLockTestPool()
+	fake := clock.NewFake(time.Now())
+	bp.SetClock(fake)
+
+	// Act - arm the idle timer, then hold the tab past its deadline
+	_ = bp.WithTabCtx(context.Background(), func(ctx context.Context) error { return nil })
+	_ = bp.WithTabCtx(context.Background(), func(ctx context.Context) error {
+		fake.Advance(2 * bp.idleTimeout)
+		return nil
+	})
+
+	// Assert
+	bp.mu.Lock()
+	running := bp.running
+	bp.mu.Unlock()
+	if !running {
+		t.Error("idle timer stopped the browser while a tab was in use")
+	}
+
+	bp.Close()
+}
+
+func TestBrowserPool_ConcurrentUseAndClose_IsSafe(t *testing.T) {
+	// Arrange
+	bp := newLockTestPool()
+	bp.idleTimeout = time.Millisecond
+	var wg sync.WaitGroup
+
+	// Act - hammer the pool while it is being closed and restarted
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_ = bp.WithTabCtx(context.Background(), func(ctx context.Context) error {
+				time.Sleep(time.Millisecond)
+				return nil
+			})
+		}()
+		go func() {
+			defer wg.Done()
+			bp.Close()
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	// Assert - no deadlock
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("concurrent use and close deadlocked")
+	}
+
+	bp.mu.Lock()
+	inUse := bp.inUse
+	bp.mu.Unlock()
+	if inUse != 0 {
+		t.Errorf("inUse: got %d, want 0", inUse)
+	}
+	bp.Close()
+}
+
+func TestBrowserPool_CloseCtx_WaitsForInFlightScrape(t *testing.T) {
+	// Arrange
+	bp := newLockTestPool()
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	go func() {
+		_ = bp.WithTabCtx(context.Background(), func(ctx context.Context) error {
+			close(entered)
+			<-release
+			return nil
+		})
+	}()
+	<-entered
+
+	// Act
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+	}()
+	err := bp.CloseCtx(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	if bp.inUse != 0 {
+		t.Errorf("inUse: got %d, want 0 (close did not wait for the scrape)", bp.inUse)
+	}
+}
+
+func TestBrowserPool_CloseCtx_StuckScrape_ForcesTerminationWithinTimeout(t *testing.T) {
+	// Arrange - a scrape that only ends when its tab is canceled
+	bp := newLockTestPool()
+	bp.ensureRunning = func() error {
+		bp.running = true
+		bp.tabCtx, bp.tabCancel = context.WithCancel(context.Background())
+		return nil
+	}
+	entered := make(chan struct{})
+	scrapeErr := make(chan error, 1)
+	go func() {
+		scrapeErr <- bp.WithTabCtx(context.Background(), func(ctx context.Context) error {
+			close(entered)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	}()
+	<-entered
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Act
+	start := time.Now()
+	err := bp.CloseCtx(ctx)
+	elapsed := time.Since(start)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error: got %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed > time.Second {
+		t.Errorf("close took %v, want it bounded by the timeout", elapsed)
+	}
+	select {
+	case err := <-scrapeErr:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("scrape error: got %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("stuck scrape was not aborted by close")
+	}
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	if bp.running {
+		t.Error("browser should be stopped after forced close")
+	}
+}
+
+func TestBrowserPool_Ping_FreshPool_IsReady(t *testing.T) {
+	// Arrange - Chrome not started yet, any navigation would be a bug
+	bp := newLockTestPool()
+	bp.run = func(ctx context.Context, actions ...chromedp.Action) error {
+		t.Error("ping should not navigate when Chrome is stopped")
+		return nil
+	}
+
+	// Act
+	err := bp.Ping(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if bp.running {
+		t.Error("ping should not start Chrome")
+	}
+}
+
+func TestBrowserPool_Ping_NavigationFails_ReturnsError(t *testing.T) {
+	// Arrange
+	bp := newLockTestPool()
+	_ = bp.WithTabCtx(context.Background(), func(ctx context.Context) error { return nil })
+	navErr := errors.New("websocket closed")
+	bp.run = func(ctx context.Context, actions ...chromedp.Action) error { return navErr }
+
+	// Act
+	err := bp.Ping(context.Background())
+
+	// Assert
+	if !errors.Is(err, navErr) {
+		t.Errorf("error: got %v, want %v", err, navErr)
+	}
+	bp.Close()
+}
+
+func TestBrowserPool_IdleTimer_StopsIdleBrowser(t *testing.T) {
+	// Arrange
+	bp := newLockTestPool()
+	fake := clock.NewFake(time.Now())
+	bp.SetClock(fake)
+	_ = bp.WithTabCtx(context.Background(), func(ctx context.Context) error { return nil })
+
+	// Act
+	fake.Advance(bp.idleTimeout - time.Second)
+	runningBefore := bp.running
+	fake.Advance(time.Second)
+
+	// Assert
+	if !runningBefore {
+		t.Error("browser stopped before the idle timeout")
+	}
+	if bp.running {
+		t.Error("browser still running after the idle timeout")
+	}
+}
+
+func TestBrowserPool_SetLang_DefaultsToEnUS(t *testing.T) {
+	// Arrange
+	bp, _ := NewBrowserPool(nil)
+
+	// Act
+	bp.SetLang("  ")
+
+	// Assert
+	if bp.lang != "en-US" {
+		t.Errorf("lang = %q, want %q", bp.lang, "en-US")
+	}
+}
+
+func TestBrowserPool_SetLang_TrimsLang(t *testing.T) {
+	// Arrange
+	bp, _ := NewBrowserPool(nil)
+
+	// Act
+	bp.SetLang(" pt-BR ")
+
+	// Assert
+	if bp.lang != "pt-BR" {
+		t.Errorf("lang = %q, want %q", bp.lang, "pt-BR")
+	}
+}
+
+func TestLangFlags_AppliesLangAndAcceptLang(t *testing.T) {
+	// Act
+	flags := langFlags("en-US")
+	env := langEnv("en-US")
+
+	// Assert
+	if flags["lang"] != "en-US" {
+		t.Errorf("lang flag = %v, want %q", flags["lang"], "en-US")
+	}
+	if flags["accept-lang"] != "en-US" {
+		t.Errorf("accept-lang flag = %v, want %q", flags["accept-lang"], "en-US")
+	}
+	if len(env) != 1 || env[0] != "LANGUAGE=en_US" {
+		t.Errorf("env = %v, want [LANGUAGE=en_US]", env)
+	}
+}
+
+func TestBrowserPool_StartFails_ReturnsErrBrowserUnavailable(t *testing.T) {
+	// Arrange
+	bp := newLockTestPool()
+	bp.ensureRunning = bp.ensureBrowserRunning
+	launchErr := errors.New("exec: \"chromium\": executable file not found in $PATH")
+	bp.start = func() error { return launchErr }
+	called := false
+
+	// Act
+	err := bp.WithTabCtx(context.Background(), func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+
+	// Assert
+	if !errors.Is(err, domain.ErrBrowserUnavailable) {
+		t.Errorf("expected ErrBrowserUnavailable, got %v", err)
+	}
+	if !errors.Is(err, launchErr) {
+		t.Errorf("expected launch error in chain, got %v", err)
+	}
+	if called {
+		t.Error("fn must not run when Chrome can't start")
+	}
+}
+
+func TestBrowserPool_Ping_AfterStartFailure_ReportsUnavailable(t *testing.T) {
+	// Arrange
+	bp := newLockTestPool()
+	bp.ensureRunning = bp.ensureBrowserRunning
+	bp.start = func() error { return errors.New("sandbox failure") }
+	_ = bp.WithTabCtx(context.Background(), func(ctx context.Context) error { return nil })
+
+	// Act
+	err := bp.Ping(context.Background())
+
+	// Assert
+	if !errors.Is(err, domain.ErrBrowserUnavailable) {
+		t.Errorf("expected ErrBrowserUnavailable, got %v", err)
+	}
+}
+
+func TestBrowserPool_Ping_AfterRecoveredStart_IsReady(t *testing.T) {
+	// Arrange
+	bp := newLockTestPool()
+	bp.ensureRunning = bp.ensureBrowserRunning
+	bp.start = func() error { return errors.New("sandbox failure") }
+	_ = bp.WithTabCtx(context.Background(), func(ctx context.Context) error { return nil })
+	bp.start = func() error {
+		bp.running = true
+		bp.tabCtx = context.Background()
+		return nil
+	}
+
+	// Act
+	startErr := bp.WithTabCtx(context.Background(), func(ctx context.Context) error { return nil })
+	pingErr := bp.Ping(context.Background())
+
+	// Assert
+	if startErr != nil {
+		t.Errorf("expected start to recover, got %v", startErr)
+	}
+	if pingErr != nil {
+		t.Errorf("expected ready after recovery, got %v", pingErr)
+	}
+}
+
+func TestBrowserPool_StartFailsOnce_RetriesAndRuns(t *testing.T) {
+	// Arrange
+	bp := newLockTestPool()
+	bp.ensureRunning = bp.ensureBrowserRunning
+	bp.SetStartAttempts(3)
+	bp.startBackoff = time.Millisecond
+	attempts := 0
+	bp.start = func() error {
+		attempts++
+		if attempts == 1 {
+			return errors.New("devtools port in use")
+		}
+		bp.running = true
+		bp.tabCtx = context.Background()
+		return nil
+	}
+
+	// Act
+	err := bp.WithTabCtx(context.Background(), func(ctx context.Context) error { return nil })
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected start to succeed on retry, got %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts: got %d, want 2", attempts)
+	}
+	bp.mu.Lock()
+	running := bp.running
+	bp.mu.Unlock()
+	if !running {
+		t.Error("expected browser to be running")
+	}
+}
+
+func TestBrowserPool_StartAlwaysFails_GivesUpAfterAttempts(t *testing.T) {
+	// Arrange
+	bp := newLockTestPool()
+	bp.ensureRunning = bp.ensureBrowserRunning
+	bp.SetStartAttempts(3)
+	bp.startBackoff = time.Millisecond
+	attempts := 0
+	launchErr := errors.New("no chrome binary")
+	bp.start = func() error {
+		attempts++
+		return launchErr
+	}
+
+	// Act
+	err := bp.WithTabCtx(context.Background(), func(ctx context.Context) error { return nil })
+
+	// Assert
+	if !errors.Is(err, launchErr) || !errors.Is(err, domain.ErrBrowserUnavailable) {
+		t.Errorf("expected ErrBrowserUnavailable wrapping the last launch error, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts: got %d, want 3", attempts)
+	}
+}
+
+func TestBrowserPool_RestartAfter_RecyclesChromeAtScrapeCount(t *testing.T) {
+	// Arrange
+	bp := newLockTestPool()
+	starts := 0
+	bp.ensureRunning = func() error {
+		if !bp.running {
+			starts++
+			bp.running = true
+			bp.tabCtx = context.Background()
+		}
+		return nil
+	}
+	bp.SetRestartAfter(3)
+	scrape := func() {
+		if err := bp.WithTabCtx(context.Background(), func(ctx context.Context) error { return nil }); err != nil {
+			t.Fatalf("scrape failed: %v", err)
+		}
+	}
+
+	// Act & Assert
+	scrape()
+	scrape()
+	if !bp.running || starts != 1 {
+		t.Fatalf("after 2 scrapes: running=%v starts=%d, want running with 1 start", bp.running, starts)
+	}
+
+	scrape()
+	if bp.running {
+		t.Fatal("expected Chrome to be stopped after the 3rd scrape")
+	}
+
+	scrape()
+	if !bp.running || starts != 2 {
+		t.Errorf("after 4 scrapes: running=%v starts=%d, want running with 2 starts", bp.running, starts)
+	}
+}
+
+func TestBrowserPool_RestartAfter_ZeroNeverRecycles(t *testing.T) {
+	// Arrange
+	bp := newLockTestPool()
+	starts := 0
+	bp.ensureRunning = func() error {
+		if !bp.running {
+			starts++
+			bp.running = true
+			bp.tabCtx = context.Background()
+		}
+		return nil
+	}
+
+	// Act
+	for i := 0; i < 10; i++ {
+		_ = bp.WithTabCtx(context.Background(), func(ctx context.Context) error { return nil })
+	}
+
+	// Assert
+	if starts != 1 {
+		t.Errorf("starts: got %d, want 1", starts)
+	}
+}
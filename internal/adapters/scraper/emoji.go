@@ -0,0 +1,90 @@
+package scraper
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// EmojiMode controls how emoji images in tweet text are rendered.
+// Twitter draws emoji as <img> tags whose alt text holds the emoji.
+type EmojiMode int
+
+const (
+	// EmojiKeep replaces emoji images with their alt text (default).
+	EmojiKeep EmojiMode = iota
+	// EmojiStrip removes emoji entirely, for plain-text indexing.
+	EmojiStrip
+	// EmojiUnicode rebuilds the emoji from the code points in the image URL,
+	// falling back to the alt text when the URL has none.
+	EmojiUnicode
+)
+
+var (
+	emojiImgRe = regexp.MustCompile(`<img[^>]*alt="([^"]*)"[^>]*>`)
+	emojiSrcRe = regexp.MustCompile(`src="[^"]*/emoji/[^"]*?/([0-9a-fA-F]+(?:-[0-9a-fA-F]+)*)\.(?:svg|png)"`)
+)
+
+// ParseEmojiMode parses "keep", "strip" or "unicode" (case-insensitive).
+func ParseEmojiMode(s string) (EmojiMode, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "keep":
+		return EmojiKeep, nil
+	case "strip":
+		return EmojiStrip, nil
+	case "unicode":
+		return EmojiUnicode, nil
+	default:
+		return EmojiKeep, fmt.Errorf("invalid emoji mode %q", s)
+	}
+}
+
+// String returns the mode name accepted by ParseEmojiMode.
+func (m EmojiMode) String() string {
+	switch m {
+	case EmojiStrip:
+		return "strip"
+	case EmojiUnicode:
+		return "unicode"
+	default:
+		return "keep"
+	}
+}
+
+// replaceEmoji rewrites every emoji <img> in html according to the mode.
+func (m EmojiMode) replaceEmoji(html string) string {
+	return emojiImgRe.ReplaceAllStringFunc(html, m.render)
+}
+
+// render returns the text for one emoji <img> tag according to the mode.
+func (m EmojiMode) render(tag string) string {
+	switch m {
+	case EmojiStrip:
+		return ""
+	case EmojiUnicode:
+		if emoji := emojiFromSrc(tag); emoji != "" {
+			return emoji
+		}
+	}
+	return emojiImgRe.FindStringSubmatch(tag)[1]
+}
+
+// emojiFromSrc decodes the code points in an emoji image URL such as
+// https://abs-0.twimg.com/emoji/v2/svg/1f469-200d-1f4bb.svg.
+func emojiFromSrc(tag string) string {
+	matches := emojiSrcRe.FindStringSubmatch(tag)
+	if len(matches) < 2 {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, hex := range strings.Split(matches[1], "-") {
+		r, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil || r > 0x10FFFF {
+			return ""
+		}
+		b.WriteRune(rune(r))
+	}
+	return b.String()
+}
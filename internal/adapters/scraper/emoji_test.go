@@ -0,0 +1,74 @@
+package scraper
+
+import "testing"
+
+const emojiTweetHTML = `<article data-testid="tweet">
+<div data-testid="tweetText" dir="ltr"><span>Shipping it </span><img alt="🚀" draggable="false" src="https://abs-0.twimg.com/emoji/v2/svg/1f680.svg"><span> at the desk </span><img alt="👩‍💻" src="https://abs-0.twimg.com/emoji/v2/svg/1f469-200d-1f4bb.svg"><span> done</span><img alt=":party:" src="https://example.com/custom.png"></div>
+</article>`
+
+func TestParseHTML_EmojiModes(t *testing.T) {
+	tests := []struct {
+		name string
+		mode EmojiMode
+		want string
+	}{
+		{name: "keep", mode: EmojiKeep, want: "Shipping it 🚀 at the desk 👩‍💻 done:party:"},
+		{name: "strip", mode: EmojiStrip, want: "Shipping it at the desk done"},
+		{name: "unicode", mode: EmojiUnicode, want: "Shipping it \U0001F680 at the desk \U0001F469‍\U0001F4BB done:party:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			s := &TwitterScraper{selectors: &SelectorConfig{}}
+			s.SetEmojiMode(tt.mode)
+
+			// Act
+			tweet, _ := s.parseHTML(emojiTweetHTML, "1")
+
+			// Assert
+			if tweet.Content.Text != tt.want {
+				t.Errorf("got %q, want %q", tweet.Content.Text, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmojiMode_Unicode_AltTextDiffersFromImage(t *testing.T) {
+	// Arrange: some clients put a shortcode in alt; the URL still has the code point
+	html := `<img alt=":fire:" src="https://abs-0.twimg.com/emoji/v2/72x72/1f525.png">`
+
+	// Act
+	text := EmojiUnicode.replaceEmoji(html)
+
+	// Assert
+	if text != "🔥" {
+		t.Errorf("got %q, want 🔥", text)
+	}
+}
+
+func TestParseEmojiMode(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    EmojiMode
+		wantErr bool
+	}{
+		{input: "keep", want: EmojiKeep},
+		{input: "STRIP", want: EmojiStrip},
+		{input: " unicode ", want: EmojiUnicode},
+		{input: "emoji", want: EmojiKeep, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseEmojiMode(tt.input)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEmojiMode(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseEmojiMode(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
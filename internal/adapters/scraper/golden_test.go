@@ -0,0 +1,70 @@
+package scraper
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sumariza-ai/test/fixtures"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite testdata/golden files from the current parser output")
+
+// TestParseHTML_Fixtures_MatchGolden pins the full parser output for every
+// fixture, so refactors of the parser internals can't change behavior
+// unnoticed. Run with -update after an intended output change.
+func TestParseHTML_Fixtures_MatchGolden(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+	}{
+		{name: "basic", html: fixtures.GenerateBasicTweet()},
+		{name: "partial", html: fixtures.GeneratePartialTweet()},
+		{name: "rtl", html: fixtures.GenerateRTLTweet()},
+		{name: "verified", html: fixtures.GenerateVerifiedTweet()},
+		{name: "quote", html: fixtures.GenerateQuoteTweet()},
+		{name: "quote_with_media", html: fixtures.GenerateQuoteTweetWithMedia()},
+		{name: "quote_different_authors", html: fixtures.GenerateQuoteTweetDifferentAuthors()},
+		{name: "complete", html: fixtures.GenerateCompleteTweet()},
+		{name: "metrics", html: fixtures.GenerateTweetWithMetrics()},
+		{name: "large_page", html: fixtures.GenerateLargeTweetPage()},
+		{name: "emoji", html: emojiTweetHTML},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			s := &TwitterScraper{selectors: DefaultSelectors()}
+			path := filepath.Join("testdata", "golden", tt.name+".json")
+
+			// Act
+			tweet, _ := s.parseHTML(tt.html, "1")
+			got, err := json.MarshalIndent(tweet, "", "  ")
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			got = append(got, '\n')
+
+			// Assert
+			if *updateGolden {
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read golden (run with -update to create): %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("parser output differs from %s:\ngot:\n%s\nwant:\n%s", path, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// defaultHTTPTimeout bounds a whole fetch (connect, headers and body)
+	// when no timeout is configured.
+	defaultHTTPTimeout = 10 * time.Second
+
+	// maxHTTPBodySize caps the page read by HTTPFetcher.
+	maxHTTPBodySize = 5 << 20
+)
+
+// ErrHTTPStatus is returned when the server answers with a non-2xx status.
+var ErrHTTPStatus = errors.New("unexpected http status")
+
+// HTTPFetcher fetches pages over plain HTTP, for scrapers that don't need a
+// browser (e.g. a Nitter instance). Every request carries the configured
+// headers, so instances requiring a User-Agent or an auth header work.
+type HTTPFetcher struct {
+	client  *http.Client
+	headers http.Header
+}
+
+// NewHTTPFetcher creates a fetcher sending headers with every request.
+// A zero timeout uses defaultHTTPTimeout.
+func NewHTTPFetcher(headers map[string]string, timeout time.Duration) *HTTPFetcher {
+	if timeout <= 0 {
+		timeout = defaultHTTPTimeout
+	}
+
+	h := make(http.Header, len(headers))
+	for name, value := range headers {
+		h.Set(name, value)
+	}
+
+	return &HTTPFetcher{
+		client:  &http.Client{Timeout: timeout},
+		headers: h,
+	}
+}
+
+// SetClient replaces the HTTP client, e.g. with the shared client honoring
+// the configured proxy. Its own timeout then applies instead of the one
+// given to NewHTTPFetcher. A nil client is ignored.
+func (f *HTTPFetcher) SetClient(client *http.Client) {
+	if client != nil {
+		f.client = client
+	}
+}
+
+// Fetch GETs url and returns the response body.
+func (f *HTTPFetcher) Fetch(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	for name, values := range f.headers {
+		req.Header[name] = values
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("%w: %d", ErrHTTPStatus, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPBodySize))
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
@@ -0,0 +1,128 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"sumariza-ai/pkg/httpclient"
+)
+
+// stubTransport answers every request with body, recording the requests.
+type stubTransport struct {
+	body     string
+	requests []*http.Request
+}
+
+func (s *stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.requests = append(s.requests, req)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(s.body)),
+		Request:    req,
+	}, nil
+}
+
+func TestHTTPFetcher_Fetch_SendsConfiguredHeaders(t *testing.T) {
+	// Arrange
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte("<html>ok</html>"))
+	}))
+	defer server.Close()
+	f := NewHTTPFetcher(map[string]string{
+		"User-Agent":    "sumariza-test/1.0",
+		"Authorization": "Bearer secret",
+	}, time.Second)
+
+	// Act
+	body, err := f.Fetch(context.Background(), server.URL)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body != "<html>ok</html>" {
+		t.Errorf("body: got %q", body)
+	}
+	if got.Get("User-Agent") != "sumariza-test/1.0" {
+		t.Errorf("User-Agent: got %q", got.Get("User-Agent"))
+	}
+	if got.Get("Authorization") != "Bearer secret" {
+		t.Errorf("Authorization: got %q", got.Get("Authorization"))
+	}
+}
+
+func TestHTTPFetcher_SetClient_UsesInjectedTransport(t *testing.T) {
+	// Arrange
+	transport := &stubTransport{body: "<html>stubbed</html>"}
+	client, err := httpclient.New(httpclient.Options{Transport: transport})
+	if err != nil {
+		t.Fatalf("httpclient.New() error = %v", err)
+	}
+	f := NewHTTPFetcher(map[string]string{"User-Agent": "sumariza-test/1.0"}, time.Second)
+	f.SetClient(client)
+
+	// Act
+	body, err := f.Fetch(context.Background(), "https://nitter.example/johndoe/status/1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body != "<html>stubbed</html>" {
+		t.Errorf("body: got %q", body)
+	}
+	if len(transport.requests) != 1 || transport.requests[0].Header.Get("User-Agent") != "sumariza-test/1.0" {
+		t.Errorf("expected one request with the configured headers through the transport, got %v", transport.requests)
+	}
+}
+
+func TestHTTPFetcher_Fetch_SlowServer_TimesOut(t *testing.T) {
+	// Arrange
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+	f := NewHTTPFetcher(nil, 50*time.Millisecond)
+
+	// Act
+	start := time.Now()
+	_, err := f.Fetch(context.Background(), server.URL)
+
+	// Assert
+	if err == nil {
+		t.Fatal("expected timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("fetch took %v, want about 50ms", elapsed)
+	}
+}
+
+func TestHTTPFetcher_Fetch_NonOKStatus_ReturnsErrHTTPStatus(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+	f := NewHTTPFetcher(nil, time.Second)
+
+	// Act
+	_, err := f.Fetch(context.Background(), server.URL)
+
+	// Assert
+	if !errors.Is(err, ErrHTTPStatus) {
+		t.Errorf("expected ErrHTTPStatus, got %v", err)
+	}
+}
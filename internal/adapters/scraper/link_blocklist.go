@@ -0,0 +1,58 @@
+package scraper
+
+import "strings"
+
+// blockedLinkText replaces a blocked link in tweet text.
+const blockedLinkText = "[link removed]"
+
+// LinkBlocklist lists domains whose links are dropped from tweet text, e.g.
+// trackers that shouldn't be surfaced. A domain also blocks its subdomains.
+type LinkBlocklist struct {
+	domains map[string]struct{}
+}
+
+// NewLinkBlocklist creates a blocklist from domain names, skipping blanks.
+// Entries are case-insensitive and may carry a scheme or a leading "www.".
+func NewLinkBlocklist(domains []string) *LinkBlocklist {
+	set := make(map[string]struct{}, len(domains))
+	for _, domain := range domains {
+		if host := linkHost(domain); host != "" {
+			set[host] = struct{}{}
+		}
+	}
+	return &LinkBlocklist{domains: set}
+}
+
+// Blocks reports whether the link points at a blocked domain. The link may
+// be a full URL or Twitter's display text ("example.com/path…").
+// A nil blocklist blocks nothing.
+func (b *LinkBlocklist) Blocks(link string) bool {
+	if b == nil || len(b.domains) == 0 {
+		return false
+	}
+
+	for host := linkHost(link); host != ""; {
+		if _, ok := b.domains[host]; ok {
+			return true
+		}
+		_, parent, found := strings.Cut(host, ".")
+		if !found {
+			break
+		}
+		host = parent
+	}
+	return false
+}
+
+// linkHost returns the lowercased host of a URL or bare domain, without
+// scheme, "www.", port or path.
+func linkHost(link string) string {
+	host := strings.ToLower(strings.TrimSpace(link))
+	if _, rest, ok := strings.Cut(host, "://"); ok {
+		host = rest
+	}
+	if i := strings.IndexAny(host, "/?#:…"); i >= 0 {
+		host = host[:i]
+	}
+	return strings.TrimPrefix(host, "www.")
+}
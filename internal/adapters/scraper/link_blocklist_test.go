@@ -0,0 +1,73 @@
+package scraper
+
+import "testing"
+
+func TestLinkBlocklist_Blocks(t *testing.T) {
+	blocked := NewLinkBlocklist([]string{"Tracker.example", " https://www.ads.test/ ", ""})
+
+	tests := []struct {
+		link string
+		want bool
+	}{
+		{link: "https://tracker.example/pixel?id=1", want: true},
+		{link: "http://cdn.tracker.example/x", want: true},
+		{link: "tracker.example/path…", want: true},
+		{link: "https://ads.test", want: true},
+		{link: "https://www.ads.test:8443/a", want: true},
+		{link: "https://nottracker.example/", want: false},
+		{link: "https://tracker.example.org/", want: false},
+		{link: "https://go.dev/doc", want: false},
+		{link: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.link, func(t *testing.T) {
+			// Act
+			got := blocked.Blocks(tt.link)
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("Blocks(%q) = %v, want %v", tt.link, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkBlocklist_Nil_BlocksNothing(t *testing.T) {
+	// Arrange
+	var blocked *LinkBlocklist
+
+	// Act & Assert
+	if blocked.Blocks("https://tracker.example/") {
+		t.Error("nil blocklist should block nothing")
+	}
+}
+
+func TestExtractTweetText_BlockedDomain_ReplacesOnlyBlockedLink(t *testing.T) {
+	// Arrange
+	html := `<div data-testid="tweetText">See <a href="https://tracker.example/r?u=1">tracker.example/r…</a> and <a href="https://go.dev/doc">go.dev/doc</a></div>`
+	blocked := NewLinkBlocklist([]string{"tracker.example"})
+
+	// Act
+	text := extractTweetText(html, textOptions{blocked: blocked})
+
+	// Assert
+	want := "See [link removed] and [[LINK:https://go.dev/doc]]"
+	if text != want {
+		t.Errorf("got %q, want %q", text, want)
+	}
+}
+
+func TestExtractTweetText_BlockedDomainBehindTco_MatchesDisplayText(t *testing.T) {
+	// Arrange
+	html := `<div data-testid="tweetText">Deal: <a href="https://t.co/xyz">tracker.example/deal…</a></div>`
+	blocked := NewLinkBlocklist([]string{"tracker.example"})
+
+	// Act
+	text := extractTweetText(html, textOptions{blocked: blocked})
+
+	// Assert
+	if text != "Deal: [link removed]" {
+		t.Errorf("got %q, want %q", text, "Deal: [link removed]")
+	}
+}
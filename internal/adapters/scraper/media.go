@@ -0,0 +1,220 @@
+package scraper
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"sumariza-ai/internal/domain"
+)
+
+// mediaMarkers identify the containers Twitter renders media in.
+var mediaMarkers = []string{
+	`data-testid="tweetPhoto"`,
+	`data-testid="videoPlayer"`,
+	`data-testid="videoComponent"`,
+}
+
+// attachmentMarkers identify the attachments other than media a tweet can
+// be posted with instead of text: polls and link cards.
+var attachmentMarkers = []string{
+	`data-testid="cardPoll"`,
+	`data-testid="card.wrapper"`,
+}
+
+var (
+	imgTagRe    = regexp.MustCompile(`<img\b[^>]*>`)
+	videoTagRe  = regexp.MustCompile(`<video\b[^>]*>`)
+	sourceTagRe = regexp.MustCompile(`<source\b[^>]*>`)
+
+	// sizeRe matches a WIDTHxHEIGHT size, as in name=1200x675 or the
+	// /vid/avc1/1280x720/ path segment of video files.
+	sizeRe = regexp.MustCompile(`^([0-9]{1,5})x([0-9]{1,5})$`)
+)
+
+// defaultPhotoAlt is the alt text Twitter sets on photos without a description.
+const defaultPhotoAlt = "Image"
+
+// hasEssentialContent reports whether the tweet parsed from page is complete
+// enough to serve: it has text, or it is only media, a poll or a card.
+func hasEssentialContent(tweet *domain.Tweet, page string) bool {
+	return tweet.Content.Text != "" || hasAttachment(tweet, page)
+}
+
+// hasAttachment reports whether the parsed tweet has media, or its own
+// article (not a quoted tweet's) holds a poll or a card, so it is complete
+// without text.
+func hasAttachment(tweet *domain.Tweet, page string) bool {
+	if len(tweet.Content.Media) > 0 {
+		return true
+	}
+	own := withoutQuoteTweet(primaryTweetScope(page))
+	for _, marker := range attachmentMarkers {
+		if strings.Contains(own, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// extractMedia returns up to limit photos, videos and GIFs in page order.
+// Each container is read once, even when Twitter nests a video player in a
+// photo container, and a photo shown in several renditions is kept once
+// (see imageKey).
+func extractMedia(page string, limit int) []domain.MediaItem {
+	if limit <= 0 {
+		limit = defaultMaxImages
+	}
+
+	var media []domain.MediaItem
+	seen := make(map[string]struct{})
+	for offset := 0; len(media) < limit; {
+		idx, marker := nextMediaMarker(page, offset)
+		if idx < 0 {
+			break
+		}
+		container := enclosingElement(page, idx)
+		// Skip past the container, so markers nested in it aren't read again
+		offset = max(strings.LastIndex(page[:idx], "<")+len(container), idx+len(marker))
+
+		item, key, ok := parseMediaItem(container)
+		if !ok {
+			continue
+		}
+		if key != "" {
+			if _, dup := seen[key]; dup {
+				continue
+			}
+			seen[key] = struct{}{}
+		}
+		media = append(media, item)
+	}
+
+	return media
+}
+
+// nextMediaMarker returns the position of the first media marker at or
+// after offset, and the marker found; -1 when there is none.
+func nextMediaMarker(page string, offset int) (int, string) {
+	first, found := -1, ""
+	for _, marker := range mediaMarkers {
+		idx := strings.Index(page[offset:], marker)
+		if idx >= 0 && (first < 0 || offset+idx < first) {
+			first, found = offset+idx, marker
+		}
+	}
+	return first, found
+}
+
+// parseMediaItem reads one media container. key identifies the media for
+// de-duplication; it is empty when nothing identifies it.
+func parseMediaItem(container string) (item domain.MediaItem, key string, ok bool) {
+	if tag := videoTagRe.FindString(container); tag != "" {
+		item = domain.MediaItem{
+			Type:      domain.MediaVideo,
+			PosterURL: tagAttr(tag, "poster"),
+			URL:       videoSource(tag, container),
+		}
+		// GIFs are silent looping videos served from tweet_video paths
+		if strings.Contains(item.PosterURL, "/tweet_video_thumb/") || strings.Contains(item.URL, "/tweet_video/") {
+			item.Type = domain.MediaGIF
+		}
+		item.Width, item.Height = mediaSize(tag, item.URL)
+		key = item.PosterURL
+		if key == "" {
+			key = item.URL
+		}
+		if key != "" {
+			key = imageKey(key)
+		}
+		return item, key, true
+	}
+
+	if tag := imgTagRe.FindString(container); tag != "" {
+		src := tagAttr(tag, "src")
+		if src == "" {
+			return item, "", false
+		}
+		item = domain.MediaItem{Type: domain.MediaPhoto, URL: src}
+		item.Width, item.Height = mediaSize(tag, src)
+		if alt := tagAttr(tag, "alt"); alt != defaultPhotoAlt {
+			item.AltText = alt
+		}
+		return item, imageKey(src), true
+	}
+
+	return item, "", false
+}
+
+// videoSource returns the video's file URL from its src or first <source>.
+// blob: URLs only exist inside the browser and are dropped.
+func videoSource(videoTag, container string) string {
+	src := tagAttr(videoTag, "src")
+	if src == "" || strings.HasPrefix(src, "blob:") {
+		if source := sourceTagRe.FindString(container); source != "" {
+			src = tagAttr(source, "src")
+		}
+	}
+	if strings.HasPrefix(src, "blob:") {
+		return ""
+	}
+	return src
+}
+
+// mediaSize returns the media's size from the tag's width and height
+// attributes, else from the size Twitter's CDN encodes in src (a name=WxH
+// query parameter or a WxH path segment). Zeros mean unknown.
+func mediaSize(tag, src string) (width, height int) {
+	width, _ = strconv.Atoi(tagAttr(tag, "width"))
+	height, _ = strconv.Atoi(tagAttr(tag, "height"))
+	if width > 0 && height > 0 {
+		return width, height
+	}
+
+	u, err := url.Parse(src)
+	if err != nil {
+		return 0, 0
+	}
+	candidates := append([]string{u.Query().Get("name")}, strings.Split(u.Path, "/")...)
+	for _, candidate := range candidates {
+		if m := sizeRe.FindStringSubmatch(candidate); m != nil {
+			width, _ = strconv.Atoi(m[1])
+			height, _ = strconv.Atoi(m[2])
+			if width > 0 && height > 0 {
+				return width, height
+			}
+		}
+	}
+	return 0, 0
+}
+
+// tagAttr returns the decoded value of a double-quoted attribute in an
+// opening tag, or "" when the tag doesn't have it.
+func tagAttr(tag, name string) string {
+	prefix := name + `="`
+	for offset := 0; ; {
+		idx := strings.Index(tag[offset:], prefix)
+		if idx < 0 {
+			return ""
+		}
+		idx += offset
+		offset = idx + len(prefix)
+
+		// Require a separator, so "src" doesn't match "data-src"
+		if idx == 0 || !isAttrSeparator(tag[idx-1]) {
+			continue
+		}
+		end := strings.IndexByte(tag[offset:], '"')
+		if end < 0 {
+			return ""
+		}
+		return html.UnescapeString(tag[offset : offset+end])
+	}
+}
+
+// isAttrSeparator reports whether c can precede an attribute name.
+func isAttrSeparator(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
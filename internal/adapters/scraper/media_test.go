@@ -0,0 +1,125 @@
+package scraper
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"sumariza-ai/internal/domain"
+	"sumariza-ai/test/fixtures"
+)
+
+func TestParseHTML_MixedMedia_KeepsOrderAndTypes(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateMixedMediaTweet()
+	s := &TwitterScraper{selectors: DefaultSelectors()}
+
+	// Act
+	tweet, _ := s.parseHTML(html, "1")
+
+	// Assert
+	want := []domain.MediaItem{
+		{Type: domain.MediaPhoto, URL: "https://pbs.twimg.com/media/CROWD1?format=jpg&name=small", AltText: "The crowd at the venue"},
+		{Type: domain.MediaVideo, PosterURL: "https://pbs.twimg.com/ext_tw_video_thumb/900/pu/img/keynote.jpg"},
+		{Type: domain.MediaGIF, URL: "https://video.twimg.com/tweet_video/CONFETTI.mp4", PosterURL: "https://pbs.twimg.com/tweet_video_thumb/CONFETTI.jpg"},
+		{Type: domain.MediaPhoto, URL: "https://pbs.twimg.com/media/STAGE2?format=jpg&name=small"},
+	}
+	if !slices.Equal(tweet.Content.Media, want) {
+		t.Errorf("Media:\n got %+v\nwant %+v", tweet.Content.Media, want)
+	}
+}
+
+func TestResultFromHTML_MediaWithoutText_Succeeds(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateImageOnlyTweet()
+	s := &TwitterScraper{selectors: DefaultSelectors()}
+
+	// Act
+	result := s.resultFromHTML(context.Background(), html, "900")
+
+	// Assert
+	if result.Err != nil {
+		t.Fatalf("err: got %v, want success", result.Err)
+	}
+	if result.Tweet.Content.Text != "" {
+		t.Errorf("Text: got %q, want empty", result.Tweet.Content.Text)
+	}
+	want := []domain.MediaItem{
+		{Type: domain.MediaPhoto, URL: "https://pbs.twimg.com/media/SUNSET9?format=jpg&name=small", AltText: "Sunset over the bay"},
+	}
+	if !slices.Equal(result.Tweet.Content.Media, want) {
+		t.Errorf("Media:\n got %+v\nwant %+v", result.Tweet.Content.Media, want)
+	}
+}
+
+func TestHasAttachment_PollWithoutText(t *testing.T) {
+	// Arrange
+	html := `<article data-testid="tweet"><div data-testid="card.wrapper"><div data-testid="cardPoll">Tabs or spaces?</div></div></article>`
+
+	// Act
+	got := hasAttachment(&domain.Tweet{}, html)
+
+	// Assert
+	if !got {
+		t.Error("expected a poll to count as an attachment")
+	}
+}
+
+func TestHasAttachment_QuotedCardOnly_NotCounted(t *testing.T) {
+	// Arrange
+	html := `<article data-testid="tweet"><div data-testid="quoteTweet"><div data-testid="card.wrapper"></div></div></article>`
+
+	// Act
+	got := hasAttachment(&domain.Tweet{}, html)
+
+	// Assert
+	if got {
+		t.Error("a quoted tweet's card should not make the outer tweet complete")
+	}
+}
+
+func TestExtractMedia_RespectsLimit(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateMixedMediaTweet()
+
+	// Act
+	media := extractMedia(withoutQuoteTweet(html), 2)
+
+	// Assert
+	if len(media) != 2 || media[1].Type != domain.MediaVideo {
+		t.Errorf("got %+v, want the photo and the video", media)
+	}
+}
+
+func TestTagAttr_IgnoresPrefixedAttributes(t *testing.T) {
+	// Arrange
+	tag := `<img data-src="https://a.example/lazy.jpg" src="https://a.example/x.jpg?a=1&amp;b=2">`
+
+	// Act
+	src := tagAttr(tag, "src")
+
+	// Assert
+	if src != "https://a.example/x.jpg?a=1&b=2" {
+		t.Errorf("got %q", src)
+	}
+}
+
+func TestParseHTML_SizedMedia_ParsesDimensions(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateTweetWithSizedMedia()
+	s := &TwitterScraper{selectors: DefaultSelectors()}
+
+	// Act
+	tweet, _ := s.parseHTML(html, "1")
+
+	// Assert
+	want := [][2]int{{1600, 900}, {675, 1200}, {1280, 720}, {0, 0}}
+	if len(tweet.Content.Media) != len(want) {
+		t.Fatalf("got %d media items, want %d", len(tweet.Content.Media), len(want))
+	}
+	for i, item := range tweet.Content.Media {
+		if got := [2]int{item.Width, item.Height}; got != want[i] {
+			t.Errorf("media %d: got %dx%d, want %dx%d", i, got[0], got[1], want[i][0], want[i][1])
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package scraper
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"sumariza-ai/internal/domain"
+)
+
+var (
+	ariaLabelRe = regexp.MustCompile(`aria-label="([^"]*)"`)
+	viewsRe     = regexp.MustCompile(`(?i)(\d[\d.,]*\s*[kmb]?)\s+views?\b`)
+	bookmarksRe = regexp.MustCompile(`(?i)(\d[\d.,]*\s*[kmb]?)\s+bookmarks?\b`)
+
+	// groupedCountRe matches thousands grouped with a single kind of separator.
+	groupedCountRe = regexp.MustCompile(`^\d{1,3}(?:(?:,\d{3})+|(?:\.\d{3})+)$`)
+	// decimalCountRe matches a number with an optional decimal part.
+	decimalCountRe = regexp.MustCompile(`^\d+(?:[.,]\d+)?$`)
+)
+
+// extractMetrics reads the views and bookmarks counts from aria-labels, which
+// carry the full number ("12,345 views") instead of the visible "12.3K".
+// html should be the main tweet's own scope: a count missing from it stays
+// zero rather than being taken from a reply. The first label mentioning a
+// count wins.
+func extractMetrics(html string) domain.Metrics {
+	var metrics domain.Metrics
+
+	for _, match := range ariaLabelRe.FindAllStringSubmatch(html, -1) {
+		label := match[1]
+		if metrics.Views == 0 {
+			if m := viewsRe.FindStringSubmatch(label); m != nil {
+				metrics.Views = parseCount(m[1])
+			}
+		}
+		if metrics.Bookmarks == 0 {
+			if m := bookmarksRe.FindStringSubmatch(label); m != nil {
+				metrics.Bookmarks = parseCount(m[1])
+			}
+		}
+		if metrics.Views != 0 && metrics.Bookmarks != 0 {
+			break
+		}
+	}
+
+	return metrics
+}
+
+// parseCount converts a displayed count to an integer. It accepts plain
+// numbers, thousands grouping with commas, dots or spaces ("1,234",
+// "1.234.567", "1 234") and K/M/B suffixes with an optional decimal part
+// ("1.2K" → 1200, "3,4M" → 3400000). Unparseable input yields 0.
+func parseCount(s string) int64 {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+	if s == "" {
+		return 0
+	}
+
+	multiplier := int64(1)
+	switch s[len(s)-1] {
+	case 'K', 'k':
+		multiplier = 1e3
+	case 'M', 'm':
+		multiplier = 1e6
+	case 'B', 'b':
+		multiplier = 1e9
+	}
+	if multiplier != 1 {
+		s = s[:len(s)-1]
+	}
+
+	// Grouped thousands: separators are dropped
+	if groupedCountRe.MatchString(s) {
+		s = strings.NewReplacer(",", "", ".", "").Replace(s)
+	}
+
+	if multiplier == 1 {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || n < 0 {
+			return 0
+		}
+		return n
+	}
+
+	// Abbreviated counts may use a decimal comma ("1,2K")
+	if !decimalCountRe.MatchString(s) {
+		return 0
+	}
+	f, err := strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+	if err != nil {
+		return 0
+	}
+	// Counts too large for int64 are unparseable, not wrapped negative
+	n := math.Round(f * float64(multiplier))
+	if math.IsNaN(n) || math.IsInf(n, 0) || n >= math.MaxInt64 {
+		return 0
+	}
+	return int64(n)
+}
@@ -0,0 +1,49 @@
+package scraper
+
+import "testing"
+
+func TestParseCount(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  int64
+	}{
+		{"plain", "987", 987},
+		{"zero", "0", 0},
+		{"comma grouping", "1,234", 1234},
+		{"comma grouping millions", "12,345,678", 12345678},
+		{"dot grouping", "1.234.567", 1234567},
+		{"space grouping", "1 234", 1234},
+		{"non-breaking space grouping", "1 234", 1234},
+		{"narrow non-breaking space grouping", "1 234", 1234},
+		{"surrounding whitespace", "  42\n", 42},
+		{"K suffix", "5K", 5000},
+		{"K decimal", "1.2K", 1200},
+		{"lowercase k", "1.2k", 1200},
+		{"K decimal comma", "1,2K", 1200},
+		{"K with space", "1.2 K", 1200},
+		{"M decimal", "3.4M", 3400000},
+		{"M two decimals", "12.34M", 12340000},
+		{"B suffix", "1.5B", 1500000000},
+		{"grouped with suffix", "1,234K", 1234000},
+		{"empty", "", 0},
+		{"whitespace only", "   ", 0},
+		{"suffix only", "K", 0},
+		{"letters", "abc", 0},
+		{"negative", "-5", 0},
+		{"decimal without suffix", "1.5", 0},
+		{"mixed separators", "1,234.567", 0},
+		{"overflows int64", "99999999999B", 0},
+		{"bad grouping", "12,34", 0},
+		{"unknown suffix", "5X", 0},
+		{"double decimal", "1.2.3K", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseCount(tt.input); got != tt.want {
+				t.Errorf("parseCount(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
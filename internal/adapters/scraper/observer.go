@@ -0,0 +1,36 @@
+package scraper
+
+import (
+	"strings"
+	"time"
+)
+
+// Scrape step names reported to a ScrapeObserver.
+const (
+	StepNavigate      = "navigate"
+	StepWaitContainer = "wait-container"
+	StepWaitText      = "wait-text"
+	StepExtract       = "extract"
+	StepParse         = "parse"
+)
+
+// ScrapeObserver is notified at each scrape step boundary.
+// Implementations must be safe for concurrent use and must not block.
+type ScrapeObserver interface {
+	// OnStep is called when a step finishes, successfully or not.
+	OnStep(step string, elapsed time.Duration)
+}
+
+// scrapeTimeline collects how long each step of one scrape took.
+// Steps that didn't run are absent.
+type scrapeTimeline map[string]time.Duration
+
+// millis returns the timeline as log fields named after the steps, e.g.
+// {"navigate_ms": 812, "wait_container_ms": 1204}.
+func (t scrapeTimeline) millis() map[string]int64 {
+	fields := make(map[string]int64, len(t))
+	for step, elapsed := range t {
+		fields[strings.ReplaceAll(step, "-", "_")+"_ms"] = elapsed.Milliseconds()
+	}
+	return fields
+}
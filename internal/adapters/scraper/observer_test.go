@@ -0,0 +1,260 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"sumariza-ai/test/fixtures"
+
+	"github.com/chromedp/chromedp"
+)
+
+// recordingObserver records every step it is notified about.
+type recordingObserver struct {
+	mu    sync.Mutex
+	steps []string
+}
+
+func (o *recordingObserver) OnStep(step string, elapsed time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.steps = append(o.steps, step)
+}
+
+// newMockedScraper creates a scraper whose chromedp actions are handled by run.
+func newMockedScraper(run func(ctx context.Context, actions ...chromedp.Action) error) *TwitterScraper {
+	s := &TwitterScraper{
+		pool: NewTestBrowserPool(1),
+		selectors: &SelectorConfig{
+			TweetContainer: "article[data-testid='tweet']",
+			TweetText:      "[data-testid='tweetText']",
+		},
+		run: run,
+	}
+	s.outerHTML = s.runOuterHTML
+	return s
+}
+
+func TestScrape_Observer_ReceivesStepSequence(t *testing.T) {
+	// Arrange
+	observer := &recordingObserver{}
+	s := newMockedScraper(func(ctx context.Context, actions ...chromedp.Action) error {
+		return nil
+	})
+	s.SetObserver(observer)
+
+	// Act - the mocked run yields empty HTML, so the scrape itself fails
+	_, _ = s.Scrape(context.Background(), "123")
+
+	// Assert
+	expected := []string{StepNavigate, StepWaitContainer, StepWaitText, StepExtract, StepParse}
+	if len(observer.steps) != len(expected) {
+		t.Fatalf("steps: got %v, want %v", observer.steps, expected)
+	}
+	for i, step := range expected {
+		if observer.steps[i] != step {
+			t.Errorf("step %d: got %q, want %q", i, observer.steps[i], step)
+		}
+	}
+}
+
+func TestScrape_Observer_StopsAtFailedStep(t *testing.T) {
+	// Arrange - second and third actions (container wait and its retry) fail
+	observer := &recordingObserver{}
+	calls := 0
+	s := newMockedScraper(func(ctx context.Context, actions ...chromedp.Action) error {
+		calls++
+		if calls == 2 || calls == 3 {
+			return errors.New("container not visible")
+		}
+		return nil
+	})
+	s.SetObserver(observer)
+
+	// Act
+	_, err := s.Scrape(context.Background(), "123")
+
+	// Assert
+	if err == nil {
+		t.Error("expected scrape error")
+	}
+	expected := []string{StepNavigate, StepWaitContainer}
+	if len(observer.steps) != len(expected) || observer.steps[0] != expected[0] || observer.steps[1] != expected[1] {
+		t.Errorf("steps: got %v, want %v", observer.steps, expected)
+	}
+}
+
+func TestScrapeResult_Timeline_HasEveryStepAndSumsToTotal(t *testing.T) {
+	// Arrange - every browser action takes a few milliseconds, including
+	// the "Show more" probe, which runs between steps
+	s := newMockedScraper(func(ctx context.Context, actions ...chromedp.Action) error {
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	})
+	s.outerHTML = func(ctx context.Context) (string, error) {
+		time.Sleep(5 * time.Millisecond)
+		return fixtures.GenerateCompleteTweet(), nil
+	}
+
+	// Act
+	start := time.Now()
+	result := s.ScrapeResult(context.Background(), "123")
+	total := time.Since(start)
+
+	// Assert
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	var sum time.Duration
+	for _, step := range []string{StepNavigate, StepWaitContainer, StepWaitText, StepExtract, StepParse} {
+		elapsed, ok := result.Timeline[step]
+		if !ok {
+			t.Errorf("timeline: missing step %q in %v", step, result.Timeline)
+		}
+		sum += elapsed
+	}
+	if sum > total || sum < total-20*time.Millisecond {
+		t.Errorf("timeline: steps sum to %v, want roughly the total %v", sum, total)
+	}
+}
+
+func TestScrapeTimeline_Millis_NamesFieldsAfterSteps(t *testing.T) {
+	// Arrange
+	timeline := scrapeTimeline{
+		StepNavigate:      812 * time.Millisecond,
+		StepWaitContainer: 1204 * time.Millisecond,
+		StepParse:         3 * time.Millisecond,
+	}
+
+	// Act
+	fields := timeline.millis()
+
+	// Assert
+	want := map[string]int64{"navigate_ms": 812, "wait_container_ms": 1204, "parse_ms": 3}
+	if len(fields) != len(want) {
+		t.Fatalf("got %v, want %v", fields, want)
+	}
+	for key, ms := range want {
+		if fields[key] != ms {
+			t.Errorf("%s: got %d, want %d", key, fields[key], ms)
+		}
+	}
+}
+
+func TestScrape_NilObserver_DoesNotPanic(t *testing.T) {
+	// Arrange
+	s := newMockedScraper(func(ctx context.Context, actions ...chromedp.Action) error {
+		return nil
+	})
+
+	// Act & Assert - must not panic
+	_, _ = s.Scrape(context.Background(), "123")
+}
+
+func TestScrape_UpstreamStatus_UnknownBeforeFirstScrape(t *testing.T) {
+	s := newMockedScraper(func(ctx context.Context, actions ...chromedp.Action) error { return nil })
+
+	if got := s.UpstreamStatus(); got != UpstreamUnknown {
+		t.Errorf("status: got %q, want %q", got, UpstreamUnknown)
+	}
+}
+
+func TestScrape_UpstreamStatus_ContainerMissing_IsBlocked(t *testing.T) {
+	// Arrange - navigation works, the tweet never renders
+	calls := 0
+	s := newMockedScraper(func(ctx context.Context, actions ...chromedp.Action) error {
+		calls++
+		if calls == 1 {
+			return nil
+		}
+		return errors.New("wait timeout")
+	})
+
+	// Act
+	_, _ = s.Scrape(context.Background(), "123")
+
+	// Assert
+	if got := s.UpstreamStatus(); got != UpstreamBlocked {
+		t.Errorf("status: got %q, want %q", got, UpstreamBlocked)
+	}
+}
+
+func TestScrape_UpstreamStatus_NavigationFails_Unchanged(t *testing.T) {
+	// Arrange
+	s := newMockedScraper(func(ctx context.Context, actions ...chromedp.Action) error {
+		return errors.New("browser gone")
+	})
+
+	// Act
+	_, _ = s.Scrape(context.Background(), "123")
+
+	// Assert
+	if got := s.UpstreamStatus(); got != UpstreamUnknown {
+		t.Errorf("status: got %q, want %q", got, UpstreamUnknown)
+	}
+}
+
+func TestScrape_ContainerWaitRetry_SucceedsOnSecondAttempt(t *testing.T) {
+	// Arrange - first container wait times out, the longer retry finds it
+	var waits []time.Duration
+	calls := 0
+	s := newMockedScraper(func(ctx context.Context, actions ...chromedp.Action) error {
+		calls++
+		if calls == 2 || calls == 3 {
+			deadline, _ := ctx.Deadline()
+			waits = append(waits, time.Until(deadline))
+		}
+		if calls == 2 {
+			return context.DeadlineExceeded
+		}
+		return nil
+	})
+	s.outerHTML = func(ctx context.Context) (string, error) {
+		return fixtures.GenerateCompleteTweet(), nil
+	}
+
+	// Act
+	tweet, err := s.Scrape(context.Background(), "123")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected success after retry, got %v", err)
+	}
+	if tweet.Content.Text != "Everything is here." {
+		t.Errorf("text: got %q", tweet.Content.Text)
+	}
+	if len(waits) != 2 {
+		t.Fatalf("expected 2 container waits, got %d", len(waits))
+	}
+	if waits[0] > containerWait || waits[1] <= containerWait || waits[1] > containerRetryWait {
+		t.Errorf("wait budgets: got %v then %v, want <= %v then <= %v", waits[0], waits[1], containerWait, containerRetryWait)
+	}
+}
+
+func TestScrape_ContainerWait_ContextDone_NoRetry(t *testing.T) {
+	// Arrange - the scrape's own context is already over
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	s := newMockedScraper(func(runCtx context.Context, actions ...chromedp.Action) error {
+		calls++
+		if calls == 2 {
+			cancel()
+			return context.Canceled
+		}
+		return nil
+	})
+
+	// Act
+	_, err := s.Scrape(ctx, "123")
+
+	// Assert
+	if err == nil {
+		t.Error("expected scrape error")
+	}
+	if calls != 2 {
+		t.Errorf("run calls: got %d, want 2 (no retry)", calls)
+	}
+}
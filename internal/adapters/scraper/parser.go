@@ -2,53 +2,262 @@ package scraper
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"html"
 	"regexp"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"sumariza-ai/internal/domain"
+	"sumariza-ai/internal/usecases"
 	"sumariza-ai/pkg/log"
 
 	"github.com/chromedp/chromedp"
 )
 
+// tabRunner provides exclusive access to a browser tab.
+// Implemented by BrowserPool; replaced in tests.
+type tabRunner interface {
+	WithTabCtx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// chromeLogSource exposes the browser's recent output. Implemented by
+// BrowserPool; tab runners without it simply log no Chrome output.
+type chromeLogSource interface {
+	ChromeLogTail() string
+}
+
 // TwitterScraper scrapes tweets from Twitter using Chromedp.
 type TwitterScraper struct {
-	pool      *BrowserPool
+	pool      tabRunner
 	selectors *SelectorConfig
+	observer  ScrapeObserver
+	upstream  upstreamTracker
+	emoji     EmojiMode
+	blocked   *LinkBlocklist
+	maxLinks  int
+
+	// trackingParams are query parameter prefixes stripped from links in
+	// tweet text. Empty (default) keeps links as they are.
+	trackingParams []string
+
+	// settleDelay is waited before extracting HTML, so late-hydrating
+	// content (metrics, media) makes it in. Zero disables it.
+	settleDelay time.Duration
+
+	// run executes chromedp actions; defaults to chromedp.Run.
+	run func(ctx context.Context, actions ...chromedp.Action) error
+
+	// outerHTML returns the page HTML; defaults to runOuterHTML.
+	outerHTML func(ctx context.Context) (string, error)
 }
 
+const (
+	// defaultMaxImages and defaultMaxLinks bound the images and links
+	// extracted from one tweet, so a page stuffed with thousands of tags
+	// can't make the parser allocate without limit.
+	defaultMaxImages = 20
+	defaultMaxLinks  = 50
+
+	// maxReplies bounds the replies collected from a tweet's page.
+	maxReplies = 10
+
+	// containerWait bounds the first wait for the tweet container.
+	containerWait = 8 * time.Second
+
+	// containerRetryWait bounds the single retry, for pages that hydrate late.
+	containerRetryWait = 15 * time.Second
+
+	// showMoreWait bounds clicking "Show more" and waiting for the full text.
+	showMoreWait = 3 * time.Second
+)
+
+// textOptions controls how a tweetText fragment is turned into text.
+// The zero value keeps emoji, blocks no links, strips no query parameters
+// and uses defaultMaxLinks.
+type textOptions struct {
+	emoji    EmojiMode
+	blocked  *LinkBlocklist
+	maxLinks int
+
+	// trackingParams are query parameter prefixes stripped from links
+	trackingParams []string
+}
+
+// showMoreSelector matches the primary tweet's "Show more" link, which long
+// tweets render instead of their full text. Quotes are excluded: their link
+// navigates to the quoted tweet. showMoreMarker finds it in the page HTML.
+const (
+	showMoreSelector = "article[data-testid='tweet'] [data-testid='tweet-text-show-more-link']:not([data-testid='quoteTweet'] *)"
+	showMoreMarker   = `data-testid="tweet-text-show-more-link"`
+)
+
+// Parser patterns, compiled once. The extract* helpers run on every scrape.
+var (
+	// tweetTextRe captures a tweetText container up to its first </div>;
+	// tweetTextOpenRe is the fallback for deeply nested containers.
+	tweetTextRe     = regexp.MustCompile(`data-testid="tweetText"[^>]*>([\s\S]*?)</div>`)
+	tweetTextOpenRe = regexp.MustCompile(`data-testid="tweetText"[^>]*>([\s\S]*?)<div`)
+
+	userNameRe     = regexp.MustCompile(`data-testid="User-Name"[^>]*>([\s\S]*?)</div></div></div>`)
+	statusHandleRe = regexp.MustCompile(`href="/([a-zA-Z0-9_]+)/status/`)
+	statusLinkRe   = regexp.MustCompile(`href="/([a-zA-Z0-9_]+)/status/([0-9]+)"`)
+	ogURLRe        = regexp.MustCompile(`<meta[^>]+property="og:url"[^>]+content="([^"]+)"`)
+	ogDescRe       = regexp.MustCompile(`<meta[^>]+property="og:description"[^>]+content="([^"]*)"`)
+	avatarRe       = regexp.MustCompile(`data-testid="Tweet-User-Avatar"[^>]*>.*?<img[^>]*src="([^"]+)"`)
+	timeRe         = regexp.MustCompile(`<time[^>]*datetime="([^"]+)"`)
+	tweetPhotoRe   = regexp.MustCompile(`data-testid="tweetPhoto"[^>]*>[\s\S]*?<img[^>]*src="([^"]+)"`)
+
+	// linkRe captures an <a>'s href and its entire content, nested tags included.
+	linkRe      = regexp.MustCompile(`<a[^>]*href="([^"]+)"[^>]*>([\s\S]*?)</a>`)
+	spanCloseRe = regexp.MustCompile(`</span>`)
+	brRe        = regexp.MustCompile(`<br\s*/?\s*>`)
+	divCloseRe  = regexp.MustCompile(`</div>`)
+	pCloseRe    = regexp.MustCompile(`</p>`)
+	tagRe       = regexp.MustCompile(`<[^>]*>`)
+
+	whitespaceRe = regexp.MustCompile(`\s+`)
+)
+
 // NewTwitterScraper creates a new Twitter scraper.
 func NewTwitterScraper(pool *BrowserPool, selectors *SelectorConfig) *TwitterScraper {
-	return &TwitterScraper{
+	s := &TwitterScraper{
 		pool:      pool,
 		selectors: selectors,
+		run:       chromedp.Run,
+	}
+	s.outerHTML = s.runOuterHTML
+	return s
+}
+
+// runOuterHTML extracts the page HTML through run.
+func (s *TwitterScraper) runOuterHTML(ctx context.Context) (string, error) {
+	var html string
+	err := s.run(ctx, chromedp.OuterHTML("html", &html))
+	return html, err
+}
+
+// SetObserver sets the observer notified at each scrape step.
+// A nil observer disables notifications.
+func (s *TwitterScraper) SetObserver(observer ScrapeObserver) {
+	s.observer = observer
+}
+
+// SetEmojiMode sets how emoji in tweet text are rendered (default EmojiKeep).
+func (s *TwitterScraper) SetEmojiMode(mode EmojiMode) {
+	s.emoji = mode
+}
+
+// SetLinkBlocklist sets the domains whose links are replaced with
+// "[link removed]" in tweet text. A nil blocklist keeps every link.
+func (s *TwitterScraper) SetLinkBlocklist(blocked *LinkBlocklist) {
+	s.blocked = blocked
+}
+
+// SetMaxLinks caps how many links are resolved per tweet text.
+// Zero or negative uses the default (50).
+func (s *TwitterScraper) SetMaxLinks(n int) {
+	s.maxLinks = n
+}
+
+// SetTrackingParams sets the query parameter prefixes (e.g. "utm_",
+// "fbclid") stripped from links in tweet text. Empty keeps links intact.
+func (s *TwitterScraper) SetTrackingParams(prefixes []string) {
+	s.trackingParams = prefixes
+}
+
+// textOptions returns how tweet text is cleaned by this scraper.
+func (s *TwitterScraper) textOptions() textOptions {
+	return textOptions{emoji: s.emoji, blocked: s.blocked, maxLinks: s.maxLinks, trackingParams: s.trackingParams}
+}
+
+// SetSettleDelay sets how long to wait after the tweet text appears before
+// extracting the page HTML. Zero (default) extracts immediately.
+func (s *TwitterScraper) SetSettleDelay(d time.Duration) {
+	s.settleDelay = d
+}
+
+// settle waits settleDelay on the tab, or returns early when ctx is done.
+func (s *TwitterScraper) settle(ctx context.Context) error {
+	if s.settleDelay <= 0 {
+		return nil
+	}
+	return s.run(ctx, chromedp.Sleep(s.settleDelay))
+}
+
+// expandTruncated clicks the primary tweet's "Show more" link, if present,
+// and waits for the full text to replace it. Failures are only logged: the
+// parser then marks the content as truncated.
+func (s *TwitterScraper) expandTruncated(ctx context.Context, tweetID string) {
+	var present bool
+	check := chromedp.Evaluate(`document.querySelector("`+showMoreSelector+`") !== null`, &present)
+	if err := s.run(ctx, check); err != nil || !present {
+		return
+	}
+
+	clickCtx, cancel := context.WithTimeout(ctx, showMoreWait)
+	defer cancel()
+	err := s.run(clickCtx,
+		chromedp.Click(showMoreSelector, chromedp.ByQuery),
+		chromedp.WaitNotPresent(showMoreSelector, chromedp.ByQuery),
+	)
+	if err != nil {
+		log.GlobalWarn("scrape show more failed, text may be truncated",
+			"tweet_id", tweetID,
+			"error", err)
+		return
+	}
+	log.GlobalDebug("scrape step: expanded long tweet", "tweet_id", tweetID)
+}
+
+// notifyStep records a finished step in the timeline and reports it to the
+// observer, if any.
+func (s *TwitterScraper) notifyStep(timeline scrapeTimeline, step string, start time.Time) {
+	elapsed := time.Since(start)
+	timeline[step] = elapsed
+	if s.observer != nil {
+		s.observer.OnStep(step, elapsed)
 	}
 }
 
 // Scrape fetches and parses a tweet from Twitter.
 func (s *TwitterScraper) Scrape(ctx context.Context, tweetID string) (*domain.Tweet, error) {
+	result := s.ScrapeResult(ctx, tweetID)
+	return result.Tweet, result.Err
+}
+
+// ScrapeResult fetches and parses a tweet from Twitter, classifying the outcome.
+func (s *TwitterScraper) ScrapeResult(ctx context.Context, tweetID string) domain.ScrapeResult {
 	// Use /i/status/{id} format for scraping (doesn't require username)
 	url := "https://twitter.com/i/status/" + tweetID
 
 	log.GlobalDebug("scrape starting", "tweet_id", tweetID, "url", url)
 	startTime := time.Now()
+	timeline := scrapeTimeline{}
 
 	var html string
+	navigated := false
 
 	// Execute scraping with exclusive tab access (backpressure)
 	// Using WithTabCtx to properly propagate context cancellation/timeout
 	err := s.pool.WithTabCtx(ctx, func(tabCtx context.Context) error {
 		// Step 1: Navigate to the URL
 		log.GlobalDebug("scrape step: navigating", "tweet_id", tweetID)
+		usecases.ReportProgress(ctx, usecases.StepNavigating)
 		navStart := time.Now()
-		if err := chromedp.Run(tabCtx, chromedp.Navigate(url)); err != nil {
+		err := s.run(tabCtx, chromedp.Navigate(url))
+		s.notifyStep(timeline, StepNavigate, navStart)
+		if err != nil {
 			log.GlobalError("scrape navigation failed",
 				"tweet_id", tweetID,
 				"error", err,
 				"duration_ms", time.Since(navStart).Milliseconds())
 			return err
 		}
+		navigated = true
 		log.GlobalDebug("scrape step: navigation complete",
 			"tweet_id", tweetID,
 			"duration_ms", time.Since(navStart).Milliseconds())
@@ -63,9 +272,13 @@ func (s *TwitterScraper) Scrape(ctx context.Context, tweetID string) (*domain.Tw
 
 		// Step 2: Wait for tweet container
 		log.GlobalDebug("scrape step: waiting for container", "tweet_id", tweetID)
+		usecases.ReportProgress(ctx, usecases.StepWaiting)
 		containerStart := time.Now()
-		containerSelector := s.selectors.GetTweetContainer()
-		if err := chromedp.Run(tabCtx, chromedp.WaitVisible(containerSelector, chromedp.ByQuery)); err != nil {
+		// Unavailable pages (protected account) never render the tweet
+		containerSelector := s.selectors.GetTweetContainer() + ", " + unavailableSelector
+		err = s.waitContainer(tabCtx, tweetID, containerSelector)
+		s.notifyStep(timeline, StepWaitContainer, containerStart)
+		if err != nil {
 			log.GlobalError("scrape wait container failed",
 				"tweet_id", tweetID,
 				"selector", containerSelector,
@@ -88,8 +301,10 @@ func (s *TwitterScraper) Scrape(ctx context.Context, tweetID string) (*domain.Tw
 		// Step 3: Wait for tweet text
 		log.GlobalDebug("scrape step: waiting for text", "tweet_id", tweetID)
 		textStart := time.Now()
-		textSelector := s.selectors.GetTweetText()
-		if err := chromedp.Run(tabCtx, chromedp.WaitVisible(textSelector, chromedp.ByQuery)); err != nil {
+		textSelector := s.selectors.GetTweetText() + ", " + unavailableSelector
+		err = s.run(tabCtx, chromedp.WaitVisible(textSelector, chromedp.ByQuery))
+		s.notifyStep(timeline, StepWaitText, textStart)
+		if err != nil {
 			log.GlobalError("scrape wait text failed",
 				"tweet_id", tweetID,
 				"selector", textSelector,
@@ -109,10 +324,23 @@ func (s *TwitterScraper) Scrape(ctx context.Context, tweetID string) (*domain.Tw
 			return tabCtx.Err()
 		}
 
+		// Let late content hydrate (SCRAPE_SETTLE_MS)
+		if err := s.settle(tabCtx); err != nil {
+			log.GlobalWarn("scrape settle interrupted",
+				"tweet_id", tweetID,
+				"error", err)
+			return err
+		}
+
+		// Long tweets hide their tail behind "Show more"
+		s.expandTruncated(tabCtx, tweetID)
+
 		// Step 4: Extract HTML
 		log.GlobalDebug("scrape step: extracting html", "tweet_id", tweetID)
 		htmlStart := time.Now()
-		if err := chromedp.Run(tabCtx, chromedp.OuterHTML("html", &html)); err != nil {
+		html, err = s.outerHTML(tabCtx)
+		s.notifyStep(timeline, StepExtract, htmlStart)
+		if err != nil {
 			log.GlobalError("scrape html extraction failed",
 				"tweet_id", tweetID,
 				"error", err,
@@ -128,61 +356,221 @@ func (s *TwitterScraper) Scrape(ctx context.Context, tweetID string) (*domain.Tw
 	})
 
 	if err != nil {
-		log.GlobalError("scrape failed",
-			"tweet_id", tweetID,
-			"error", err,
-			"total_duration_ms", time.Since(startTime).Milliseconds())
-		return nil, domain.ErrScrapingFailed
+		// Twitter answered but never rendered the tweet: likely blocked
+		if navigated && ctx.Err() == nil {
+			s.upstream.set(UpstreamBlocked)
+		}
+		log.GlobalError("scrape failed", s.failureLogFields(tweetID, err,
+			"timeline", timeline.millis(),
+			"total_duration_ms", time.Since(startTime).Milliseconds())...)
+		failure := scrapeFailed(ctx, tweetID, err)
+		if errors.Is(err, domain.ErrBrowserUnavailable) {
+			failure = domain.ErrBrowserUnavailable
+		}
+		result := domain.NewScrapeResult(nil, failure)
+		result.Timeline = timeline
+		return result
 	}
 
 	log.GlobalDebug("scrape complete, parsing html",
 		"tweet_id", tweetID,
 		"total_duration_ms", time.Since(startTime).Milliseconds())
+	usecases.ReportProgress(ctx, usecases.StepParsing)
 
-	tweet, partial := s.parseHTML(html, tweetID)
+	parseStart := time.Now()
+	result := s.resultFromHTML(ctx, html, tweetID)
+	s.notifyStep(timeline, StepParse, parseStart)
+	result.Timeline = timeline
 
-	// Text is essential - fail if not found
-	if tweet.Content.Text == "" {
+	switch result.Status {
+	case domain.ScrapeError:
+		log.GlobalWarn("scrape parsing aborted",
+			"tweet_id", tweetID,
+			"error", result.Err,
+			"html_length", len(html))
+	case domain.ScrapeBlocked:
 		log.GlobalError("scrape text not found in html",
 			"tweet_id", tweetID,
 			"html_length", len(html))
-		return nil, domain.ErrTextNotFound
+		s.upstream.set(UpstreamBlocked)
+	case domain.ScrapeNotFound, domain.ScrapePrivate, domain.ScrapeSensitive:
+		log.GlobalInfo("scrape tweet unavailable", "tweet_id", tweetID, "status", result.Status)
+		s.upstream.set(UpstreamOK)
+	default:
+		s.upstream.set(UpstreamOK)
+		if result.Status == domain.ScrapePartial {
+			log.GlobalDebug("partial data retrieved", "tweet_id", tweetID, "missing", result.Tweet.PartialReasons)
+		}
+		log.GlobalInfo("scrape success",
+			"tweet_id", tweetID,
+			"partial", result.Tweet.Partial,
+			"timeline", timeline.millis(),
+			"total_duration_ms", time.Since(startTime).Milliseconds())
+	}
+
+	return result
+}
+
+// failureLogFields returns the fields of a scrape failure log, with the
+// Chrome output tail when the browser was running: a crashed renderer or a
+// killed Chrome explains itself there. Launch failures already log it.
+func (s *TwitterScraper) failureLogFields(tweetID string, err error, extra ...any) []any {
+	fields := append([]any{"tweet_id", tweetID, "error", err}, extra...)
+	if errors.Is(err, domain.ErrBrowserUnavailable) {
+		return fields
+	}
+	if src, ok := s.pool.(chromeLogSource); ok {
+		if tail := src.ChromeLogTail(); tail != "" {
+			fields = append(fields, "chrome_logs", tail)
+		}
 	}
+	return fields
+}
+
+// scrapeFailed wraps domain.ErrScrapingFailed with the request ID from ctx
+// and the underlying cause, so the error shown to the user can be tied to the
+// scrape failure in aggregated logs. errors.Is(err, domain.ErrScrapingFailed)
+// still holds.
+func scrapeFailed(ctx context.Context, tweetID string, cause error) error {
+	if requestID := log.RequestIDFromContext(ctx); requestID != "" {
+		return fmt.Errorf("%w (request_id=%s, tweet_id=%s): %w", domain.ErrScrapingFailed, requestID, tweetID, cause)
+	}
+	return fmt.Errorf("%w (tweet_id=%s): %w", domain.ErrScrapingFailed, tweetID, cause)
+}
 
+// resultFromHTML classifies a fetched tweet page and parses the tweet.
+// Parsing stops early with the context's error once ctx is done.
+func (s *TwitterScraper) resultFromHTML(ctx context.Context, html, tweetID string) domain.ScrapeResult {
+	if err := ctx.Err(); err != nil {
+		return domain.NewScrapeResult(nil, err)
+	}
+
+	// Twitter answered with a page explaining why the tweet isn't shown
+	if err := ClassifyPage(html); err != nil {
+		return domain.NewScrapeResult(nil, err)
+	}
+
+	tweet, partial, err := s.parseHTMLContext(ctx, html, tweetID)
+	if err != nil {
+		return domain.NewScrapeResult(nil, err)
+	}
+
+	// Text is essential, unless the tweet is only media, a poll or a card
+	if tweet.Content.Text == "" {
+		// A warning overlay hides the text until clicked through
+		if hasSensitiveWarning(html) {
+			return domain.NewScrapeResult(nil, domain.ErrSensitiveContent)
+		}
+		if !hasEssentialContent(tweet, html) {
+			return domain.NewScrapeResult(nil, domain.ErrTextNotFound)
+		}
+	}
 	tweet.Partial = partial
 
-	if partial {
-		log.GlobalDebug("partial data retrieved", "tweet_id", tweetID)
+	return domain.NewScrapeResult(tweet, nil)
+}
+
+// waitContainer waits for the tweet container in two phases: a short wait,
+// then one longer retry for pages that hydrate late. Both are bounded by ctx.
+func (s *TwitterScraper) waitContainer(ctx context.Context, tweetID, selector string) error {
+	waitCtx, cancel := context.WithTimeout(ctx, containerWait)
+	err := s.run(waitCtx, chromedp.WaitVisible(selector, chromedp.ByQuery))
+	cancel()
+	if err == nil || ctx.Err() != nil {
+		return err
 	}
 
-	log.GlobalInfo("scrape success",
+	log.GlobalWarn("scrape container not visible yet, retrying with longer wait",
 		"tweet_id", tweetID,
-		"partial", partial,
-		"total_duration_ms", time.Since(startTime).Milliseconds())
+		"error", err,
+		"retry_timeout", containerRetryWait)
+
+	retryCtx, cancel := context.WithTimeout(ctx, containerRetryWait)
+	defer cancel()
+	return s.run(retryCtx, chromedp.WaitVisible(selector, chromedp.ByQuery))
+}
+
+// ParseTweetHTML parses tweet page HTML without a scraper instance.
+// A nil selectors uses DefaultSelectors. The bool result reports whether
+// optional fields were missing.
+func ParseTweetHTML(html, tweetID string, selectors *SelectorConfig) (*domain.Tweet, bool) {
+	if selectors == nil {
+		selectors = DefaultSelectors()
+	}
+	s := &TwitterScraper{selectors: selectors}
+	return s.parseHTML(html, tweetID)
+}
+
+// ParseHTML parses tweet page HTML obtained elsewhere, without scraping.
+// The bool result reports whether optional fields were missing.
+func (s *TwitterScraper) ParseHTML(html, tweetID string) (*domain.Tweet, bool) {
+	return s.parseHTML(html, tweetID)
+}
 
-	return tweet, nil
+// HasEssentialContent reports whether a tweet parsed from html has text, or
+// is only media, a poll or a card, the same check scrapes pass.
+func (s *TwitterScraper) HasEssentialContent(tweet *domain.Tweet, html string) bool {
+	return hasEssentialContent(tweet, html)
 }
 
 // parseHTML extracts tweet data from the HTML.
+// Author and content are read from the primary tweet's article only, so
+// quoted tweets and reply context can't leak into them.
+// The tweet's PartialReasons list the optional fields that could not be found.
 func (s *TwitterScraper) parseHTML(html, tweetID string) (*domain.Tweet, bool) {
-	partial := false
+	tweet, partial, _ := s.parseHTMLContext(context.Background(), html, tweetID)
+	return tweet, partial
+}
 
+// parseHTMLContext is parseHTML with cancellation: ctx is checked between
+// the parse stages, so a canceled request stops before the next regex pass
+// over a large page and returns ctx's error.
+func (s *TwitterScraper) parseHTMLContext(ctx context.Context, html, tweetID string) (*domain.Tweet, bool, error) {
 	tweet := &domain.Tweet{
 		ID: tweetID,
 	}
+	primary := primaryTweetScope(html)
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
 
 	// Parse author info
-	tweet.Author, partial = s.parseAuthor(html)
+	tweet.Author, tweet.PartialReasons = s.parseAuthor(withoutQuoteTweet(primary))
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
 
 	// Parse content
-	tweet.Content = s.parseContent(html)
+	var fromMeta bool
+	tweet.Content, fromMeta = s.parseContent(primary, html)
+	if fromMeta {
+		tweet.PartialReasons = append(tweet.PartialReasons, domain.PartialReasonText)
+	}
+	partial := len(tweet.PartialReasons) > 0
+	tweet.Content.InReplyToID, tweet.Content.InReplyToURL = extractInReplyTo(withoutQuoteTweet(primary), tweetID)
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
 
-	return tweet, partial
+	// Collect the replies rendered below the tweet
+	replies, err := s.parseReplies(ctx, html, tweet.Author.Handle)
+	if err != nil {
+		return nil, false, err
+	}
+	tweet.Content.Replies = replies
+
+	// Parse engagement counts
+	tweet.Metrics = extractMetrics(withoutQuoteTweet(primary))
+
+	tweet.ResolvedURL = extractResolvedURL(html, primary, tweetID)
+
+	return tweet, partial, nil
 }
 
 // parseAuthor extracts author information from the HTML.
-func (s *TwitterScraper) parseAuthor(html string) (domain.Author, bool) {
-	partial := false
+// It also returns the reasons for any missing field.
+func (s *TwitterScraper) parseAuthor(html string) (domain.Author, []string) {
+	var missing []string
 	author := domain.Author{}
 
 	// Extract author name and handle from User-Name testid
@@ -191,7 +579,7 @@ func (s *TwitterScraper) parseAuthor(html string) (domain.Author, bool) {
 	if name != "" {
 		author.Name = name
 	} else {
-		partial = true
+		missing = append(missing, domain.PartialReasonAuthorName)
 	}
 
 	if handle != "" {
@@ -202,7 +590,7 @@ func (s *TwitterScraper) parseAuthor(html string) (domain.Author, bool) {
 		if handleMatch != "" {
 			author.Handle = handleMatch
 		} else {
-			partial = true
+			missing = append(missing, domain.PartialReasonAuthorHandle)
 		}
 	}
 
@@ -211,7 +599,7 @@ func (s *TwitterScraper) parseAuthor(html string) (domain.Author, bool) {
 	if avatarMatch != "" {
 		author.AvatarURL = avatarMatch
 	} else {
-		partial = true
+		missing = append(missing, domain.PartialReasonAvatar)
 	}
 
 	// Check for verified badge
@@ -220,121 +608,262 @@ func (s *TwitterScraper) parseAuthor(html string) (domain.Author, bool) {
 		author.VerifiedType = detectVerifiedType(html)
 	}
 
-	return author, partial
+	return author, missing
 }
 
 // parseContent extracts the tweet content from the HTML.
-func (s *TwitterScraper) parseContent(html string) domain.Content {
-	content := domain.Content{
+// Everything but the quoted tweet is read outside the quote's container.
+// When the DOM has no tweet text, the text falls back to the og:description
+// meta tag of the whole page; fromMeta reports that it did.
+func (s *TwitterScraper) parseContent(html, page string) (content domain.Content, fromMeta bool) {
+	content = domain.Content{
 		Direction: domain.LTR,
 	}
+	own := withoutQuoteTweet(html)
 
 	// Extract tweet text (already cleaned with newlines preserved)
-	textMatch := extractTweetText(html)
-	if textMatch != "" {
-		content.Text = textMatch
+	if fragment, ok := tweetTextFragment(own); ok {
+		opts := s.textOptions()
+		content.Text = cleanTweetHTML(fragment, opts)
+		content.Tokens = tweetTokens(fragment, opts)
 	}
+	if content.Text == "" {
+		content.Tokens = nil
+		if desc := extractOGDescription(page); desc != "" {
+			content.Text = desc
+			content.Tokens = []domain.Token{{Kind: domain.TokenText, Value: desc}}
+			fromMeta = true
+		}
+	}
+
+	// A leftover "Show more" means only the prefix was rendered
+	content.Truncated = content.Text != "" && !fromMeta && strings.Contains(own, showMoreMarker)
 
 	// Extract text direction
-	content.Direction = extractTextDirection(html)
+	content.Direction = extractTextDirection(own)
 
 	// Extract timestamp
-	content.CreatedAt = extractTimestamp(html)
+	content.CreatedAt = extractTimestamp(own)
+
+	// Extract photos, videos and GIFs, leaving the quote's media out
+	content.Media = extractMedia(own, defaultMaxImages)
 
 	// Extract quoted tweet (1 level only)
-	content.QuotedTweet = extractQuotedTweet(html)
+	content.QuotedTweet = extractQuotedTweet(html, s.textOptions())
+
+	return content, fromMeta
+}
+
+// parseReplies extracts up to maxReplies replies following the primary
+// tweet. Articles by the tweet's own author are its thread continuation and
+// are skipped, as are articles without text. ctx is checked per reply.
+func (s *TwitterScraper) parseReplies(ctx context.Context, html, authorHandle string) ([]domain.ReplyItem, error) {
+	var replies []domain.ReplyItem
+	for scope := range followingTweetScopes(html) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if len(replies) == maxReplies {
+			break
+		}
+
+		own := withoutQuoteTweet(scope)
+		name, handle := extractNameAndHandle(own)
+		if handle == "" {
+			handle = extractHandleFromURL(own)
+		}
+		if handle == "" || strings.EqualFold(handle, authorHandle) {
+			continue
+		}
+
+		text := extractTweetText(own, s.textOptions())
+		if text == "" {
+			continue
+		}
 
-	return content
+		reply := domain.ReplyItem{
+			Author: domain.Author{Name: name, Handle: handle, AvatarURL: extractAvatar(own)},
+			Text:   text,
+		}
+		if m := statusLinkRe.FindStringSubmatch(own); len(m) > 2 {
+			reply.ID = m[2]
+		}
+		replies = append(replies, reply)
+	}
+	return replies, nil
+}
+
+// extractOGDescription returns the og:description meta tag's text, with
+// entities decoded and the curly quotes X wraps it in removed.
+func extractOGDescription(page string) string {
+	m := ogDescRe.FindStringSubmatch(page)
+	if len(m) < 2 {
+		return ""
+	}
+	text := strings.TrimSpace(html.UnescapeString(m[1]))
+	text = strings.TrimPrefix(text, "“")
+	text = strings.TrimSuffix(text, "”")
+	return cleanTextPreserveNewlines(text)
 }
 
 // extractTweetText extracts the main tweet text from HTML, preserving links with full URLs.
-func extractTweetText(html string) string {
+func extractTweetText(html string, opts textOptions) string {
+	fragment, ok := tweetTextFragment(html)
+	if !ok {
+		return ""
+	}
+	return cleanTweetHTML(fragment, opts)
+}
+
+// tweetTextFragment returns the inner HTML of the first tweetText container.
+func tweetTextFragment(html string) (string, bool) {
 	// Find the tweetText container - Twitter uses div with nested spans
 	// The content may be in a div that contains multiple spans with the actual text
-	re := regexp.MustCompile(`data-testid="tweetText"[^>]*>([\s\S]*?)</div>`)
-	matches := re.FindStringSubmatch(html)
+	matches := tweetTextRe.FindStringSubmatch(html)
 	if len(matches) < 2 {
 		// Try without closing div (might be deeply nested)
-		re2 := regexp.MustCompile(`data-testid="tweetText"[^>]*>([\s\S]*?)<div`)
-		matches = re2.FindStringSubmatch(html)
+		matches = tweetTextOpenRe.FindStringSubmatch(html)
 		if len(matches) < 2 {
-			return ""
+			return "", false
 		}
 	}
+	return matches[1], true
+}
 
-	content := matches[1]
+// cleanTweetHTML converts a tweetText HTML fragment into plain text, keeping
+// link markers, emoji (rendered per the emoji mode) and line breaks. Links to
+// blocked domains are replaced with blockedLinkText. The result is always
+// valid UTF-8 and cleaning it again yields the same text.
+func cleanTweetHTML(content string, opts textOptions) string {
+	// Drop invalid byte sequences up front so every regex sees the same input
+	content = strings.ToValidUTF8(content, "")
+	content = strings.ReplaceAll(content, linkPad, "")
 
 	// Replace links with their full href URLs
 	// Twitter uses <a href="FULL_URL">truncated_text</a>
 	// We want to preserve the full URL from href
-	content = preserveLinks(content)
+	content = preserveLinks(content, opts)
 
 	// Convert closing </span> to preserve line structure
 	// Twitter puts newlines inside <span> tags
-	content = regexp.MustCompile(`</span>`).ReplaceAllString(content, "")
+	content = spanCloseRe.ReplaceAllString(content, "")
 
 	// Remove remaining HTML tags (spans, etc.) but keep the processed links
 	// This also converts <br>, </div>, </p> to newlines
-	content = stripHTMLKeepLinks(content)
+	content = stripHTMLKeepLinks(content, opts.emoji)
+
+	// Space links naturally now that their neighbours are plain text
+	content = resolveLinkPadding(content)
 
 	// Clean text while preserving newlines for formatting
 	return cleanTextPreserveNewlines(content)
 }
 
 // preserveLinks replaces Twitter's truncated link text with the full URL from href.
-func preserveLinks(html string) string {
-	// Simple regex to match <a> tags - captures href and the entire link content
-	// Using [\s\S]*? for content to handle nested tags
-	linkRe := regexp.MustCompile(`<a[^>]*href="([^"]+)"[^>]*>([\s\S]*?)</a>`)
-
-	return linkRe.ReplaceAllStringFunc(html, func(match string) string {
-		submatches := linkRe.FindStringSubmatch(match)
-		if len(submatches) >= 2 {
-			href := submatches[1]
-			// Skip Twitter internal links (hashtags, mentions, etc.)
-			if strings.HasPrefix(href, "/") ||
-				strings.Contains(href, "twitter.com/hashtag") ||
-				strings.Contains(href, "twitter.com/search") ||
-				strings.Contains(href, "x.com/hashtag") ||
-				strings.Contains(href, "x.com/search") {
-				// For hashtags/mentions, just return the visible text
-				if len(submatches) >= 3 {
-					return " " + stripHTML(submatches[2]) + " "
-				}
-				return ""
-			}
-			// For external links (including t.co redirects), use the full URL from href
-			// Mark it with special delimiters so we can convert back to link later
-			return " [[LINK:" + href + "]] "
+// Matches are located in a single pass over the input, so overlapping or nested
+// anchors are never re-matched against already rewritten output.
+// Only the first opts.maxLinks anchors are resolved; later ones are left for
+// tag stripping, which keeps just their visible text.
+func preserveLinks(html string, opts textOptions) string {
+	limit := opts.maxLinks
+	if limit <= 0 {
+		limit = defaultMaxLinks
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, loc := range linkRe.FindAllStringSubmatchIndex(html, limit) {
+		b.WriteString(html[last:loc[0]])
+		last = loc[1]
+
+		href := html[loc[2]:loc[3]]
+		// Skip Twitter internal links (hashtags, mentions, etc.)
+		if isInternalLink(href) {
+			// For hashtags/mentions, just return the visible text
+			b.WriteString(linkPad + stripHTML(html[loc[4]:loc[5]]) + linkPad)
+			continue
 		}
-		return match
-	})
+		// t.co hides the destination, so check the display text too
+		if opts.blocked.Blocks(href) || opts.blocked.Blocks(stripHTML(html[loc[4]:loc[5]])) {
+			b.WriteString(linkPad + blockedLinkText + linkPad)
+			continue
+		}
+		// For external links (including t.co redirects), use the full URL from href
+		// Mark it with special delimiters so we can convert back to link later
+		b.WriteString(linkPad + "[[LINK:" + opts.linkURL(href) + "]]" + linkPad)
+	}
+	b.WriteString(html[last:])
+
+	return b.String()
+}
+
+// isInternalLink reports whether href points inside Twitter (a profile,
+// hashtag or search) rather than at an external page.
+func isInternalLink(href string) bool {
+	return strings.HasPrefix(href, "/") ||
+		strings.Contains(href, "twitter.com/hashtag") ||
+		strings.Contains(href, "twitter.com/search") ||
+		strings.Contains(href, "x.com/hashtag") ||
+		strings.Contains(href, "x.com/search")
+}
+
+// linkPad marks where preserveLinks may need a space around a link. It is
+// resolved by resolveLinkPadding once the surrounding text is known.
+const linkPad = "\x1f"
+
+// resolveLinkPadding turns each run of link pads into a single space, or
+// nothing when the link already touches whitespace, the start or end of the
+// text, closing punctuation after it, or opening punctuation before it.
+func resolveLinkPadding(text string) string {
+	if !strings.Contains(text, linkPad) {
+		return text
+	}
+
+	var b strings.Builder
+	b.Grow(len(text))
+	var prev rune
+	for i := 0; i < len(text); {
+		if !strings.HasPrefix(text[i:], linkPad) {
+			r, size := utf8.DecodeRuneInString(text[i:])
+			b.WriteRune(r)
+			prev = r
+			i += size
+			continue
+		}
+
+		for strings.HasPrefix(text[i:], linkPad) {
+			i += len(linkPad)
+		}
+		next, _ := utf8.DecodeRuneInString(text[i:])
+		if prev != 0 && i < len(text) &&
+			!unicode.IsSpace(prev) && !unicode.IsSpace(next) &&
+			!strings.ContainsRune("([{", prev) && !strings.ContainsRune(".,;:!?)]}…", next) {
+			b.WriteByte(' ')
+		}
+	}
+
+	return b.String()
 }
 
 // stripHTMLKeepLinks removes HTML tags but preserves our link markers, emojis, and converts line breaks.
-func stripHTMLKeepLinks(html string) string {
+func stripHTMLKeepLinks(html string, emoji EmojiMode) string {
 	// Convert line break elements to newlines BEFORE removing tags
-	html = regexp.MustCompile(`<br\s*/?\s*>`).ReplaceAllString(html, "\n")
-	html = regexp.MustCompile(`</div>`).ReplaceAllString(html, "\n")
-	html = regexp.MustCompile(`</p>`).ReplaceAllString(html, "\n")
+	html = brRe.ReplaceAllString(html, "\n")
+	html = divCloseRe.ReplaceAllString(html, "\n")
+	html = pCloseRe.ReplaceAllString(html, "\n")
 
-	// Preserve emojis from <img alt="emoji"> tags (Twitter renders emojis as images)
-	// Replace <img alt="X" ...> with the alt text (emoji)
-	emojiRe := regexp.MustCompile(`<img[^>]*alt="([^"]*)"[^>]*>`)
-	html = emojiRe.ReplaceAllString(html, "$1")
+	// Render emojis from <img alt="emoji"> tags (Twitter renders emojis as images)
+	html = emoji.replaceEmoji(html)
 
 	// Remove remaining HTML tags
-	re := regexp.MustCompile(`<[^>]*>`)
-	text := re.ReplaceAllString(html, "")
-	return text
+	return tagRe.ReplaceAllString(html, "")
 }
 
 // extractNameAndHandle extracts display name and handle from User-Name testid.
 // Twitter structure: [data-testid="User-Name"] contains "DisplayName @handle"
 func extractNameAndHandle(html string) (name, handle string) {
 	// Find the User-Name container
-	re := regexp.MustCompile(`data-testid="User-Name"[^>]*>([\s\S]*?)</div></div></div>`)
-	matches := re.FindStringSubmatch(html)
+	matches := userNameRe.FindStringSubmatch(html)
 	if len(matches) < 2 {
 		return "", ""
 	}
@@ -364,18 +893,55 @@ func extractNameAndHandle(html string) (name, handle string) {
 
 // extractHandleFromURL extracts the @handle from status URL in HTML.
 func extractHandleFromURL(html string) string {
-	re := regexp.MustCompile(`href="/([a-zA-Z0-9_]+)/status/`)
-	matches := re.FindStringSubmatch(html)
+	matches := statusHandleRe.FindStringSubmatch(html)
 	if len(matches) > 1 {
 		return matches[1]
 	}
 	return ""
 }
 
+// extractResolvedURL returns the tweet's canonical permalink: the og:url
+// meta tag when it points at tweetID, else the primary tweet's own status
+// anchor. Either way the handle is the one X rendered, not the one in the
+// request URL.
+func extractResolvedURL(html, primary, tweetID string) string {
+	suffix := "/status/" + tweetID
+	if m := ogURLRe.FindStringSubmatch(html); len(m) > 1 && strings.HasSuffix(m[1], suffix) {
+		return m[1]
+	}
+
+	for _, m := range statusLinkRe.FindAllStringSubmatch(primary, -1) {
+		if m[2] == tweetID {
+			return "https://x.com/" + m[1] + suffix
+		}
+	}
+	return ""
+}
+
+// replyingToMarker starts the "Replying to @handle" context of a reply.
+const replyingToMarker = "Replying to"
+
+// extractInReplyTo returns the parent of a reply: the first status anchor
+// after the tweet's "Replying to" context that points at another tweet.
+// Links to tweetID (its own timestamp and analytics) are skipped. Both are
+// empty for tweets without reply context or without a parent link.
+func extractInReplyTo(own, tweetID string) (id, url string) {
+	idx := strings.Index(own, replyingToMarker)
+	if idx < 0 {
+		return "", ""
+	}
+
+	for _, m := range statusLinkRe.FindAllStringSubmatch(own[idx:], -1) {
+		if m[2] != tweetID {
+			return m[2], "https://x.com/" + m[1] + "/status/" + m[2]
+		}
+	}
+	return "", ""
+}
+
 // extractAvatar extracts the avatar URL from HTML.
 func extractAvatar(html string) string {
-	re := regexp.MustCompile(`data-testid="Tweet-User-Avatar"[^>]*>.*?<img[^>]*src="([^"]+)"`)
-	matches := re.FindStringSubmatch(html)
+	matches := avatarRe.FindStringSubmatch(html)
 	if len(matches) > 1 {
 		return matches[1]
 	}
@@ -392,8 +958,7 @@ func extractTextDirection(html string) domain.TextDirection {
 
 // extractTimestamp extracts the tweet timestamp from HTML.
 func extractTimestamp(html string) time.Time {
-	re := regexp.MustCompile(`<time[^>]*datetime="([^"]+)"`)
-	matches := re.FindStringSubmatch(html)
+	matches := timeRe.FindStringSubmatch(html)
 	if len(matches) > 1 {
 		t, err := time.Parse(time.RFC3339, matches[1])
 		if err == nil {
@@ -404,21 +969,43 @@ func extractTimestamp(html string) time.Time {
 }
 
 // extractQuotedTweet extracts a quoted tweet (1 level only).
-func extractQuotedTweet(html string) *domain.QuotedTweet {
-	if !strings.Contains(html, `data-testid="quoteTweet"`) {
+// Every field is read from within the quoteTweet container, so the outer
+// tweet's author and media never bleed into the quote.
+func extractQuotedTweet(html string, opts textOptions) *domain.QuotedTweet {
+	scope := quoteTweetScope(html)
+	if scope == "" {
 		return nil
 	}
 
-	// Extract quoted tweet section
-	re := regexp.MustCompile(`data-testid="quoteTweet"[\s\S]*?data-testid="tweetText"[^>]*>([^<]+)`)
-	matches := re.FindStringSubmatch(html)
-	if len(matches) < 2 {
+	text := extractTweetText(scope, opts)
+	if text == "" {
+		if isUnavailableQuote(scope) {
+			return &domain.QuotedTweet{Unavailable: true}
+		}
 		return nil
 	}
 
-	return &domain.QuotedTweet{
-		Text: cleanText(matches[1]),
+	name, handle := extractNameAndHandle(scope)
+	if handle == "" {
+		handle = extractHandleFromURL(scope)
+	}
+
+	quoted := &domain.QuotedTweet{
+		Author: domain.Author{
+			Name:      name,
+			Handle:    handle,
+			AvatarURL: extractAvatar(scope),
+		},
+		Text:     text,
+		HasMedia: extractHasVideo(scope) || len(extractImages(scope, 1)) > 0,
+	}
+
+	if strings.Contains(scope, `data-testid="icon-verified"`) {
+		quoted.Author.Verified = true
+		quoted.Author.VerifiedType = detectVerifiedType(scope)
 	}
+
+	return quoted
 }
 
 // detectVerifiedType determines the type of verification badge.
@@ -447,40 +1034,67 @@ func extractBySelector(html, selector string) string {
 // cleanText removes extra whitespace and trims the text.
 func cleanText(text string) string {
 	// Remove multiple spaces
-	re := regexp.MustCompile(`\s+`)
-	text = re.ReplaceAllString(text, " ")
+	text = whitespaceRe.ReplaceAllString(text, " ")
 	return strings.TrimSpace(text)
 }
 
 // cleanTextPreserveNewlines normalizes horizontal whitespace but preserves line breaks.
+// Each line is trimmed, runs of spaces, tabs, \f and \r inside it become one
+// space, blank lines collapse to at most one empty line (max 2 newlines), and
+// leading and trailing blank lines are dropped. It works in a single pass
+// with one allocation for the result.
 func cleanTextPreserveNewlines(text string) string {
-	// Normalize horizontal whitespace only (spaces, tabs) - not newlines
-	re := regexp.MustCompile(`[^\S\n]+`)
-	text = re.ReplaceAllString(text, " ")
+	var b strings.Builder
+	b.Grow(len(text))
+
+	// breaks counts the line breaks since the last non-empty line. Lines are
+	// trimmed before counting, so whitespace-only lines count as blank and
+	// cleaning the result again yields the same text.
+	breaks := 0
+	for {
+		line, rest, more := strings.Cut(text, "\n")
+		if line = strings.TrimSpace(line); line != "" {
+			if b.Len() > 0 {
+				b.WriteString("\n\n"[:min(breaks, 2)])
+			}
+			writeCollapsedSpaces(&b, line)
+			breaks = 0
+		}
+		if !more {
+			break
+		}
+		text = rest
+		breaks++
+	}
 
-	// Collapse multiple newlines to max 2 (paragraph separation)
-	re2 := regexp.MustCompile(`\n{3,}`)
-	text = re2.ReplaceAllString(text, "\n\n")
+	return b.String()
+}
 
-	// Trim spaces from each line
-	lines := strings.Split(text, "\n")
-	for i, line := range lines {
-		lines[i] = strings.TrimSpace(line)
+// writeCollapsedSpaces writes line with each run of horizontal whitespace
+// (the ASCII \s class minus \n) replaced by a single space.
+func writeCollapsedSpaces(b *strings.Builder, line string) {
+	inSpace := false
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; c {
+		case ' ', '\t', '\f', '\r':
+			if !inSpace {
+				b.WriteByte(' ')
+			}
+			inSpace = true
+		default:
+			b.WriteByte(c)
+			inSpace = false
+		}
 	}
-
-	return strings.TrimSpace(strings.Join(lines, "\n"))
 }
 
 // stripHTML removes HTML tags from a string, preserving emoji alt text.
 func stripHTML(html string) string {
 	// Preserve emojis from <img alt="emoji"> tags (Twitter renders emojis as images)
-	emojiRe := regexp.MustCompile(`<img[^>]*alt="([^"]*)"[^>]*>`)
-	html = emojiRe.ReplaceAllString(html, "$1")
+	html = emojiImgRe.ReplaceAllString(html, "$1")
 
 	// Remove remaining HTML tags
-	re := regexp.MustCompile(`<[^>]*>`)
-	text := re.ReplaceAllString(html, "")
-	return cleanText(text)
+	return cleanText(tagRe.ReplaceAllString(html, ""))
 }
 
 // extractHasVideo checks if the tweet contains a video.
@@ -490,24 +1104,53 @@ func extractHasVideo(html string) bool {
 		strings.Contains(html, `data-testid="videoComponent"`)
 }
 
-// extractImages extracts image URLs from the tweet.
-// Twitter uses data-testid="tweetPhoto" for images.
-func extractImages(html string) []string {
+// extractImages extracts up to limit distinct image URLs from the tweet, in
+// page order; matching stops once limit is reached. Twitter uses
+// data-testid="tweetPhoto" for images. The same photo often appears in
+// several sizes, so only the first URL per media key (see imageKey) is kept.
+func extractImages(html string, limit int) []string {
+	if limit <= 0 {
+		limit = defaultMaxImages
+	}
+
 	if !strings.Contains(html, `data-testid="tweetPhoto"`) {
 		return nil
 	}
 
 	// Find image URLs within tweetPhoto containers
 	// Twitter uses <img src="..."> inside these containers
-	re := regexp.MustCompile(`data-testid="tweetPhoto"[^>]*>[\s\S]*?<img[^>]*src="([^"]+)"`)
-	matches := re.FindAllStringSubmatch(html, -1)
-
 	var images []string
-	for _, match := range matches {
-		if len(match) > 1 {
-			images = append(images, match[1])
+	seen := make(map[string]struct{})
+	for offset := 0; len(images) < limit; {
+		loc := tweetPhotoRe.FindStringSubmatchIndex(html[offset:])
+		if loc == nil {
+			break
 		}
+		src := html[offset+loc[2] : offset+loc[3]]
+		offset += loc[1]
+
+		key := imageKey(src)
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		images = append(images, src)
 	}
 
 	return images
 }
+
+// imageKey identifies a photo regardless of the rendition requested:
+// the URL without query, fragment or file extension, so
+// media/ABC.jpg, media/ABC?format=jpg&name=small and
+// media/ABC?format=png&name=large share one key.
+func imageKey(src string) string {
+	if i := strings.IndexAny(src, "?#"); i >= 0 {
+		src = src[:i]
+	}
+	slash := strings.LastIndexByte(src, '/')
+	if dot := strings.LastIndexByte(src, '.'); dot > slash {
+		src = src[:dot]
+	}
+	return src
+}
@@ -0,0 +1,73 @@
+package scraper
+
+import (
+	"regexp"
+	"testing"
+
+	"sumariza-ai/test/fixtures"
+)
+
+// largeTweetText is the primary tweetText fragment of the large page fixture.
+func largeTweetText(b *testing.B) string {
+	b.Helper()
+	html := fixtures.GenerateLargeTweetPage()
+	matches := regexp.MustCompile(`data-testid="tweetText"[^>]*>([\s\S]*?)</div>\s*<div data-testid="quoteTweet"`).FindStringSubmatch(html)
+	if len(matches) < 2 {
+		b.Fatal("large fixture has no tweetText")
+	}
+	return matches[1]
+}
+
+func BenchmarkParseHTML(b *testing.B) {
+	html := fixtures.GenerateLargeTweetPage()
+	s := &TwitterScraper{selectors: DefaultSelectors()}
+	b.SetBytes(int64(len(html)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		s.parseHTML(html, "1001")
+	}
+}
+
+func BenchmarkExtractTweetText(b *testing.B) {
+	html := withoutQuoteTweet(primaryTweetScope(fixtures.GenerateLargeTweetPage()))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		extractTweetText(html, textOptions{})
+	}
+}
+
+func BenchmarkPreserveLinks(b *testing.B) {
+	content := largeTweetText(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		preserveLinks(content, textOptions{})
+	}
+}
+
+func BenchmarkCleanTextPreserveNewlines(b *testing.B) {
+	text := stripHTMLKeepLinks(preserveLinks(largeTweetText(b), textOptions{}), EmojiKeep)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cleanTextPreserveNewlines(text)
+	}
+}
+
+// BenchmarkCleanTextPreserveNewlinesRegex measures the regex reference
+// implementation, for comparison with BenchmarkCleanTextPreserveNewlines.
+func BenchmarkCleanTextPreserveNewlinesRegex(b *testing.B) {
+	text := stripHTMLKeepLinks(preserveLinks(largeTweetText(b), textOptions{}), EmojiKeep)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cleanTextPreserveNewlinesRegex(text)
+	}
+}
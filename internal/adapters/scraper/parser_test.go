@@ -1,7 +1,15 @@
 package scraper
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	"sumariza-ai/internal/domain"
 	"sumariza-ai/test/fixtures"
@@ -31,6 +39,231 @@ func TestParseHTML_BasicTweet_ExtractsAllFields(t *testing.T) {
 	_ = partial
 }
 
+func TestParseHTML_OGURL_SetsResolvedURL(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateTweetWithCanonicalURL()
+	s := &TwitterScraper{selectors: DefaultSelectors()}
+
+	// Act
+	tweet, _ := s.parseHTML(html, "555")
+
+	// Assert
+	if tweet.ResolvedURL != "https://x.com/realhandle/status/555" {
+		t.Errorf("ResolvedURL: got %q, want https://x.com/realhandle/status/555", tweet.ResolvedURL)
+	}
+}
+
+func TestParseHTML_NoOGURL_ResolvesFromStatusAnchor(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateBasicTweet()
+	s := &TwitterScraper{selectors: DefaultSelectors()}
+
+	// Act
+	tweet, _ := s.parseHTML(html, "123")
+
+	// Assert
+	if tweet.ResolvedURL != "https://x.com/johndoe/status/123" {
+		t.Errorf("ResolvedURL: got %q, want https://x.com/johndoe/status/123", tweet.ResolvedURL)
+	}
+}
+
+func TestParseHTML_OGURLForOtherTweet_Ignored(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateTweetWithCanonicalURL()
+	s := &TwitterScraper{selectors: DefaultSelectors()}
+
+	// Act
+	tweet, _ := s.parseHTML(html, "999")
+
+	// Assert
+	if tweet.ResolvedURL != "" {
+		t.Errorf("ResolvedURL: got %q, want empty", tweet.ResolvedURL)
+	}
+}
+
+func TestParseHTML_NoTweetText_FallsBackToOGDescription(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateMetaOnlyTweet()
+	s := &TwitterScraper{selectors: DefaultSelectors()}
+
+	// Act
+	tweet, partial := s.parseHTML(html, "789")
+
+	// Assert
+	if tweet.Content.Text != "Recovered from the meta tags & still readable." {
+		t.Errorf("Text: got %q", tweet.Content.Text)
+	}
+	if !partial {
+		t.Error("expected partial to be true for text read from meta tags")
+	}
+	if !slices.Contains(tweet.PartialReasons, domain.PartialReasonText) {
+		t.Errorf("PartialReasons: got %v, want %q included", tweet.PartialReasons, domain.PartialReasonText)
+	}
+}
+
+func TestParseHTML_TweetText_IgnoresOGDescription(t *testing.T) {
+	// Arrange
+	html := `<head><meta property="og:description" content="“from meta”"/></head>` + fixtures.GenerateBasicTweet()
+	s := &TwitterScraper{selectors: DefaultSelectors()}
+
+	// Act
+	tweet, _ := s.parseHTML(html, "123")
+
+	// Assert
+	if tweet.Content.Text != "This is a test tweet content." {
+		t.Errorf("Text: got %q, want the DOM text", tweet.Content.Text)
+	}
+	if slices.Contains(tweet.PartialReasons, domain.PartialReasonText) {
+		t.Errorf("PartialReasons: got %v, want no %q", tweet.PartialReasons, domain.PartialReasonText)
+	}
+}
+
+func TestParseHTML_ShowMoreLink_MarksTruncated(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateTruncatedLongTweet()
+	s := &TwitterScraper{selectors: DefaultSelectors()}
+
+	// Act
+	tweet, _ := s.parseHTML(html, "900")
+
+	// Assert
+	if !tweet.Content.Truncated {
+		t.Error("expected Truncated to be true when Show more is present")
+	}
+	if tweet.Content.Text != "This long post starts here and keeps going well past the limit" {
+		t.Errorf("Text: got %q", tweet.Content.Text)
+	}
+}
+
+func TestParseHTML_ShowMoreInQuote_NotTruncated(t *testing.T) {
+	// Arrange
+	html := `<article data-testid="tweet"><div data-testid="tweetText">Outer text</div>` +
+		`<div data-testid="quoteTweet"><div data-testid="tweetText">Quoted prefix</div>` +
+		`<button data-testid="tweet-text-show-more-link">Show more</button></div></article>`
+	s := &TwitterScraper{selectors: DefaultSelectors()}
+
+	// Act
+	tweet, _ := s.parseHTML(html, "1")
+
+	// Assert
+	if tweet.Content.Truncated {
+		t.Error("a quote's Show more should not mark the outer tweet truncated")
+	}
+}
+
+func TestParseHTML_Replies_CapturedApartFromMainTweet(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateTweetWithReplies()
+	s := &TwitterScraper{selectors: DefaultSelectors()}
+
+	// Act
+	tweet, _ := s.parseHTML(html, "600")
+
+	// Assert
+	if tweet.Content.Text != "What should I build next?" {
+		t.Errorf("Text: got %q", tweet.Content.Text)
+	}
+	want := []domain.ReplyItem{
+		{ID: "602", Author: domain.Author{Name: "Alice", Handle: "alice"}, Text: "A tweet summarizer!"},
+		{ID: "603", Author: domain.Author{Name: "Bob", Handle: "bob"}, Text: "Another todo app."},
+	}
+	if len(tweet.Content.Replies) != len(want) {
+		t.Fatalf("Replies: got %+v, want %+v", tweet.Content.Replies, want)
+	}
+	for i, reply := range tweet.Content.Replies {
+		if reply.ID != want[i].ID || reply.Author.Handle != want[i].Author.Handle ||
+			reply.Author.Name != want[i].Author.Name || reply.Text != want[i].Text {
+			t.Errorf("reply %d: got %+v, want %+v", i, reply, want[i])
+		}
+	}
+}
+
+func TestParseHTML_Reply_ExtractsParent(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateReplyTweet()
+	s := &TwitterScraper{selectors: DefaultSelectors()}
+
+	// Act
+	tweet, _ := s.parseHTML(html, "700")
+
+	// Assert
+	if tweet.Content.InReplyToID != "650" {
+		t.Errorf("InReplyToID: got %q, want 650", tweet.Content.InReplyToID)
+	}
+	if tweet.Content.InReplyToURL != "https://x.com/janeroe/status/650" {
+		t.Errorf("InReplyToURL: got %q", tweet.Content.InReplyToURL)
+	}
+}
+
+func TestParseHTML_NotAReply_NoParent(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		html    string
+		tweetID string
+	}{
+		{name: "no reply context", html: fixtures.GenerateTweetWithReplies(), tweetID: "1"},
+		{name: "quoted status", html: fixtures.GenerateQuoteTweetDifferentAuthors(), tweetID: "300"},
+		{
+			name: "reply context without parent link",
+			html: `<article data-testid="tweet"><div><span>Replying to </span><a href="/janeroe">@janeroe</a></div>` +
+				`<div data-testid="tweetText">Agreed</div><a href="/johndoe/status/700"><time datetime="2026-01-01T12:00:00Z"></time></a></article>`,
+			tweetID: "700",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			s := &TwitterScraper{selectors: DefaultSelectors()}
+
+			// Act
+			tweet, _ := s.parseHTML(tc.html, tc.tweetID)
+
+			// Assert
+			if tweet.Content.InReplyToID != "" || tweet.Content.InReplyToURL != "" {
+				t.Errorf("got parent %q %q, want none", tweet.Content.InReplyToID, tweet.Content.InReplyToURL)
+			}
+		})
+	}
+}
+
+func TestParseHTML_Replies_Capped(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateLargeTweetPage()
+	s := &TwitterScraper{selectors: DefaultSelectors()}
+
+	// Act
+	tweet, _ := s.parseHTML(html, "1001")
+
+	// Assert
+	if len(tweet.Content.Replies) != maxReplies {
+		t.Errorf("Replies: got %d, want %d", len(tweet.Content.Replies), maxReplies)
+	}
+}
+
+func TestParseTweetHTML_NilSelectors_ParsesFixture(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateQuoteTweetDifferentAuthors()
+
+	// Act
+	tweet, partial := ParseTweetHTML(html, "300", nil)
+
+	// Assert
+	if tweet.ID != "300" {
+		t.Errorf("ID: got %v, want 300", tweet.ID)
+	}
+	if tweet.Content.Text != "My take on this" {
+		t.Errorf("Text: got %q, want 'My take on this'", tweet.Content.Text)
+	}
+	if tweet.Author.Handle != "mainauthor" {
+		t.Errorf("Author.Handle: got %q, want 'mainauthor'", tweet.Author.Handle)
+	}
+	if tweet.Content.QuotedTweet == nil {
+		t.Error("expected quoted tweet to be extracted")
+	}
+	if !partial {
+		t.Error("expected partial for a main tweet without avatar")
+	}
+}
+
 func TestParseHTML_PartialTweet_MarksAsPartial(t *testing.T) {
 	// Arrange
 	html := fixtures.GeneratePartialTweet()
@@ -96,12 +329,174 @@ func TestParseHTML_QuoteTweet_ExtractsQuote(t *testing.T) {
 	}
 }
 
+func TestParseHTML_QuoteTweetWithMedia_ExtractsScopedQuote(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateQuoteTweetWithMedia()
+	s := &TwitterScraper{selectors: &SelectorConfig{}}
+
+	// Act
+	tweet, _ := s.parseHTML(html, "200")
+
+	// Assert
+	quoted := tweet.Content.QuotedTweet
+	if quoted == nil {
+		t.Fatal("expected quoted tweet to be extracted")
+	}
+	if quoted.Text != "Look at this photo" {
+		t.Errorf("Text: got %q, want 'Look at this photo'", quoted.Text)
+	}
+	if quoted.Author.Name != "Quoted Author" {
+		t.Errorf("Author.Name: got %q, want 'Quoted Author'", quoted.Author.Name)
+	}
+	if quoted.Author.Handle != "quotedauthor" {
+		t.Errorf("Author.Handle: got %q, want 'quotedauthor'", quoted.Author.Handle)
+	}
+	if quoted.Author.AvatarURL != "https://pbs.twimg.com/profile_images/quoted.jpg" {
+		t.Errorf("Author.AvatarURL: got %q", quoted.Author.AvatarURL)
+	}
+	if !quoted.Author.Verified {
+		t.Error("expected quoted author to be verified")
+	}
+	if !quoted.HasMedia {
+		t.Error("expected quoted tweet to have media")
+	}
+}
+
+func TestExtractQuotedTweet_OuterFields_DoNotBleedIn(t *testing.T) {
+	// Arrange: the outer tweet has a verified author, an avatar and a photo,
+	// the quote has none of them
+	html := `<article data-testid="tweet">
+<div data-testid="Tweet-User-Avatar"><div><img src="https://pbs.twimg.com/profile_images/outer.jpg"/></div></div>
+<div data-testid="User-Name"><div><div><span>Outer</span><svg data-testid="icon-verified"></svg><span>@outer</span></div></div></div>
+<div data-testid="tweetText" dir="ltr">Outer text</div>
+<div data-testid="tweetPhoto"><img src="https://pbs.twimg.com/media/outer.jpg"/></div>
+<div data-testid="quoteTweet"><div><div data-testid="tweetText" dir="ltr">Plain quote</div></div></div>
+</article>`
+
+	// Act
+	quoted := extractQuotedTweet(html, textOptions{})
+
+	// Assert
+	if quoted == nil {
+		t.Fatal("expected quoted tweet to be extracted")
+	}
+	if quoted.Text != "Plain quote" {
+		t.Errorf("Text: got %q, want 'Plain quote'", quoted.Text)
+	}
+	if quoted.Author != (domain.Author{}) {
+		t.Errorf("expected empty quoted author, got %+v", quoted.Author)
+	}
+	if quoted.HasMedia {
+		t.Error("expected quoted tweet without media")
+	}
+}
+
+func TestParseHTML_UnavailableQuote_FlagsQuote(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateQuoteTweetUnavailable()
+	s := &TwitterScraper{selectors: &SelectorConfig{}}
+
+	// Act
+	tweet, _ := s.parseHTML(html, "300")
+
+	// Assert
+	if tweet.Content.Text != "Remember this?" {
+		t.Errorf("Text: got %q, want 'Remember this?'", tweet.Content.Text)
+	}
+	quoted := tweet.Content.QuotedTweet
+	if quoted == nil {
+		t.Fatal("expected the unavailable quote to be kept")
+	}
+	if !quoted.Unavailable {
+		t.Error("expected Unavailable to be true")
+	}
+	if quoted.Text != "" {
+		t.Errorf("Text: got %q, want empty", quoted.Text)
+	}
+}
+
+func TestParseHTML_DifferentAuthors_MainAuthorIsOuter(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateQuoteTweetDifferentAuthors()
+	s := &TwitterScraper{selectors: &SelectorConfig{}}
+
+	// Act
+	tweet, _ := s.parseHTML(html, "300")
+
+	// Assert
+	if tweet.Author.Name != "Main Author" {
+		t.Errorf("Author.Name: got %q, want 'Main Author'", tweet.Author.Name)
+	}
+	if tweet.Author.Handle != "mainauthor" {
+		t.Errorf("Author.Handle: got %q, want 'mainauthor'", tweet.Author.Handle)
+	}
+	if tweet.Author.AvatarURL != "" {
+		t.Errorf("Author.AvatarURL: got %q, want the quoted avatar not to leak", tweet.Author.AvatarURL)
+	}
+	if tweet.Author.Verified {
+		t.Error("expected the quoted author's badge not to leak into the main author")
+	}
+	if tweet.Content.Text != "My take on this" {
+		t.Errorf("Text: got %q, want 'My take on this'", tweet.Content.Text)
+	}
+	if tweet.Content.Direction != domain.LTR {
+		t.Errorf("Direction: got %v, want LTR", tweet.Content.Direction)
+	}
+	if got := tweet.Content.CreatedAt.Format(time.RFC3339); got != "2026-01-01T16:00:00Z" {
+		t.Errorf("CreatedAt: got %s, want the outer timestamp", got)
+	}
+	if quoted := tweet.Content.QuotedTweet; quoted == nil || quoted.Author.Handle != "quotedauthor" {
+		t.Errorf("expected quoted tweet by quotedauthor, got %+v", quoted)
+	}
+}
+
+func TestPrimaryTweetScope_SkipsArticlesInsideQuote(t *testing.T) {
+	// Arrange: the only article is nested in a quote, so the page is used
+	html := `<div data-testid="quoteTweet"><article data-testid="tweet">quoted</article></div>`
+
+	// Act
+	scope := primaryTweetScope(html)
+
+	// Assert
+	if scope != html {
+		t.Errorf("got %q, want the whole page", scope)
+	}
+}
+
+func TestEnclosingElement_BalancesNestedTags(t *testing.T) {
+	// Arrange
+	html := `<div id="a"><div id="b"><span>x</span></div><div>y</div></div><div>after</div>`
+
+	// Act
+	element := enclosingElement(html, strings.Index(html, `id="a"`))
+
+	// Assert
+	want := `<div id="a"><div id="b"><span>x</span></div><div>y</div></div>`
+	if element != want {
+		t.Errorf("got %q, want %q", element, want)
+	}
+}
+
+func TestEnclosingElement_Unclosed_RunsToEnd(t *testing.T) {
+	// Arrange
+	html := `<p>before</p><div id="a"><div>x</div>`
+
+	// Act
+	element := enclosingElement(html, strings.Index(html, `id="a"`))
+
+	// Assert
+	want := `<div id="a"><div>x</div>`
+	if element != want {
+		t.Errorf("got %q, want %q", element, want)
+	}
+}
+
 func TestExtractTweetText_BasicHTML_ReturnsText(t *testing.T) {
 	// Arrange
 	html := `<div data-testid="tweetText" dir="ltr">Hello World</div>`
 
 	// Act
-	text := extractTweetText(html)
+	text := extractTweetText(html, textOptions{})
 
 	// Assert
 	if text != "Hello World" {
@@ -273,12 +668,69 @@ func TestCleanTextPreserveNewlines_TrimsLineSpaces(t *testing.T) {
 	}
 }
 
+// cleanTextPreserveNewlinesRegex is the original regex-based cleaner, kept as
+// the reference the single-pass implementation must match.
+func cleanTextPreserveNewlinesRegex(text string) string {
+	text = regexp.MustCompile(`[^\S\n]+`).ReplaceAllString(text, " ")
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	text = strings.Join(lines, "\n")
+	text = regexp.MustCompile(`\n{3,}`).ReplaceAllString(text, "\n\n")
+	return strings.TrimSpace(text)
+}
+
+func TestCleanTextPreserveNewlines_MatchesRegexReference(t *testing.T) {
+	inputs := []string{
+		"",
+		"   ",
+		"\n\n\n",
+		"plain",
+		"a \t\f\r b",
+		"a\r\nb\r\n",
+		"\n\n  lead\n\n\n\ntrail  \n\n",
+		"Para 1\n \n\t\n\u00a0\nPara 2",
+		"\u00a0 \t x \u00a0y\u2028",
+		"a\vb \v c",
+		"x\n\ny\n\n\nz",
+		"emoji 🚀   done\n\n\n\n👩‍💻",
+		"\xff\xfe  \xc3\n\n\n\xc3",
+	}
+
+	for _, in := range inputs {
+		// Act
+		got := cleanTextPreserveNewlines(in)
+		want := cleanTextPreserveNewlinesRegex(in)
+
+		// Assert
+		if got != want {
+			t.Errorf("cleanTextPreserveNewlines(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCleanTextPreserveNewlines_AllocatesOnce(t *testing.T) {
+	// Arrange
+	text := "  Line 1 \t here  \n\n\n\n  Line 2\r\n\u00a0\nLine 3  "
+
+	// Act
+	allocs := testing.AllocsPerRun(100, func() {
+		cleanTextPreserveNewlines(text)
+	})
+
+	// Assert
+	if allocs > 1 {
+		t.Errorf("allocs per call = %v, want at most 1", allocs)
+	}
+}
+
 func TestStripHTMLKeepLinks_ConvertsBrToNewline(t *testing.T) {
 	// Arrange
 	html := "Line 1<br>Line 2<br/>Line 3"
 
 	// Act
-	text := stripHTMLKeepLinks(html)
+	text := stripHTMLKeepLinks(html, EmojiKeep)
 
 	// Assert - should have newlines where <br> was
 	expected := "Line 1\nLine 2\nLine 3"
@@ -292,7 +744,7 @@ func TestStripHTMLKeepLinks_ConvertsClosingDivToNewline(t *testing.T) {
 	html := "<div>Line 1</div><div>Line 2</div>"
 
 	// Act
-	text := stripHTMLKeepLinks(html)
+	text := stripHTMLKeepLinks(html, EmojiKeep)
 
 	// Assert - should have newlines where </div> was
 	expected := "Line 1\nLine 2\n"
@@ -306,10 +758,449 @@ func TestExtractTweetText_WithLineBreaks_PreservesFormatting(t *testing.T) {
 	html := `<div data-testid="tweetText"><span>First line</span><br><span>Second line</span></div>`
 
 	// Act
-	text := extractTweetText(html)
+	text := extractTweetText(html, textOptions{})
 
 	// Assert - should preserve line break
 	if text != "First line\nSecond line" {
 		t.Errorf("got %q, want 'First line\\nSecond line'", text)
 	}
 }
+
+func TestCleanTweetHTML_WhitespaceOnlyLines_IsIdempotent(t *testing.T) {
+	// Arrange - blank lines made of spaces must not survive a second pass
+	html := "Para 1\n \n \n \nPara 2"
+
+	// Act
+	once := cleanTweetHTML(html, textOptions{})
+	twice := cleanTweetHTML(once, textOptions{})
+
+	// Assert
+	if once != "Para 1\n\nPara 2" {
+		t.Errorf("got %q, want 'Para 1\\n\\nPara 2'", once)
+	}
+	if twice != once {
+		t.Errorf("second pass changed output: %q -> %q", once, twice)
+	}
+}
+
+func TestPreserveLinks_NestedAnchors_NoLeftoverTags(t *testing.T) {
+	// Arrange - malformed nested anchors
+	html := `<div data-testid="tweetText"><a href="https://a.com"><a href="https://b.com">b</a></a> end</div>`
+
+	// Act
+	text := extractTweetText(html, textOptions{})
+
+	// Assert
+	if text != "[[LINK:https://a.com]] end" {
+		t.Errorf("got %q, want '[[LINK:https://a.com]] end'", text)
+	}
+}
+
+func TestExtractTweetText_ManyLinks_CapsResolvedLinks(t *testing.T) {
+	// Arrange
+	var b strings.Builder
+	b.WriteString(`<div data-testid="tweetText">`)
+	for i := 0; i < 5000; i++ {
+		fmt.Fprintf(&b, `<a href="https://example.com/%d">link%d</a> `, i, i)
+	}
+	b.WriteString(`</div>`)
+
+	// Act
+	start := time.Now()
+	text := extractTweetText(b.String(), textOptions{})
+	elapsed := time.Since(start)
+
+	// Assert
+	if got := strings.Count(text, "[[LINK:"); got != defaultMaxLinks {
+		t.Errorf("resolved links: got %d, want %d", got, defaultMaxLinks)
+	}
+	if !strings.HasSuffix(text, "link4999") {
+		t.Errorf("links past the cap should keep their visible text, got suffix %q", text[len(text)-20:])
+	}
+	if elapsed > 2*time.Second {
+		t.Errorf("parsing took %v", elapsed)
+	}
+}
+
+func TestExtractTweetText_MaxLinks_Configurable(t *testing.T) {
+	// Arrange
+	html := `<div data-testid="tweetText"><a href="https://a.com">a</a> <a href="https://b.com">b</a> <a href="https://c.com">c</a></div>`
+
+	// Act
+	text := extractTweetText(html, textOptions{maxLinks: 2})
+
+	// Assert
+	want := "[[LINK:https://a.com]] [[LINK:https://b.com]] c"
+	if text != want {
+		t.Errorf("got %q, want %q", text, want)
+	}
+}
+
+func TestExtractImages_ManyPhotos_StopsAtLimit(t *testing.T) {
+	// Arrange
+	var b strings.Builder
+	for i := 0; i < 5000; i++ {
+		fmt.Fprintf(&b, `<div data-testid="tweetPhoto"><img src="https://pbs.twimg.com/media/%d.jpg"/></div>`, i)
+	}
+	html := b.String()
+
+	// Act
+	defaults := extractImages(html, 0)
+	limited := extractImages(html, 3)
+
+	// Assert
+	if len(defaults) != defaultMaxImages {
+		t.Errorf("default cap: got %d images, want %d", len(defaults), defaultMaxImages)
+	}
+	if len(limited) != 3 || limited[2] != "https://pbs.twimg.com/media/2.jpg" {
+		t.Errorf("limit 3: got %v", limited)
+	}
+}
+
+func TestExtractImages_DuplicateRenditions_OnePerMediaKey(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateTweetWithDuplicateImages()
+
+	// Act
+	images := extractImages(html, 0)
+
+	// Assert
+	want := []string{
+		"https://pbs.twimg.com/media/AAA111?format=jpg&amp;name=small",
+		"https://pbs.twimg.com/media/BBB222?format=jpg&amp;name=small",
+	}
+	if !slices.Equal(images, want) {
+		t.Errorf("got %v, want %v", images, want)
+	}
+}
+
+func TestExtractImages_Duplicates_DoNotCountTowardsLimit(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateTweetWithDuplicateImages()
+
+	// Act
+	images := extractImages(html, 2)
+
+	// Assert
+	if len(images) != 2 {
+		t.Errorf("got %d images, want 2 distinct: %v", len(images), images)
+	}
+}
+
+func TestExtractTweetText_LinkAtEndOfSentence_NoSpaceBeforePeriod(t *testing.T) {
+	// Arrange
+	html := `<div data-testid="tweetText">Read the docs at <a href="https://t.co/abc">go.dev/doc</a>.</div>`
+
+	// Act
+	text := extractTweetText(html, textOptions{})
+
+	// Assert
+	want := "Read the docs at [[LINK:https://t.co/abc]]."
+	if text != want {
+		t.Errorf("got %q, want %q", text, want)
+	}
+}
+
+func TestExtractTweetText_LinkMidSentence_SingleSpaces(t *testing.T) {
+	// Arrange
+	html := `<div data-testid="tweetText">See <a href="https://t.co/abc">go.dev</a> and <a href="/hashtag/golang">#golang</a>, then (<a href="https://t.co/def">blog</a>) too</div>`
+
+	// Act
+	text := extractTweetText(html, textOptions{})
+
+	// Assert
+	want := "See [[LINK:https://t.co/abc]] and #golang, then ([[LINK:https://t.co/def]]) too"
+	if text != want {
+		t.Errorf("got %q, want %q", text, want)
+	}
+}
+
+func TestExtractTweetText_AdjacentLinks_SeparatedBySpace(t *testing.T) {
+	// Arrange
+	html := `<div data-testid="tweetText">x<a href="https://a.com">a</a><a href="https://b.com">b</a>y</div>`
+
+	// Act
+	text := extractTweetText(html, textOptions{})
+
+	// Assert
+	want := "x [[LINK:https://a.com]] [[LINK:https://b.com]] y"
+	if text != want {
+		t.Errorf("got %q, want %q", text, want)
+	}
+}
+
+// Fuzz tests
+
+func FuzzExtractTweetText(f *testing.F) {
+	f.Add(`<div data-testid="tweetText" dir="ltr">Hello World</div>`)
+	f.Add(`<div data-testid="tweetText"><span>First line</span><br><span>Second line</span></div>`)
+	f.Add(`<div data-testid="tweetText"><a href="https://t.co/x">t.co/x</a> <a href="/hashtag/go">#go</a></div>`)
+	f.Add(`<div data-testid="tweetText"><img alt="😀" src="e.png"><a href="x"<a href="y">z</a></a></div>`)
+	f.Add(`<div data-testid="tweetText">a < b > c<br/ \n \n \n</div>`)
+	f.Add("<div data-testid=\"tweetText\">\xff\xfe<span>\xc3</span></div>")
+
+	f.Fuzz(func(t *testing.T, html string) {
+		text := extractTweetText(html, textOptions{})
+
+		if !utf8.ValidString(text) {
+			t.Errorf("extractTweetText returned invalid UTF-8: %q", text)
+		}
+	})
+}
+
+func FuzzCleanTweetHTML_Idempotent(f *testing.F) {
+	f.Add("Hello World")
+	f.Add("<span>First line</span><br><span>Second line</span>")
+	f.Add(`<a href="https://t.co/x">t.co/x</a> <a href="/hashtag/go">#go</a>`)
+	f.Add(`(<a href="https://t.co/x">x</a>). <a href="/a">@a</a>,<a href="https://b">b</a>` + "\x1f")
+	f.Add(`<a href="x<y">z</a> <img alt="<b>" src="e.png"> a < b`)
+	f.Add("Para 1\n \n\t\n\u00a0\nPara 2")
+	f.Add("\xff\xfe<span>\xc3</span>")
+
+	f.Fuzz(func(t *testing.T, html string) {
+		once := cleanTweetHTML(html, textOptions{})
+		twice := cleanTweetHTML(once, textOptions{})
+
+		if !utf8.ValidString(once) {
+			t.Errorf("cleanTweetHTML returned invalid UTF-8: %q", once)
+		}
+		if once != twice {
+			t.Errorf("cleanTweetHTML is not idempotent: %q -> %q -> %q", html, once, twice)
+		}
+	})
+}
+
+func FuzzCleanTextPreserveNewlines_MatchesRegexReference(f *testing.F) {
+	f.Add("Para 1\n \n\t\n\u00a0\nPara 2")
+	f.Add("  a \t\f\r b \n\n\n\n c\v")
+	f.Add("\xff\n\n\n\xc3 \u2028")
+
+	f.Fuzz(func(t *testing.T, text string) {
+		got := cleanTextPreserveNewlines(text)
+		want := cleanTextPreserveNewlinesRegex(text)
+
+		if got != want {
+			t.Errorf("cleanTextPreserveNewlines(%q) = %q, want %q", text, got, want)
+		}
+	})
+}
+
+func TestParseHTML_TweetWithMetrics_ExtractsViewsAndBookmarks(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateTweetWithMetrics()
+	s := &TwitterScraper{selectors: &SelectorConfig{}}
+
+	// Act
+	tweet, _ := s.parseHTML(html, "321")
+
+	// Assert - full numbers from aria-labels, not the visible "12.3M"/"1K"
+	if tweet.Metrics.Views != 12345678 {
+		t.Errorf("Views: got %d, want 12345678", tweet.Metrics.Views)
+	}
+	if tweet.Metrics.Bookmarks != 1024 {
+		t.Errorf("Bookmarks: got %d, want 1024", tweet.Metrics.Bookmarks)
+	}
+}
+
+func TestParseHTML_NoMetrics_LeavesZero(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateBasicTweet()
+	s := &TwitterScraper{selectors: &SelectorConfig{}}
+
+	// Act
+	tweet, _ := s.parseHTML(html, "123")
+
+	// Assert
+	if tweet.Metrics.Views != 0 || tweet.Metrics.Bookmarks != 0 {
+		t.Errorf("Metrics: got %+v, want zero", tweet.Metrics)
+	}
+}
+
+func TestParseHTML_MetricsOnlyOnReply_NotReportedForMainTweet(t *testing.T) {
+	// Arrange - the main tweet shows views but no bookmarks, its reply both
+	html := `<article data-testid="tweet">
+<div data-testid="User-Name"><div><div><span>John Doe</span><span>@johndoe</span></div></div></div>
+<div data-testid="tweetText" dir="ltr">Main tweet</div>
+<a href="/johndoe/status/1/analytics" aria-label="500 views. View post analytics"></a>
+</article>
+<article data-testid="tweet">
+<div data-testid="User-Name"><div><div><span>Alice</span><span>@alice</span></div></div></div>
+<div data-testid="tweetText" dir="ltr">A reply</div>
+<div role="group" aria-label="3 likes, 77 bookmarks, 9,999 views"></div>
+</article>`
+	s := &TwitterScraper{selectors: &SelectorConfig{}}
+
+	// Act
+	tweet, _ := s.parseHTML(html, "1")
+
+	// Assert
+	if tweet.Metrics.Views != 500 {
+		t.Errorf("Views: got %d, want the main tweet's 500", tweet.Metrics.Views)
+	}
+	if tweet.Metrics.Bookmarks != 0 {
+		t.Errorf("Bookmarks: got %d, want 0 (the count is the reply's)", tweet.Metrics.Bookmarks)
+	}
+}
+
+func TestExtractMetrics_LabelFormats(t *testing.T) {
+	tests := []struct {
+		name          string
+		html          string
+		wantViews     int64
+		wantBookmarks int64
+	}{
+		{"plain", `<a aria-label="987 views. View post analytics"></a>`, 987, 0},
+		{"comma grouping", `<div aria-label="1,234 bookmarks, 5,678 views"></div>`, 5678, 1234},
+		{"abbreviated K", `<a aria-label="1.2K Views"></a>`, 1200, 0},
+		{"abbreviated M", `<a aria-label="3.4M views"></a>`, 3400000, 0},
+		{"singular", `<button aria-label="1 Bookmark. Bookmark"></button><a aria-label="1 View"></a>`, 1, 1},
+		{"no counts", `<button aria-label="Bookmark"></button>`, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := extractMetrics(tt.html)
+
+			if metrics.Views != tt.wantViews {
+				t.Errorf("Views: got %d, want %d", metrics.Views, tt.wantViews)
+			}
+			if metrics.Bookmarks != tt.wantBookmarks {
+				t.Errorf("Bookmarks: got %d, want %d", metrics.Bookmarks, tt.wantBookmarks)
+			}
+		})
+	}
+}
+
+func TestParseHTML_MissingAvatarAndHandle_ListsReasons(t *testing.T) {
+	// Arrange - name only, no handle link and no avatar
+	html := `<article data-testid="tweet">
+		<div data-testid="User-Name"><span>John Doe</span></div></div></div>
+		<div data-testid="tweetText" dir="ltr">Hello</div>
+	</article>`
+	s := &TwitterScraper{selectors: &SelectorConfig{}}
+
+	// Act
+	tweet, partial := s.parseHTML(html, "123")
+
+	// Assert
+	if !partial {
+		t.Error("expected partial to be true")
+	}
+	want := []string{domain.PartialReasonAuthorHandle, domain.PartialReasonAvatar}
+	if len(tweet.PartialReasons) != len(want) {
+		t.Fatalf("PartialReasons: got %v, want %v", tweet.PartialReasons, want)
+	}
+	for i := range want {
+		if tweet.PartialReasons[i] != want[i] {
+			t.Errorf("PartialReasons[%d]: got %q, want %q", i, tweet.PartialReasons[i], want[i])
+		}
+	}
+}
+
+func TestParseHTML_NoAuthor_ListsAllReasons(t *testing.T) {
+	// Arrange
+	html := fixtures.GeneratePartialTweet()
+	s := &TwitterScraper{selectors: &SelectorConfig{}}
+
+	// Act
+	tweet, _ := s.parseHTML(html, "456")
+
+	// Assert
+	want := []string{domain.PartialReasonAuthorName, domain.PartialReasonAuthorHandle, domain.PartialReasonAvatar}
+	if len(tweet.PartialReasons) != len(want) {
+		t.Fatalf("PartialReasons: got %v, want %v", tweet.PartialReasons, want)
+	}
+	for i := range want {
+		if tweet.PartialReasons[i] != want[i] {
+			t.Errorf("PartialReasons[%d]: got %q, want %q", i, tweet.PartialReasons[i], want[i])
+		}
+	}
+}
+
+func TestParseHTMLContext_CanceledContext_ReturnsEarly(t *testing.T) {
+	// Arrange
+	html := strings.Repeat(fixtures.GenerateBasicTweet(), 2000)
+	s := &TwitterScraper{selectors: DefaultSelectors()}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	tweet, partial, err := s.parseHTMLContext(ctx, html, "123")
+
+	// Assert
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if tweet != nil || partial {
+		t.Errorf("got tweet %+v, partial %v; want nil, false", tweet, partial)
+	}
+}
+
+func TestResultFromHTML_CanceledContext_ReturnsError(t *testing.T) {
+	// Arrange
+	html := strings.Repeat(fixtures.GenerateBasicTweet(), 2000)
+	s := &TwitterScraper{selectors: DefaultSelectors()}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	result := s.resultFromHTML(ctx, html, "123")
+
+	// Assert
+	if result.Status != domain.ScrapeError {
+		t.Errorf("Status = %q, want %q", result.Status, domain.ScrapeError)
+	}
+	if !errors.Is(result.Err, context.Canceled) {
+		t.Errorf("Err = %v, want context.Canceled", result.Err)
+	}
+	if result.Tweet != nil {
+		t.Errorf("Tweet = %+v, want nil", result.Tweet)
+	}
+}
+
+func TestParseHTMLContext_LiveContext_MatchesParseHTML(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateBasicTweet()
+	s := &TwitterScraper{selectors: DefaultSelectors()}
+
+	// Act
+	want, _ := s.parseHTML(html, "123")
+	got, _, err := s.parseHTMLContext(context.Background(), html, "123")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ContentHash() != want.ContentHash() {
+		t.Errorf("parseHTMLContext result differs from parseHTML")
+	}
+}
+
+func TestParseHTML_LargePage_ExtractsPrimaryTweet(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateLargeTweetPage()
+	s := &TwitterScraper{selectors: DefaultSelectors()}
+
+	// Act
+	tweet, partial := s.parseHTML(html, "1001")
+
+	// Assert
+	if partial {
+		t.Errorf("partial = true, reasons %v", tweet.PartialReasons)
+	}
+	if tweet.Author.Handle != "janeroe" || !tweet.Author.Verified {
+		t.Errorf("Author = %+v, want verified @janeroe", tweet.Author)
+	}
+	if !strings.HasPrefix(tweet.Content.Text, "Shipping the new release today 🚀") {
+		t.Errorf("Text = %q, want primary tweet text", tweet.Content.Text)
+	}
+	if !strings.Contains(tweet.Content.Text, "[[LINK:https://t.co/AbCdEf123]]") {
+		t.Errorf("Text = %q, want preserved changelog link", tweet.Content.Text)
+	}
+	if tweet.Content.QuotedTweet == nil || tweet.Content.QuotedTweet.Author.Handle != "project" {
+		t.Errorf("QuotedTweet = %+v, want @project", tweet.Content.QuotedTweet)
+	}
+	if tweet.Metrics.Views != 1234567 {
+		t.Errorf("Views = %d, want 1234567", tweet.Metrics.Views)
+	}
+}
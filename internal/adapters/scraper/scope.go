@@ -0,0 +1,168 @@
+package scraper
+
+import (
+	"iter"
+	"strings"
+)
+
+// enclosingElement returns the element whose opening tag contains html[pos],
+// from its opening tag through the matching closing tag. Nested elements with
+// the same tag name are balanced; an unclosed element runs to the end of html.
+// Returns "" if pos is not inside a tag.
+func enclosingElement(html string, pos int) string {
+	start := strings.LastIndex(html[:pos], "<")
+	if start < 0 {
+		return ""
+	}
+
+	nameEnd := start + 1
+	for nameEnd < len(html) && isTagNameChar(html[nameEnd]) {
+		nameEnd++
+	}
+	name := html[start+1 : nameEnd]
+	if name == "" {
+		return ""
+	}
+
+	depth := 0
+	for i := start; i < len(html); {
+		next := strings.IndexByte(html[i:], '<')
+		if next < 0 {
+			break
+		}
+		i += next
+
+		switch {
+		case hasTagAt(html, i+1, name):
+			depth++
+		case strings.HasPrefix(html[i+1:], "/") && hasTagAt(html, i+2, name):
+			depth--
+			if depth == 0 {
+				end := strings.IndexByte(html[i:], '>')
+				if end < 0 {
+					return html[start:]
+				}
+				return html[start : i+end+1]
+			}
+		}
+		i++
+	}
+
+	return html[start:]
+}
+
+// hasTagAt reports whether the tag name at html[i:] is exactly name.
+func hasTagAt(html string, i int, name string) bool {
+	if !strings.HasPrefix(html[i:], name) {
+		return false
+	}
+	after := i + len(name)
+	return after == len(html) || !isTagNameChar(html[after])
+}
+
+// isTagNameChar reports whether c can appear in an HTML tag name.
+func isTagNameChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-'
+}
+
+// quoteTweetScope returns the quoted tweet's container element, or "" if the
+// page has no quoted tweet.
+func quoteTweetScope(html string) string {
+	idx := strings.Index(html, `data-testid="quoteTweet"`)
+	if idx < 0 {
+		return ""
+	}
+	return enclosingElement(html, idx)
+}
+
+// primaryTweetScope returns the main tweet's article: the first
+// data-testid="tweet" element that is not nested in a quoted tweet.
+// Falls back to the whole page when no article is found.
+func primaryTweetScope(html string) string {
+	const marker = `data-testid="tweet"`
+
+	for offset := 0; ; {
+		idx := strings.Index(html[offset:], marker)
+		if idx < 0 {
+			return html
+		}
+		idx += offset
+		offset = idx + len(marker)
+
+		if !insideQuoteTweet(html, idx) {
+			return enclosingElement(html, idx)
+		}
+	}
+}
+
+// followingTweetScopes yields the tweet articles after the primary one, in
+// page order, skipping articles nested in quoted tweets.
+func followingTweetScopes(html string) iter.Seq[string] {
+	primary := primaryTweetScope(html)
+	if primary == html {
+		return func(func(string) bool) {}
+	}
+	return tweetScopesFrom(html, strings.Index(html, primary)+len(primary))
+}
+
+// tweetScopes yields every tweet article on the page, in page order,
+// skipping articles nested in quoted tweets.
+func tweetScopes(html string) iter.Seq[string] {
+	return tweetScopesFrom(html, 0)
+}
+
+// tweetScopesFrom yields the top-level tweet articles starting at or after
+// html[offset].
+func tweetScopesFrom(html string, offset int) iter.Seq[string] {
+	const marker = `data-testid="tweet"`
+
+	return func(yield func(string) bool) {
+		for offset := offset; ; {
+			idx := strings.Index(html[offset:], marker)
+			if idx < 0 {
+				return
+			}
+			idx += offset
+			offset = idx + len(marker)
+
+			if insideQuoteTweet(html, idx) {
+				continue
+			}
+			scope := enclosingElement(html, idx)
+			offset = strings.LastIndex(html[:idx], "<") + len(scope)
+			if !yield(scope) {
+				return
+			}
+		}
+	}
+}
+
+// insideQuoteTweet reports whether html[pos] lies within a quoteTweet element.
+func insideQuoteTweet(html string, pos int) bool {
+	const marker = `data-testid="quoteTweet"`
+
+	for offset := 0; offset < pos; {
+		idx := strings.Index(html[offset:pos], marker)
+		if idx < 0 {
+			return false
+		}
+		idx += offset
+		offset = idx + len(marker)
+
+		start := strings.LastIndex(html[:idx], "<")
+		if start >= 0 && start+len(enclosingElement(html, idx)) > pos {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutQuoteTweet removes the quoted tweet's container from html, leaving
+// only the fields that belong to the outer tweet.
+func withoutQuoteTweet(html string) string {
+	quote := quoteTweetScope(html)
+	if quote == "" {
+		return html
+	}
+	return strings.Replace(html, quote, "", 1)
+}
@@ -1,13 +1,50 @@
 package scraper
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"sync"
 	"time"
 
+	"sumariza-ai/pkg/log"
+
 	"gopkg.in/yaml.v3"
 )
 
+const (
+	// selectorsReadTimeout bounds reading the selector file, which may live on
+	// a slow or network filesystem.
+	selectorsReadTimeout = 5 * time.Second
+
+	// maxSelectorsSize caps the selector file size.
+	maxSelectorsSize = 1 << 20
+)
+
+var (
+	// ErrSelectorsReadTimeout is returned when the selector file can't be read in time.
+	ErrSelectorsReadTimeout = errors.New("timed out reading selectors file")
+
+	// ErrSelectorsTooLarge is returned when the selector file exceeds maxSelectorsSize.
+	ErrSelectorsTooLarge = errors.New("selectors file too large")
+)
+
+// DefaultSelectorsPath is the selector file used when no override is set.
+const DefaultSelectorsPath = "config/selectors.yaml"
+
+// SelectorsPath returns the selector file to load: flagValue if set, else
+// the SELECTORS_PATH environment variable, else DefaultSelectorsPath.
+func SelectorsPath(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if envValue := os.Getenv("SELECTORS_PATH"); envValue != "" {
+		return envValue
+	}
+	return DefaultSelectorsPath
+}
+
 // SelectorConfig holds the CSS selectors for scraping Twitter.
 type SelectorConfig struct {
 	TweetContainer string
@@ -23,6 +60,26 @@ type SelectorConfig struct {
 	mu          sync.RWMutex
 	lastModTime time.Time
 	filePath    string
+
+	// open opens the selector file; defaults to os.Open.
+	open        func(name string) (io.ReadCloser, error)
+	readTimeout time.Duration
+}
+
+// DefaultSelectors returns the built-in selectors, matching the shipped
+// config/selectors.yaml. The result is not hot-reloaded.
+func DefaultSelectors() *SelectorConfig {
+	return &SelectorConfig{
+		TweetContainer: "article[data-testid='tweet']",
+		TweetText:      "[data-testid='tweetText']",
+		Timestamp:      "time",
+		AuthorName:     "[data-testid='User-Name'] span",
+		AuthorHandle:   "[data-testid='User-Name'] a[href*='/']",
+		AuthorAvatar:   "img[data-testid='Tweet-User-Avatar']",
+		VerifiedBadge:  "[data-testid='icon-verified']",
+		QuoteContainer: "[data-testid='quoteTweet']",
+		QuoteText:      "[data-testid='quoteTweet'] [data-testid='tweetText']",
+	}
 }
 
 // rawConfig represents the YAML structure.
@@ -47,7 +104,7 @@ type rawConfig struct {
 // LoadSelectors loads selector configuration from a YAML file.
 // It starts a background goroutine for hot-reloading.
 func LoadSelectors(filePath string) (*SelectorConfig, error) {
-	config := &SelectorConfig{filePath: filePath}
+	config := newSelectorConfig(filePath)
 	if err := config.reload(); err != nil {
 		return nil, err
 	}
@@ -58,9 +115,19 @@ func LoadSelectors(filePath string) (*SelectorConfig, error) {
 	return config, nil
 }
 
+// newSelectorConfig returns an empty config reading from filePath.
+func newSelectorConfig(filePath string) *SelectorConfig {
+	return &SelectorConfig{
+		filePath:    filePath,
+		open:        func(name string) (io.ReadCloser, error) { return os.Open(name) },
+		readTimeout: selectorsReadTimeout,
+	}
+}
+
 // reload reads the configuration from the file.
+// On error the previously loaded selectors are kept.
 func (c *SelectorConfig) reload() error {
-	data, err := os.ReadFile(c.filePath)
+	data, err := c.readFile()
 	if err != nil {
 		return err
 	}
@@ -86,6 +153,46 @@ func (c *SelectorConfig) reload() error {
 	return nil
 }
 
+// readFile reads the selector file, giving up after readTimeout or once
+// the file grows past maxSelectorsSize.
+func (c *SelectorConfig) readFile() ([]byte, error) {
+	type result struct {
+		data []byte
+		err  error
+	}
+
+	file, err := c.open(c.filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Buffered so the reader can finish and exit after a timeout
+	done := make(chan result, 1)
+	go func() {
+		data, err := io.ReadAll(io.LimitReader(file, maxSelectorsSize+1))
+		done <- result{data: data, err: err}
+	}()
+
+	timer := time.NewTimer(c.readTimeout)
+	defer timer.Stop()
+
+	select {
+	case res := <-done:
+		_ = file.Close()
+		if res.err != nil {
+			return nil, res.err
+		}
+		if len(res.data) > maxSelectorsSize {
+			return nil, fmt.Errorf("%w: %s exceeds %d bytes", ErrSelectorsTooLarge, c.filePath, maxSelectorsSize)
+		}
+		return res.data, nil
+	case <-timer.C:
+		// Closing may unblock the pending read; the goroutine exits either way
+		_ = file.Close()
+		return nil, fmt.Errorf("%w: %s after %s", ErrSelectorsReadTimeout, c.filePath, c.readTimeout)
+	}
+}
+
 // watch monitors the configuration file for changes and reloads it.
 func (c *SelectorConfig) watch() {
 	ticker := time.NewTicker(10 * time.Second)
@@ -95,7 +202,11 @@ func (c *SelectorConfig) watch() {
 			continue
 		}
 		if info.ModTime().After(c.lastModTime) {
-			_ = c.reload()
+			// Retried on the next tick if it fails
+			if err := c.reload(); err != nil {
+				log.GlobalWarn("selectors reload failed, keeping previous config", "path", c.filePath, "error", err)
+				continue
+			}
 			c.lastModTime = info.ModTime()
 		}
 	}
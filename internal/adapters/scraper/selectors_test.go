@@ -0,0 +1,145 @@
+package scraper
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSelectorsPath_Precedence(t *testing.T) {
+	tests := []struct {
+		name string
+		flag string
+		env  string
+		want string
+	}{
+		{name: "default", want: DefaultSelectorsPath},
+		{name: "env", env: "/tmp/env.yaml", want: "/tmp/env.yaml"},
+		{name: "flag wins over env", flag: "/tmp/flag.yaml", env: "/tmp/env.yaml", want: "/tmp/flag.yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SELECTORS_PATH", tt.env)
+
+			if got := SelectorsPath(tt.flag); got != tt.want {
+				t.Errorf("SelectorsPath(%q) = %q, want %q", tt.flag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadSelectors_OverridePath_IsUsed(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "custom.yaml")
+	yaml := "tweet:\n  container: \"div.custom-tweet\"\n  text: \"p.custom-text\"\n"
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SELECTORS_PATH", path)
+
+	// Act
+	config, err := LoadSelectors(SelectorsPath(""))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("LoadSelectors() error = %v", err)
+	}
+	if got := config.GetTweetContainer(); got != "div.custom-tweet" {
+		t.Errorf("container: got %q, want 'div.custom-tweet'", got)
+	}
+	if got := config.GetTweetText(); got != "p.custom-text" {
+		t.Errorf("text: got %q, want 'p.custom-text'", got)
+	}
+}
+
+// blockingReader blocks every Read until it is closed.
+type blockingReader struct {
+	closed chan struct{}
+}
+
+func (r *blockingReader) Read(p []byte) (int, error) {
+	<-r.closed
+	return 0, io.ErrClosedPipe
+}
+
+func (r *blockingReader) Close() error {
+	close(r.closed)
+	return nil
+}
+
+func TestSelectorConfig_Reload_BlockingRead_TimesOutAndKeepsConfig(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "selectors.yaml")
+	yaml := "tweet:\n  container: \"article\"\n  text: \"div.text\"\n"
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config := newSelectorConfig(path)
+	if err := config.reload(); err != nil {
+		t.Fatalf("initial reload() error = %v", err)
+	}
+	config.readTimeout = 20 * time.Millisecond
+	config.open = func(string) (io.ReadCloser, error) {
+		return &blockingReader{closed: make(chan struct{})}, nil
+	}
+
+	// Act
+	start := time.Now()
+	err := config.reload()
+
+	// Assert
+	if !errors.Is(err, ErrSelectorsReadTimeout) {
+		t.Fatalf("expected ErrSelectorsReadTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("reload took %v, expected it to give up after the timeout", elapsed)
+	}
+	if got := config.GetTweetContainer(); got != "article" {
+		t.Errorf("container: got %q, want previous 'article'", got)
+	}
+	if got := config.GetTweetText(); got != "div.text" {
+		t.Errorf("text: got %q, want previous 'div.text'", got)
+	}
+}
+
+func TestSelectorConfig_Reload_OversizedFile_ReturnsError(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "selectors.yaml")
+	if err := os.WriteFile(path, []byte(strings.Repeat("#", maxSelectorsSize+1)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config := newSelectorConfig(path)
+
+	// Act
+	err := config.reload()
+
+	// Assert
+	if !errors.Is(err, ErrSelectorsTooLarge) {
+		t.Errorf("expected ErrSelectorsTooLarge, got %v", err)
+	}
+}
+
+func TestDefaultSelectors_MatchShippedConfig(t *testing.T) {
+	// Arrange
+	loaded := newSelectorConfig("../../../" + DefaultSelectorsPath)
+	if err := loaded.reload(); err != nil {
+		t.Fatalf("reload() error = %v", err)
+	}
+
+	// Act
+	defaults := DefaultSelectors()
+
+	// Assert
+	if defaults.GetTweetContainer() != loaded.GetTweetContainer() || defaults.GetTweetText() != loaded.GetTweetText() ||
+		defaults.Timestamp != loaded.Timestamp || defaults.AuthorName != loaded.AuthorName ||
+		defaults.AuthorHandle != loaded.AuthorHandle || defaults.AuthorAvatar != loaded.AuthorAvatar ||
+		defaults.VerifiedBadge != loaded.VerifiedBadge || defaults.QuoteContainer != loaded.QuoteContainer ||
+		defaults.QuoteText != loaded.QuoteText {
+		t.Errorf("DefaultSelectors() differs from %s", DefaultSelectorsPath)
+	}
+}
@@ -0,0 +1,56 @@
+package scraper
+
+import (
+	"context"
+	"strings"
+
+	"sumariza-ai/internal/domain"
+)
+
+// pinnedLabel is the social context X shows above a profile's pinned tweet.
+const pinnedLabel = "Pinned"
+
+// ParseTimelineHTML extracts the tweets listed on a rendered profile page,
+// in page order. Articles without an author or text are skipped. The pinned
+// tweet, which X lists first whatever its date, has Pinned set. ctx is
+// checked per article.
+func (s *TwitterScraper) ParseTimelineHTML(ctx context.Context, html string) ([]domain.FeedItem, error) {
+	var items []domain.FeedItem
+	for scope := range tweetScopes(html) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		own := withoutQuoteTweet(scope)
+		name, handle := extractNameAndHandle(own)
+		if handle == "" {
+			handle = extractHandleFromURL(own)
+		}
+		text := extractTweetText(own, s.textOptions())
+		if handle == "" || text == "" {
+			continue
+		}
+
+		item := domain.FeedItem{
+			Author:    domain.Author{Name: name, Handle: handle, AvatarURL: extractAvatar(own)},
+			Text:      text,
+			CreatedAt: extractTimestamp(own),
+			Pinned:    isPinned(own),
+		}
+		if m := statusLinkRe.FindStringSubmatch(own); len(m) > 2 {
+			item.ID = m[2]
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// isPinned reports whether the article's social context, the line above
+// the author ("Pinned", "Alice reposted"), marks it as the pinned tweet.
+func isPinned(article string) bool {
+	idx := strings.Index(article, `data-testid="socialContext"`)
+	if idx < 0 {
+		return false
+	}
+	return strings.EqualFold(stripHTML(enclosingElement(article, idx)), pinnedLabel)
+}
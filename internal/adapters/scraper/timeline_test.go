@@ -0,0 +1,42 @@
+package scraper
+
+import (
+	"context"
+	"testing"
+
+	"sumariza-ai/test/fixtures"
+)
+
+func TestParseTimelineHTML_FlagsPinnedTweet(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateProfileTimelineWithPinned()
+	s := &TwitterScraper{selectors: DefaultSelectors()}
+
+	// Act
+	items, err := s.ParseTimelineHTML(context.Background(), html)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ParseTimelineHTML() error = %v", err)
+	}
+	want := []struct {
+		id     string
+		handle string
+		pinned bool
+	}{
+		{id: "800", handle: "johndoe", pinned: true},
+		{id: "801", handle: "alice", pinned: false}, // A repost's social context is not a pin
+		{id: "802", handle: "johndoe", pinned: false},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("got %d items, want %d: %+v", len(items), len(want), items)
+	}
+	for i, item := range items {
+		if item.ID != want[i].id || item.Author.Handle != want[i].handle || item.Pinned != want[i].pinned {
+			t.Errorf("item %d: got ID %s @%s Pinned %v, want %+v", i, item.ID, item.Author.Handle, item.Pinned, want[i])
+		}
+	}
+	if items[0].CreatedAt.Year() != 2025 {
+		t.Errorf("pinned CreatedAt: got %v, want its own 2025 date", items[0].CreatedAt)
+	}
+}
@@ -0,0 +1,132 @@
+package scraper
+
+import (
+	"strings"
+
+	"sumariza-ai/internal/domain"
+)
+
+// tweetTokens splits a tweetText HTML fragment into typed tokens, following
+// the same rules as cleanTweetHTML: the first opts.maxLinks anchors become
+// links, mentions or hashtags (blocked links become blockedLinkText), emoji
+// are rendered per opts.emoji, and the remaining markup is stripped.
+// Whitespace inside text tokens is collapsed like in the flat text.
+func tweetTokens(content string, opts textOptions) []domain.Token {
+	content = strings.ToValidUTF8(content, "")
+	content = strings.ReplaceAll(content, linkPad, "")
+
+	limit := opts.maxLinks
+	if limit <= 0 {
+		limit = defaultMaxLinks
+	}
+
+	var tokens []domain.Token
+	last := 0
+	for _, loc := range linkRe.FindAllStringSubmatchIndex(content, limit) {
+		tokens = appendTextTokens(tokens, content[last:loc[0]], opts.emoji)
+		last = loc[1]
+		tokens = appendToken(tokens, anchorToken(content[loc[2]:loc[3]], stripHTML(content[loc[4]:loc[5]]), opts))
+	}
+	tokens = appendTextTokens(tokens, content[last:], opts.emoji)
+
+	return trimTokens(tokens)
+}
+
+// anchorToken classifies one <a> by its href and visible text.
+func anchorToken(href, display string, opts textOptions) domain.Token {
+	if isInternalLink(href) {
+		switch {
+		case strings.HasPrefix(display, "@") && len(display) > 1:
+			return domain.Token{Kind: domain.TokenMention, Value: display[1:]}
+		case strings.HasPrefix(display, "#") && len(display) > 1:
+			return domain.Token{Kind: domain.TokenHashtag, Value: display[1:]}
+		}
+		// Cashtags and other internal links keep just their text
+		return domain.Token{Kind: domain.TokenText, Value: display}
+	}
+	if opts.blocked.Blocks(href) || opts.blocked.Blocks(display) {
+		return domain.Token{Kind: domain.TokenText, Value: blockedLinkText}
+	}
+	return domain.Token{Kind: domain.TokenLink, Value: opts.linkURL(href)}
+}
+
+// appendTextTokens appends the text and emoji of an HTML fragment that holds
+// no resolved anchor.
+func appendTextTokens(tokens []domain.Token, html string, emoji EmojiMode) []domain.Token {
+	html = brRe.ReplaceAllString(html, "\n")
+	html = divCloseRe.ReplaceAllString(html, "\n")
+	html = pCloseRe.ReplaceAllString(html, "\n")
+
+	last := 0
+	for _, loc := range emojiImgRe.FindAllStringIndex(html, -1) {
+		tokens = appendToken(tokens, domain.Token{Kind: domain.TokenText, Value: tagRe.ReplaceAllString(html[last:loc[0]], "")})
+		last = loc[1]
+		if rendered := emoji.render(html[loc[0]:loc[1]]); rendered != "" {
+			tokens = appendToken(tokens, domain.Token{Kind: domain.TokenEmoji, Value: rendered})
+		}
+	}
+	return appendToken(tokens, domain.Token{Kind: domain.TokenText, Value: tagRe.ReplaceAllString(html[last:], "")})
+}
+
+// appendToken appends token, merging adjacent text tokens and dropping empty ones.
+func appendToken(tokens []domain.Token, token domain.Token) []domain.Token {
+	if token.Value == "" {
+		return tokens
+	}
+	if n := len(tokens); n > 0 && token.Kind == domain.TokenText && tokens[n-1].Kind == domain.TokenText {
+		tokens[n-1].Value += token.Value
+		return tokens
+	}
+	return append(tokens, token)
+}
+
+// trimTokens collapses whitespace in text tokens, trims the text at both
+// ends and drops text tokens left empty.
+func trimTokens(tokens []domain.Token) []domain.Token {
+	trimmed := tokens[:0]
+	for i, token := range tokens {
+		if token.Kind == domain.TokenText {
+			token.Value = collapseTokenSpace(token.Value)
+			if i == 0 {
+				token.Value = strings.TrimLeft(token.Value, " \n")
+			}
+			if i == len(tokens)-1 {
+				token.Value = strings.TrimRight(token.Value, " \n")
+			}
+			if token.Value == "" {
+				continue
+			}
+		}
+		trimmed = append(trimmed, token)
+	}
+	if len(trimmed) == 0 {
+		return nil
+	}
+	return trimmed
+}
+
+// collapseTokenSpace replaces each run of ASCII whitespace with a single
+// space, or with one or two newlines when the run holds line breaks, the
+// way cleanTextPreserveNewlines shapes the flat text.
+func collapseTokenSpace(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+	for i := 0; i < len(text); {
+		j := i
+		for j < len(text) && strings.IndexByte(" \t\n\f\r", text[j]) >= 0 {
+			j++
+		}
+		if j == i {
+			b.WriteByte(text[i])
+			i++
+			continue
+		}
+		if breaks := strings.Count(text[i:j], "\n"); breaks > 0 {
+			b.WriteString("\n\n"[:min(breaks, 2)])
+		} else {
+			b.WriteByte(' ')
+		}
+		i = j
+	}
+	return b.String()
+}
@@ -0,0 +1,83 @@
+package scraper
+
+import (
+	"slices"
+	"testing"
+
+	"sumariza-ai/internal/domain"
+	"sumariza-ai/test/fixtures"
+)
+
+func TestParseHTML_RichText_ProducesTokens(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateTweetWithRichText()
+	s := &TwitterScraper{selectors: DefaultSelectors()}
+
+	// Act
+	tweet, _ := s.parseHTML(html, "123")
+
+	// Assert
+	want := []domain.Token{
+		{Kind: domain.TokenText, Value: "Thanks "},
+		{Kind: domain.TokenMention, Value: "teammate"},
+		{Kind: domain.TokenText, Value: " for the fix "},
+		{Kind: domain.TokenEmoji, Value: "🙌"},
+		{Kind: domain.TokenText, Value: "\nNotes: "},
+		{Kind: domain.TokenLink, Value: "https://t.co/AbC123"},
+		{Kind: domain.TokenText, Value: " "},
+		{Kind: domain.TokenHashtag, Value: "golang"},
+	}
+	if !slices.Equal(tweet.Content.Tokens, want) {
+		t.Errorf("Tokens:\n got %q\nwant %q", tweet.Content.Tokens, want)
+	}
+	if tweet.Content.Text != "Thanks @teammate for the fix 🙌\nNotes: [[LINK:https://t.co/AbC123]] #golang" {
+		t.Errorf("Text should be unchanged, got %q", tweet.Content.Text)
+	}
+}
+
+func TestTweetTokens_FollowsTextOptions(t *testing.T) {
+	// Arrange
+	fragment := `Read <a href="https://t.co/x">tracker.example/a</a> <img alt="🎉" src="https://abs-0.twimg.com/emoji/v2/svg/1f389.svg"/>`
+	opts := textOptions{emoji: EmojiStrip, blocked: NewLinkBlocklist([]string{"tracker.example"})}
+
+	// Act
+	tokens := tweetTokens(fragment, opts)
+
+	// Assert
+	want := []domain.Token{{Kind: domain.TokenText, Value: "Read " + blockedLinkText}}
+	if !slices.Equal(tokens, want) {
+		t.Errorf("got %q, want %q", tokens, want)
+	}
+}
+
+func TestTweetTokens_LinksPastLimit_StayText(t *testing.T) {
+	// Arrange
+	fragment := `<a href="https://a.example">a</a> <a href="https://b.example">b.example</a>`
+
+	// Act
+	tokens := tweetTokens(fragment, textOptions{maxLinks: 1})
+
+	// Assert
+	want := []domain.Token{
+		{Kind: domain.TokenLink, Value: "https://a.example"},
+		{Kind: domain.TokenText, Value: " b.example"},
+	}
+	if !slices.Equal(tokens, want) {
+		t.Errorf("got %q, want %q", tokens, want)
+	}
+}
+
+func TestParseHTML_MetaOnlyTweet_SingleTextToken(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateMetaOnlyTweet()
+	s := &TwitterScraper{selectors: DefaultSelectors()}
+
+	// Act
+	tweet, _ := s.parseHTML(html, "789")
+
+	// Assert
+	want := []domain.Token{{Kind: domain.TokenText, Value: tweet.Content.Text}}
+	if !slices.Equal(tweet.Content.Tokens, want) {
+		t.Errorf("got %q, want %q", tweet.Content.Tokens, want)
+	}
+}
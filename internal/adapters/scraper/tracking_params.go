@@ -0,0 +1,56 @@
+package scraper
+
+import (
+	"html"
+	"net/url"
+	"strings"
+)
+
+// stripTrackingParams removes the query parameters whose names start with
+// one of prefixes (case-insensitive), e.g. "utm_" or "fbclid". Other
+// parameters keep their order and encoding, and the fragment is kept.
+// Links without a query, or that don't parse, are returned unchanged.
+func stripTrackingParams(link string, prefixes []string) string {
+	if len(prefixes) == 0 || !strings.Contains(link, "?") {
+		return link
+	}
+	u, err := url.Parse(link)
+	if err != nil || u.RawQuery == "" {
+		return link
+	}
+
+	var kept []string
+	for _, param := range strings.Split(u.RawQuery, "&") {
+		name, _, _ := strings.Cut(param, "=")
+		if decoded, err := url.QueryUnescape(name); err == nil {
+			name = decoded
+		}
+		if !hasTrackingPrefix(name, prefixes) {
+			kept = append(kept, param)
+		}
+	}
+	u.RawQuery = strings.Join(kept, "&")
+	u.ForceQuery = false
+	return u.String()
+}
+
+// hasTrackingPrefix reports whether a parameter name starts with one of prefixes.
+func hasTrackingPrefix(name string, prefixes []string) bool {
+	name = strings.ToLower(name)
+	for _, prefix := range prefixes {
+		if prefix != "" && strings.HasPrefix(name, strings.ToLower(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// linkURL returns the URL a link in tweet text resolves to: href as found in
+// the page, entity-decoded and without tracking parameters when stripping is
+// enabled.
+func (o textOptions) linkURL(href string) string {
+	if len(o.trackingParams) == 0 {
+		return href
+	}
+	return stripTrackingParams(html.UnescapeString(href), o.trackingParams)
+}
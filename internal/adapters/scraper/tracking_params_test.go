@@ -0,0 +1,60 @@
+package scraper
+
+import "testing"
+
+func TestStripTrackingParams(t *testing.T) {
+	prefixes := []string{"utm_", "fbclid", "gclid"}
+
+	tests := []struct {
+		name string
+		link string
+		want string
+	}{
+		{name: "utm params", link: "https://go.dev/blog?utm_source=x&utm_medium=social&utm_campaign=launch", want: "https://go.dev/blog"},
+		{name: "fbclid", link: "https://example.com/a?fbclid=IwAR0abc", want: "https://example.com/a"},
+		{name: "meaningful params kept in order", link: "https://example.com/search?q=go+tips&utm_source=x&page=2&sort=new", want: "https://example.com/search?q=go+tips&page=2&sort=new"},
+		{name: "case-insensitive", link: "https://example.com/?UTM_Source=x&id=7", want: "https://example.com/?id=7"},
+		{name: "fragment kept", link: "https://example.com/doc?gclid=1#install", want: "https://example.com/doc#install"},
+		{name: "no query", link: "https://t.co/AbC123", want: "https://t.co/AbC123"},
+		{name: "similar name kept", link: "https://example.com/?utm=1&fbclid_note=no", want: "https://example.com/?utm=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := stripTrackingParams(tt.link, prefixes)
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("stripTrackingParams(%q) = %q, want %q", tt.link, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripTrackingParams_NoPrefixes_KeepsLink(t *testing.T) {
+	// Arrange
+	link := "https://example.com/a?utm_source=x"
+
+	// Act
+	got := stripTrackingParams(link, nil)
+
+	// Assert
+	if got != link {
+		t.Errorf("got %q, want %q", got, link)
+	}
+}
+
+func TestExtractTweetText_TrackingParams_StrippedFromLinks(t *testing.T) {
+	// Arrange
+	html := `<div data-testid="tweetText">Read <a href="https://go.dev/blog/x?utm_source=twitter&amp;id=3">go.dev/blog/x</a></div>`
+
+	// Act
+	text := extractTweetText(html, textOptions{trackingParams: []string{"utm_"}})
+
+	// Assert
+	want := "Read [[LINK:https://go.dev/blog/x?id=3]]"
+	if text != want {
+		t.Errorf("got %q, want %q", text, want)
+	}
+}
@@ -0,0 +1,95 @@
+package scraper
+
+import (
+	"strings"
+
+	"sumariza-ai/internal/domain"
+)
+
+// unavailableSelector matches the notices Twitter shows instead of a tweet
+// (protected account, deleted or missing tweet).
+const unavailableSelector = "[data-testid='emptyState'], [data-testid='error-detail']"
+
+// unavailableNotices map phrases (lowercase) of Twitter's notices to errors.
+var unavailableNotices = []struct {
+	phrase string
+	err    error
+}{
+	{phrase: "these posts are protected", err: domain.ErrTweetPrivate},
+	{phrase: "these tweets are protected", err: domain.ErrTweetPrivate},
+	{phrase: "limits who can view their posts", err: domain.ErrTweetPrivate},
+	{phrase: "this page doesn’t exist", err: domain.ErrTweetNotFound},
+	{phrase: "this page doesn't exist", err: domain.ErrTweetNotFound},
+	{phrase: "this post was deleted", err: domain.ErrTweetNotFound},
+}
+
+// unavailableQuoteNotices are phrases (lowercase) of the placeholder Twitter
+// renders in the quote container when the quoted tweet was deleted or is
+// private.
+var unavailableQuoteNotices = []string{
+	"this post is unavailable",
+	"this tweet is unavailable",
+	"this post is from an account that no longer exists",
+	"you’re unable to view this post",
+	"you're unable to view this post",
+}
+
+// isUnavailableQuote reports whether a quote container holds the
+// placeholder of an unavailable tweet instead of the tweet.
+func isUnavailableQuote(scope string) bool {
+	text := strings.ToLower(stripHTML(scope))
+	for _, phrase := range unavailableQuoteNotices {
+		if strings.Contains(text, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// sensitiveNotices are phrases (lowercase) of the warnings Twitter shows over
+// sensitive or age-restricted tweets until the reader clicks through.
+var sensitiveNotices = []string{
+	"may contain sensitive content",
+	"potentially sensitive content",
+	"age-restricted adult content",
+	"content warning:",
+}
+
+// hasSensitiveWarning reports whether the primary tweet is covered by a
+// sensitive content warning. Only the primary tweet's article is inspected,
+// so a warning on a reply or a sidebar item is ignored.
+func hasSensitiveWarning(html string) bool {
+	scope := strings.ToLower(primaryTweetScope(html))
+	for _, phrase := range sensitiveNotices {
+		if strings.Contains(scope, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClassifyPage reports why a tweet page shows no tweet. It returns
+// domain.ErrTweetPrivate for a protected account's notice,
+// domain.ErrTweetNotFound for a missing or deleted tweet, or nil when the
+// page has no recognized notice. Only the notice elements are inspected, so
+// tweet text quoting the same words is never misclassified.
+func ClassifyPage(html string) error {
+	for _, marker := range []string{`data-testid="emptyState"`, `data-testid="error-detail"`} {
+		for offset := 0; ; {
+			idx := strings.Index(html[offset:], marker)
+			if idx < 0 {
+				break
+			}
+			idx += offset
+			offset = idx + len(marker)
+
+			notice := strings.ToLower(enclosingElement(html, idx))
+			for _, n := range unavailableNotices {
+				if strings.Contains(notice, n.phrase) {
+					return n.err
+				}
+			}
+		}
+	}
+	return nil
+}
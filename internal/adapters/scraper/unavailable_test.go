@@ -0,0 +1,188 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/chromedp/chromedp"
+
+	"sumariza-ai/internal/domain"
+	"sumariza-ai/pkg/log"
+	"sumariza-ai/test/fixtures"
+)
+
+func TestClassifyPage_ProtectedAccount_ReturnsErrTweetPrivate(t *testing.T) {
+	// Arrange
+	html := fixtures.GenerateProtectedAccountPage()
+
+	// Act
+	err := ClassifyPage(html)
+
+	// Assert
+	if !errors.Is(err, domain.ErrTweetPrivate) {
+		t.Errorf("expected ErrTweetPrivate, got %v", err)
+	}
+}
+
+func TestClassifyPage_LimitedViewErrorDetail_ReturnsErrTweetPrivate(t *testing.T) {
+	// Arrange
+	html := `<div data-testid="error-detail"><span>You’re unable to view this Post because this account owner limits who can view their Posts.</span></div>`
+
+	// Act
+	err := ClassifyPage(html)
+
+	// Assert
+	if !errors.Is(err, domain.ErrTweetPrivate) {
+		t.Errorf("expected ErrTweetPrivate, got %v", err)
+	}
+}
+
+func TestClassifyPage_TweetQuotingNotice_ReturnsNil(t *testing.T) {
+	// Arrange: the phrase appears in tweet text, not in a notice
+	html := `<article data-testid="tweet"><div data-testid="tweetText">These posts are protected, lol</div></article>`
+
+	// Act
+	err := ClassifyPage(html)
+
+	// Assert
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+}
+
+func TestClassifyPage_RegularTweet_ReturnsNil(t *testing.T) {
+	if err := ClassifyPage(fixtures.GenerateBasicTweet()); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+}
+
+func TestHasSensitiveWarning_OnlyInspectsPrimaryTweet(t *testing.T) {
+	// Arrange: the warning is on a reply, not on the main tweet
+	html := `<article data-testid="tweet"><div data-testid="User-Name">Jane</div></article>
+<article data-testid="tweet"><span>Age-restricted adult content.</span></article>`
+
+	// Act
+	got := hasSensitiveWarning(html)
+
+	// Assert
+	if got {
+		t.Error("expected warning on a reply to be ignored")
+	}
+}
+
+func TestHasSensitiveWarning_DetectsOverlay(t *testing.T) {
+	// Act
+	got := hasSensitiveWarning(fixtures.GenerateSensitiveContentTweet())
+
+	// Assert
+	if !got {
+		t.Error("expected sensitive content warning to be detected")
+	}
+}
+
+func TestResultFromHTML_StatusPerFixture(t *testing.T) {
+	tests := []struct {
+		name      string
+		html      string
+		want      domain.ScrapeStatus
+		wantErr   error
+		wantTweet bool
+	}{
+		{name: "success", html: fixtures.GenerateCompleteTweet(), want: domain.ScrapeSuccess, wantTweet: true},
+		{name: "partial", html: fixtures.GeneratePartialTweet(), want: domain.ScrapePartial, wantTweet: true},
+		{name: "not found", html: fixtures.GenerateNotFoundPage(), want: domain.ScrapeNotFound, wantErr: domain.ErrTweetNotFound},
+		{name: "private", html: fixtures.GenerateProtectedAccountPage(), want: domain.ScrapePrivate, wantErr: domain.ErrTweetPrivate},
+		{name: "blocked", html: fixtures.GenerateLoginWallPage(), want: domain.ScrapeBlocked, wantErr: domain.ErrTextNotFound},
+		{name: "sensitive", html: fixtures.GenerateSensitiveContentTweet(), want: domain.ScrapeSensitive, wantErr: domain.ErrSensitiveContent},
+		{name: "meta only", html: fixtures.GenerateMetaOnlyTweet(), want: domain.ScrapePartial, wantTweet: true},
+		{name: "sensitive media with text", html: fixtures.GenerateSensitiveMediaTweet(), want: domain.ScrapePartial, wantTweet: true},
+		{name: "media without text", html: fixtures.GenerateImageOnlyTweet(), want: domain.ScrapeSuccess, wantTweet: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			s := &TwitterScraper{selectors: DefaultSelectors()}
+
+			// Act
+			result := s.resultFromHTML(context.Background(), tt.html, "1")
+
+			// Assert
+			if result.Status != tt.want {
+				t.Errorf("status: got %q, want %q", result.Status, tt.want)
+			}
+			if result.Err != tt.wantErr {
+				t.Errorf("err: got %v, want %v", result.Err, tt.wantErr)
+			}
+			if (result.Tweet != nil) != tt.wantTweet {
+				t.Errorf("tweet: got %v, want present=%v", result.Tweet, tt.wantTweet)
+			}
+		})
+	}
+}
+
+func TestScrapeResult_BrowserFailure_IsError(t *testing.T) {
+	// Arrange
+	s := newMockedScraper(func(ctx context.Context, actions ...chromedp.Action) error {
+		return errors.New("browser gone")
+	})
+
+	// Act
+	result := s.ScrapeResult(context.Background(), "1")
+
+	// Assert
+	if result.Status != domain.ScrapeError {
+		t.Errorf("status: got %q, want %q", result.Status, domain.ScrapeError)
+	}
+	if !errors.Is(result.Err, domain.ErrScrapingFailed) {
+		t.Errorf("err: got %v, want ErrScrapingFailed", result.Err)
+	}
+}
+
+func TestScrapeResult_BrowserCannotStart_ReturnsErrBrowserUnavailable(t *testing.T) {
+	// Arrange
+	bp := newLockTestPool()
+	bp.ensureRunning = bp.ensureBrowserRunning
+	bp.start = func() error { return errors.New("no chrome binary") }
+	s := newMockedScraper(func(ctx context.Context, actions ...chromedp.Action) error { return nil })
+	s.pool = bp
+
+	// Act
+	result := s.ScrapeResult(context.Background(), "1")
+
+	// Assert
+	if result.Err != domain.ErrBrowserUnavailable {
+		t.Errorf("err: got %v, want ErrBrowserUnavailable", result.Err)
+	}
+	if result.Status != domain.ScrapeError {
+		t.Errorf("status: got %q, want %q", result.Status, domain.ScrapeError)
+	}
+}
+
+func TestScrapeResult_BrowserFailure_WrapsRequestID(t *testing.T) {
+	// Arrange
+	cause := errors.New("browser gone")
+	s := newMockedScraper(func(ctx context.Context, actions ...chromedp.Action) error {
+		return cause
+	})
+	ctx := log.WithRequestID(context.Background(), "req-123")
+
+	// Act
+	result := s.ScrapeResult(ctx, "42")
+
+	// Assert
+	if !errors.Is(result.Err, domain.ErrScrapingFailed) {
+		t.Errorf("errors.Is(err, ErrScrapingFailed) = false for %v", result.Err)
+	}
+	if !errors.Is(result.Err, cause) {
+		t.Errorf("errors.Is(err, cause) = false for %v", result.Err)
+	}
+	want := "failed to scrape tweet (request_id=req-123, tweet_id=42): browser gone"
+	if result.Err.Error() != want {
+		t.Errorf("err text: got %q, want %q", result.Err.Error(), want)
+	}
+	if result.Status != domain.ScrapeError {
+		t.Errorf("status: got %q, want %q", result.Status, domain.ScrapeError)
+	}
+}
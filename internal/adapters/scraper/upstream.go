@@ -0,0 +1,35 @@
+package scraper
+
+import "sync/atomic"
+
+// Upstream states reported by UpstreamStatus.
+const (
+	UpstreamUnknown = "unknown"
+	UpstreamOK      = "ok"
+	UpstreamBlocked = "blocked"
+)
+
+// upstreamTracker remembers the upstream state seen by the last scrape.
+type upstreamTracker struct {
+	status atomic.Value
+}
+
+func (u *upstreamTracker) set(status string) {
+	u.status.Store(status)
+}
+
+func (u *upstreamTracker) get() string {
+	if status, ok := u.status.Load().(string); ok {
+		return status
+	}
+	return UpstreamUnknown
+}
+
+// UpstreamStatus reports whether Twitter served the last scraped tweet.
+// It is derived from real scrapes, so no extra traffic is sent to Twitter:
+// "unknown" before the first scrape, "blocked" when the page loaded but the
+// tweet never rendered, "ok" otherwise. Navigation failures are a browser or
+// network problem and leave the status unchanged.
+func (s *TwitterScraper) UpstreamStatus() string {
+	return s.upstream.get()
+}
@@ -0,0 +1,81 @@
+package web
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"sumariza-ai/pkg/log"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxWarmSize is the maximum number of URLs accepted per warm request.
+const maxWarmSize = 200
+
+// warmItemResponse is the JSON result for one URL of a warm request.
+type warmItemResponse struct {
+	URL   string `json:"url"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// warmResponse is the JSON response of a warm request.
+type warmResponse struct {
+	Warmed  int                `json:"warmed"`
+	Failed  int                `json:"failed"`
+	Results []warmItemResponse `json:"results"`
+}
+
+// SetAdminToken sets the bearer token required by admin endpoints.
+// An empty token disables them.
+func (h *Handlers) SetAdminToken(token string) {
+	h.adminToken = token
+}
+
+// RequireAdmin rejects requests without the admin bearer token.
+// Admin endpoints answer 404 when no token is configured.
+func (h *Handlers) RequireAdmin(c *fiber.Ctx) error {
+	if h.adminToken == "" {
+		return fiber.ErrNotFound
+	}
+
+	token, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) != 1 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized."})
+	}
+
+	return c.Next()
+}
+
+// AdminWarm pre-populates the cache with the tweets behind a list of URLs.
+// Scrapes go through the batch use case, so the concurrency cap and the
+// global scrape throttle apply.
+func (h *Handlers) AdminWarm(c *fiber.Ctx) error {
+	var req batchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body."})
+	}
+	if len(req.URLs) == 0 || len(req.URLs) > maxWarmSize {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Send between 1 and 200 tweet URLs."})
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Minute)
+	defer cancel()
+
+	resp := warmResponse{Results: make([]warmItemResponse, len(req.URLs))}
+	for i, outcome := range h.runBatch(ctx, req.URLs) {
+		resp.Results[i].URL = outcome.url
+		if outcome.err != nil {
+			resp.Results[i].Error = outcome.err.Error()
+			resp.Failed++
+			continue
+		}
+		resp.Results[i].OK = true
+		resp.Warmed++
+	}
+
+	log.GlobalInfoCtx(ctx, "cache warm finished", "warmed", resp.Warmed, "failed", resp.Failed)
+	return c.JSON(resp)
+}
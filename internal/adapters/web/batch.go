@@ -0,0 +1,101 @@
+package web
+
+import (
+	"context"
+	"time"
+
+	"sumariza-ai/internal/domain"
+	"sumariza-ai/internal/usecases"
+	"sumariza-ai/pkg/log"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxBatchSize is the maximum number of URLs accepted per batch request.
+const maxBatchSize = 20
+
+// batchRequest is the JSON body of a batch request.
+type batchRequest struct {
+	URLs []string `json:"urls"`
+}
+
+// batchItemResponse is the JSON result for one URL of a batch.
+type batchItemResponse struct {
+	URL   string         `json:"url"`
+	Tweet *tweetResponse `json:"tweet,omitempty"`
+	Error string         `json:"error,omitempty"`
+}
+
+// batchResponse is the JSON response of a batch request.
+type batchResponse struct {
+	Results []batchItemResponse `json:"results"`
+}
+
+// APIGetTweetsBatch returns several tweets as JSON, in request order.
+// Invalid URLs are reported per item without failing the whole batch.
+func (h *Handlers) APIGetTweetsBatch(c *fiber.Ctx) error {
+	var req batchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body."})
+	}
+	if len(req.URLs) == 0 || len(req.URLs) > maxBatchSize {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Send between 1 and 20 tweet URLs."})
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 60*time.Second)
+	defer cancel()
+
+	items := make([]batchItemResponse, len(req.URLs))
+	for i, outcome := range h.runBatch(ctx, req.URLs) {
+		items[i].URL = outcome.url
+		if outcome.err != nil {
+			items[i].Error = h.friendlyError(outcome.err)
+			continue
+		}
+		tweet := newTweetResponse(outcome.tweet)
+		items[i].Tweet = &tweet
+	}
+
+	return c.JSON(batchResponse{Results: items})
+}
+
+// batchOutcome is the result of fetching one URL of a batch.
+type batchOutcome struct {
+	url   string
+	tweet *domain.Tweet
+	err   error
+}
+
+// runBatch fetches the tweets behind urls through the batch use case,
+// returning one outcome per URL in order. Invalid URLs fail with
+// domain.ErrInvalidURL without being scraped.
+func (h *Handlers) runBatch(ctx context.Context, urls []string) []batchOutcome {
+	outcomes := make([]batchOutcome, len(urls))
+	var refs []usecases.TweetRef
+	var refIndex []int
+	for i, url := range urls {
+		outcomes[i].url = url
+		username, tweetID, err := ParseTweetURL(url)
+		if err != nil {
+			outcomes[i].err = domain.ErrInvalidURL
+			continue
+		}
+		refs = append(refs, usecases.TweetRef{Username: username, TweetID: tweetID})
+		refIndex = append(refIndex, i)
+	}
+
+	for j, result := range h.getTweets.Execute(ctx, refs) {
+		outcome := &outcomes[refIndex[j]]
+		if result.Err != nil {
+			log.GlobalErrorCtx(ctx, "batch get tweet failed",
+				"username", result.Ref.Username,
+				"tweet_id", result.Ref.TweetID,
+				"error", result.Err)
+			outcome.err = result.Err
+			continue
+		}
+		outcome.tweet = result.Tweet
+	}
+
+	return outcomes
+}
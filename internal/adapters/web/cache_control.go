@@ -0,0 +1,38 @@
+package web
+
+import (
+	"fmt"
+	"time"
+
+	"sumariza-ai/internal/usecases"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// missMaxAge is the Cache-Control max-age, in seconds, of freshly scraped tweets.
+const missMaxAge = 60
+
+// StaleHeader is set to "true" on tweets served from an expired cache entry
+// because scraping failed.
+const StaleHeader = "X-Stale"
+
+// setTweetCacheControl lets browsers and CDNs cache a tweet response for as
+// long as the server cache will keep serving it. meta is nil for a fresh
+// scrape, which gets a short max-age instead. Stale entries are flagged and
+// must not be cached downstream.
+func setTweetCacheControl(c *fiber.Ctx, meta *usecases.CacheMeta) {
+	if meta != nil && meta.Stale {
+		c.Set(StaleHeader, "true")
+		c.Set(fiber.HeaderCacheControl, "no-cache")
+		return
+	}
+
+	maxAge := missMaxAge
+	if meta != nil {
+		maxAge = int(time.Until(meta.ExpiresAt) / time.Second)
+		if maxAge < 0 {
+			maxAge = 0
+		}
+	}
+	c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", maxAge))
+}
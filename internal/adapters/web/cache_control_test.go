@@ -0,0 +1,102 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"sumariza-ai/internal/adapters/cache"
+	"sumariza-ai/internal/domain"
+	"sumariza-ai/internal/usecases"
+	"sumariza-ai/pkg/clock"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func getCacheControl(t *testing.T, app *fiber.App, path string) string {
+	t.Helper()
+	resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	resp.Body.Close()
+	return resp.Header.Get("Cache-Control")
+}
+
+func TestAPIGetTweetJSON_CacheHit_MaxAgeIsRemainingTTL(t *testing.T) {
+	// Arrange
+	ttl := 10 * time.Minute
+	tweetCache := cache.NewMemoryCache(ttl)
+	tweetCache.Set("user", "123", &domain.Tweet{ID: "123", Content: domain.Content{Text: "Cached"}})
+	getTweetUC := usecases.NewGetTweetUseCase(tweetCache, usecases.NewScrapeTweetUseCase(&countingScraper{}))
+	app := fiber.New()
+	app.Get("/api/v1/tweet/:username/:id", NewHandlers(getTweetUC, nil).APIGetTweetJSON)
+
+	// Act
+	got := getCacheControl(t, app, "/api/v1/tweet/user/123")
+
+	// Assert - allow a couple of seconds for the time spent in the test
+	var maxAge int
+	if _, err := fmt.Sscanf(got, "public, max-age=%d", &maxAge); err != nil {
+		t.Fatalf("Cache-Control: got %q, want 'public, max-age=N'", got)
+	}
+	if want := int(ttl / time.Second); maxAge > want || maxAge < want-2 {
+		t.Errorf("max-age: got %d, want about %d", maxAge, want)
+	}
+}
+
+func TestAPIGetTweetJSON_CacheMiss_ShortMaxAge(t *testing.T) {
+	// Arrange
+	getTweetUC := usecases.NewGetTweetUseCase(cache.NewMemoryCache(time.Hour), usecases.NewScrapeTweetUseCase(&countingScraper{text: "Fresh"}))
+	app := fiber.New()
+	app.Get("/api/v1/tweet/:username/:id", NewHandlers(getTweetUC, nil).APIGetTweetJSON)
+
+	// Act
+	got := getCacheControl(t, app, "/api/v1/tweet/user/123")
+
+	// Assert
+	if want := fmt.Sprintf("public, max-age=%d", missMaxAge); got != want {
+		t.Errorf("Cache-Control: got %q, want %q", got, want)
+	}
+}
+
+// failingScraper always fails as if Twitter were unreachable.
+type failingScraper struct{}
+
+func (failingScraper) Scrape(ctx context.Context, tweetID string) (*domain.Tweet, error) {
+	return nil, domain.ErrScrapingFailed
+}
+
+func TestAPIGetTweetJSON_StaleFallback_FlagsResponse(t *testing.T) {
+	// Arrange - the entry expired a minute ago but is still within the grace
+	fake := clock.NewFake(time.Now())
+	tweetCache := cache.NewMemoryCache(time.Minute)
+	tweetCache.SetClock(fake)
+	tweetCache.SetStaleGrace(time.Hour)
+	tweetCache.Set("user", "123", &domain.Tweet{ID: "123", Content: domain.Content{Text: "Old"}})
+	fake.Advance(2 * time.Minute)
+	getTweetUC := usecases.NewGetTweetUseCase(tweetCache, usecases.NewScrapeTweetUseCase(failingScraper{}))
+	getTweetUC.SetStaleFallback(tweetCache)
+	app := fiber.New()
+	app.Get("/api/v1/tweet/:username/:id", NewHandlers(getTweetUC, nil).APIGetTweetJSON)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/tweet/user/123", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	resp.Body.Close()
+
+	// Assert
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if got := resp.Header.Get(StaleHeader); got != "true" {
+		t.Errorf("%s: got %q, want \"true\"", StaleHeader, got)
+	}
+	if got := resp.Header.Get("Cache-Control"); got != "no-cache" {
+		t.Errorf("Cache-Control: got %q, want \"no-cache\"", got)
+	}
+}
@@ -7,31 +7,47 @@ import (
 	"sumariza-ai/internal/domain"
 	"sumariza-ai/internal/usecases"
 	"sumariza-ai/pkg/log"
+	"sumariza-ai/pkg/stats"
 	"sumariza-ai/templates/components"
 	"sumariza-ai/templates/pages"
 	"sumariza-ai/templates/partials"
 
 	"github.com/a-h/templ"
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/adaptor"
 )
 
 // Handlers contains the HTTP handlers for the web application.
 type Handlers struct {
-	getTweet *usecases.GetTweetUseCase
+	getTweet   *usecases.GetTweetUseCase
+	getTweets  *usecases.BatchGetTweetsUseCase
+	browser    BrowserPinger
+	upstream   UpstreamReporter
+	parser     TweetParser
+	robotsTxt  string
+	noIndex    bool
+	adminToken string
+	displayTZ  *time.Location
+	stats      *stats.Registry
+	policy     ScrapePolicy
 }
 
 // NewHandlers creates a new Handlers instance.
-func NewHandlers(getTweet *usecases.GetTweetUseCase) *Handlers {
+func NewHandlers(getTweet *usecases.GetTweetUseCase, getTweets *usecases.BatchGetTweetsUseCase) *Handlers {
 	return &Handlers{
-		getTweet: getTweet,
+		getTweet:  getTweet,
+		getTweets: getTweets,
 	}
 }
 
-// render is a helper to render templ components.
+// render is a helper to render templ components. The response keeps the
+// status already set on c (200 unless a handler chose another, e.g. 404).
 func render(c *fiber.Ctx, component templ.Component) error {
-	c.Set("Content-Type", "text/html")
-	return adaptor.HTTPHandler(templ.Handler(component))(c)
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	if err := component.Render(c.UserContext(), c.Response().BodyWriter()); err != nil {
+		c.Response().ResetBody()
+		return err
+	}
+	return nil
 }
 
 // Home renders the landing page with URL input.
@@ -40,11 +56,14 @@ func (h *Handlers) Home(c *fiber.Ctx) error {
 }
 
 // ViewTweet renders a tweet by username and ID (mirrors Twitter URL structure).
-// Shows skeleton immediately, HTMX loads content.
+// Shows skeleton immediately, HTMX loads content. A tweet that is already
+// cached is also embedded as JSON-LD; the page never waits for a scrape.
+// On .../photo/N paths the N-th media item is emphasized once loaded.
 func (h *Handlers) ViewTweet(c *fiber.Ctx) error {
 	username := c.Params("username")
 	tweetID := c.Params("id")
-	return render(c, pages.TweetViewWithSkeleton(username, tweetID))
+	cached, _ := h.getTweet.Cached(tweetID, username)
+	return render(c, pages.TweetViewWithSkeleton(username, tweetID, ParsePhotoIndex(c.Path()), cached))
 }
 
 // FetchTweet handles HTMX request to fetch and render a tweet from form input.
@@ -60,7 +79,7 @@ func (h *Handlers) FetchTweet(c *fiber.Ctx) error {
 	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
 	defer cancel()
 
-	tweet, err := h.getTweet.Execute(ctx, tweetID, username)
+	tweet, err := h.getTweet.Get(ctx, tweetID, username)
 	if err != nil {
 		log.GlobalErrorCtx(ctx, "fetch tweet failed", "username", username, "tweet_id", tweetID, "error", err)
 		return h.renderError(c, err)
@@ -69,7 +88,7 @@ func (h *Handlers) FetchTweet(c *fiber.Ctx) error {
 	// Set HX-Push-Url header for shareable URL (mirrors Twitter structure)
 	c.Set("HX-Push-Url", "/"+username+"/status/"+tweetID)
 
-	return render(c, partials.TweetContent(tweet))
+	return render(c, partials.TweetContent(tweet, h.displayLocation(c)))
 }
 
 // APIGetTweet handles the HTMX request to fetch actual tweet content.
@@ -81,13 +100,70 @@ func (h *Handlers) APIGetTweet(c *fiber.Ctx) error {
 	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
 	defer cancel()
 
-	tweet, err := h.getTweet.Execute(ctx, tweetID, username)
+	tweet, meta, err := h.getTweet.ExecuteWithMeta(ctx, tweetID, username, getTweetOptions(c))
 	if err != nil {
 		log.GlobalErrorCtx(ctx, "api get tweet failed", "username", username, "tweet_id", tweetID, "error", err)
 		return render(c, components.ErrorMessage(h.friendlyError(err)))
 	}
 
-	return render(c, components.TweetCard(tweet))
+	setTweetCacheControl(c, meta)
+	return render(c, components.TweetCard(tweet, h.displayLocation(c)))
+}
+
+// APIGetTweetJSON returns a tweet as JSON.
+// Supports ?fresh=1 to bypass the cache read (the result is still cached)
+// and ?max_age=N to reject cache entries older than N seconds.
+func (h *Handlers) APIGetTweetJSON(c *fiber.Ctx) error {
+	username := c.Params("username")
+	tweetID := c.Params("id")
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
+	defer cancel()
+
+	tweet, meta, err := h.getTweet.ExecuteWithMeta(ctx, tweetID, username, getTweetOptions(c))
+	if err != nil {
+		log.GlobalErrorCtx(ctx, "api get tweet json failed", "username", username, "tweet_id", tweetID, "error", err)
+		return c.Status(statusForError(err)).JSON(fiber.Map{"error": h.friendlyError(err)})
+	}
+
+	setTweetCacheControl(c, meta)
+	return c.JSON(newTweetResponse(tweet))
+}
+
+// getTweetOptions builds use case options from the request query.
+// ?fresh=1 bypasses the cache read; ?max_age=N rejects entries older than N seconds.
+func getTweetOptions(c *fiber.Ctx) usecases.GetTweetOptions {
+	opts := usecases.GetTweetOptions{
+		BypassCache: c.QueryBool("fresh"),
+	}
+	if seconds := c.QueryInt("max_age"); seconds > 0 {
+		opts.MaxAge = time.Duration(seconds) * time.Second
+	}
+	return opts
+}
+
+// statusForError maps domain errors to HTTP status codes for JSON responses.
+// Scrape outcomes are mapped by their status; other errors individually.
+func statusForError(err error) int {
+	switch domain.StatusFromError(err) {
+	case domain.ScrapeNotFound, domain.ScrapeBlocked:
+		return fiber.StatusNotFound
+	case domain.ScrapePrivate, domain.ScrapeSensitive:
+		return fiber.StatusForbidden
+	}
+
+	switch err {
+	case domain.ErrInvalidURL:
+		return fiber.StatusBadRequest
+	case domain.ErrBlockedContent:
+		return fiber.StatusUnavailableForLegalReasons
+	case domain.ErrRateLimited:
+		return fiber.StatusTooManyRequests
+	case domain.ErrBrowserUnavailable:
+		return fiber.StatusServiceUnavailable
+	default:
+		return fiber.StatusBadGateway
+	}
 }
 
 // renderError renders a full-page error.
@@ -97,18 +173,28 @@ func (h *Handlers) renderError(c *fiber.Ctx, err error) error {
 }
 
 // friendlyError returns a neutral, non-blaming error message.
+// Scrape outcomes are described by their status; other errors individually.
 func (h *Handlers) friendlyError(err error) string {
-	switch err {
-	case domain.ErrTweetNotFound:
+	switch domain.StatusFromError(err) {
+	case domain.ScrapeNotFound:
 		return "This tweet couldn't be found. It might be private or no longer available."
-	case domain.ErrTweetPrivate:
+	case domain.ScrapePrivate:
 		return "This tweet isn't available. It might be from a private account."
+	case domain.ScrapeBlocked:
+		return "This tweet couldn't be loaded. It might not be publicly available."
+	case domain.ScrapeSensitive:
+		return "This tweet is marked as sensitive content and can't be shown here."
+	}
+
+	switch err {
 	case domain.ErrInvalidURL:
 		return "That doesn't look like a tweet URL. Try pasting a link from twitter.com or x.com"
 	case domain.ErrRateLimited:
 		return "Too many requests. Please wait a moment and try again."
-	case domain.ErrTextNotFound:
-		return "This tweet couldn't be loaded. It might not be publicly available."
+	case domain.ErrBlockedContent:
+		return "This tweet isn't available here."
+	case domain.ErrBrowserUnavailable:
+		return "The service is starting up. Please try again in a moment."
 	default:
 		return "Unable to load this tweet right now. Please try again in a moment."
 	}
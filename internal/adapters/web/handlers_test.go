@@ -0,0 +1,425 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"sumariza-ai/internal/adapters/cache"
+	"sumariza-ai/internal/domain"
+	"sumariza-ai/internal/usecases"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// countingScraper returns a fixed tweet and counts scrapes.
+type countingScraper struct {
+	text  string
+	calls int
+}
+
+func (s *countingScraper) Scrape(ctx context.Context, tweetID string) (*domain.Tweet, error) {
+	s.calls++
+	return &domain.Tweet{ID: tweetID, Content: domain.Content{Text: s.text}}, nil
+}
+
+func TestAPIGetTweetJSON_Fresh_BypassesCacheRead(t *testing.T) {
+	// Arrange
+	tweetCache := cache.NewMemoryCache(time.Minute)
+	tweetCache.Set("user", "123", &domain.Tweet{ID: "123", Content: domain.Content{Text: "Cached"}})
+	scraper := &countingScraper{text: "Fresh"}
+	getTweetUC := usecases.NewGetTweetUseCase(tweetCache, usecases.NewScrapeTweetUseCase(scraper))
+	app := fiber.New()
+	app.Get("/api/v1/tweet/:username/:id", NewHandlers(getTweetUC, usecases.NewBatchGetTweetsUseCase(getTweetUC, 1)).APIGetTweetJSON)
+
+	for _, tc := range []struct {
+		query     string
+		wantText  string
+		wantCalls int
+	}{
+		{query: "", wantText: "Cached", wantCalls: 0},
+		{query: "?fresh=1", wantText: "Fresh", wantCalls: 1},
+	} {
+		// Act
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/tweet/user/123"+tc.query, nil))
+		if err != nil {
+			t.Fatalf("app.Test() error = %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		// Assert
+		var got struct {
+			Text string `json:"text"`
+		}
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("invalid JSON %q: %v", body, err)
+		}
+		if got.Text != tc.wantText {
+			t.Errorf("query %q: text = %q, want %q", tc.query, got.Text, tc.wantText)
+		}
+		if scraper.calls != tc.wantCalls {
+			t.Errorf("query %q: scraper calls = %d, want %d", tc.query, scraper.calls, tc.wantCalls)
+		}
+	}
+}
+
+func TestAPIGetTweetsBatch_MixedURLs_ReturnsResultsInOrder(t *testing.T) {
+	// Arrange
+	scraper := &countingScraper{text: "Batch"}
+	getTweetUC := usecases.NewGetTweetUseCase(cache.NewMemoryCache(time.Minute), usecases.NewScrapeTweetUseCase(scraper))
+	app := fiber.New()
+	app.Post("/api/v1/tweets", NewHandlers(getTweetUC, usecases.NewBatchGetTweetsUseCase(getTweetUC, 1)).APIGetTweetsBatch)
+
+	body := `{"urls":["https://x.com/a/status/1","not a url","https://x.com/b/status/2"]}`
+	req := httptest.NewRequest("POST", "/api/v1/tweets", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+
+	// Assert
+	var got struct {
+		Results []struct {
+			URL   string `json:"url"`
+			Tweet *struct {
+				ID string `json:"id"`
+			} `json:"tweet"`
+			Error string `json:"error"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", data, err)
+	}
+	if len(got.Results) != 3 {
+		t.Fatalf("results: got %d, want 3", len(got.Results))
+	}
+	if got.Results[0].Tweet == nil || got.Results[0].Tweet.ID != "1" {
+		t.Errorf("result 0: got %+v, want tweet 1", got.Results[0])
+	}
+	if got.Results[1].Tweet != nil || got.Results[1].Error == "" {
+		t.Errorf("result 1: expected error for invalid URL, got %+v", got.Results[1])
+	}
+	if got.Results[2].Tweet == nil || got.Results[2].Tweet.ID != "2" {
+		t.Errorf("result 2: got %+v, want tweet 2", got.Results[2])
+	}
+	if scraper.calls != 2 {
+		t.Errorf("scraper calls: got %d, want 2", scraper.calls)
+	}
+}
+
+func TestAPIGetTweetsBatch_TooManyURLs_ReturnsBadRequest(t *testing.T) {
+	getTweetUC := usecases.NewGetTweetUseCase(cache.NewMemoryCache(time.Minute), usecases.NewScrapeTweetUseCase(&countingScraper{}))
+	app := fiber.New()
+	app.Post("/api/v1/tweets", NewHandlers(getTweetUC, usecases.NewBatchGetTweetsUseCase(getTweetUC, 1)).APIGetTweetsBatch)
+
+	urls := make([]string, maxBatchSize+1)
+	for i := range urls {
+		urls[i] = `"https://x.com/a/status/1"`
+	}
+	req := httptest.NewRequest("POST", "/api/v1/tweets", strings.NewReader(`{"urls":[`+strings.Join(urls, ",")+`]}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("status: got %d, want 400", resp.StatusCode)
+	}
+}
+
+func setupWarmApp(token string) (*fiber.App, *cache.MemoryCache, *countingScraper) {
+	tweetCache := cache.NewMemoryCache(time.Minute)
+	scraper := &countingScraper{text: "Warm"}
+	getTweetUC := usecases.NewGetTweetUseCase(tweetCache, usecases.NewScrapeTweetUseCase(scraper))
+	h := NewHandlers(getTweetUC, usecases.NewBatchGetTweetsUseCase(getTweetUC, 1))
+	h.SetAdminToken(token)
+
+	app := fiber.New()
+	app.Post("/admin/warm", h.RequireAdmin, h.AdminWarm)
+	return app, tweetCache, scraper
+}
+
+func postWarm(t *testing.T, app *fiber.App, auth, body string) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/admin/warm", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	return resp
+}
+
+func TestAdminWarm_ValidToken_CachesEveryURL(t *testing.T) {
+	// Arrange
+	app, tweetCache, scraper := setupWarmApp("secret")
+	body := `{"urls":["https://x.com/a/status/1","https://x.com/b/status/2","bad"]}`
+
+	// Act
+	resp := postWarm(t, app, "Bearer secret", body)
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+
+	// Assert
+	var got struct {
+		Warmed int `json:"warmed"`
+		Failed int `json:"failed"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", data, err)
+	}
+	if got.Warmed != 2 || got.Failed != 1 {
+		t.Errorf("warmed/failed: got %d/%d, want 2/1", got.Warmed, got.Failed)
+	}
+	if _, found := tweetCache.Get("a", "1"); !found {
+		t.Error("tweet a/1 not cached")
+	}
+	if _, found := tweetCache.Get("b", "2"); !found {
+		t.Error("tweet b/2 not cached")
+	}
+	if scraper.calls != 2 {
+		t.Errorf("scraper calls: got %d, want 2", scraper.calls)
+	}
+}
+
+func TestAdminWarm_Auth(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		token      string
+		auth       string
+		wantStatus int
+	}{
+		{name: "missing header", token: "secret", auth: "", wantStatus: fiber.StatusUnauthorized},
+		{name: "wrong token", token: "secret", auth: "Bearer nope", wantStatus: fiber.StatusUnauthorized},
+		{name: "disabled", token: "", auth: "Bearer secret", wantStatus: fiber.StatusNotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			app, _, scraper := setupWarmApp(tc.token)
+
+			resp := postWarm(t, app, tc.auth, `{"urls":["https://x.com/a/status/1"]}`)
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Errorf("status: got %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+			if scraper.calls != 0 {
+				t.Errorf("scraper calls: got %d, want 0", scraper.calls)
+			}
+		})
+	}
+}
+
+func TestStatusForError_SensitiveContent_IsForbidden(t *testing.T) {
+	// Arrange
+	h := &Handlers{}
+
+	// Act
+	status := statusForError(domain.ErrSensitiveContent)
+	message := h.friendlyError(domain.ErrSensitiveContent)
+
+	// Assert
+	if status != fiber.StatusForbidden {
+		t.Errorf("status: got %d, want %d", status, fiber.StatusForbidden)
+	}
+	if !strings.Contains(message, "sensitive") {
+		t.Errorf("message: got %q, want a sensitive content explanation", message)
+	}
+}
+
+func TestStatusForError_BrowserUnavailable_IsServiceUnavailable(t *testing.T) {
+	// Arrange
+	h := &Handlers{}
+
+	// Act
+	status := statusForError(domain.ErrBrowserUnavailable)
+	message := h.friendlyError(domain.ErrBrowserUnavailable)
+
+	// Assert
+	if status != fiber.StatusServiceUnavailable {
+		t.Errorf("status: got %d, want %d", status, fiber.StatusServiceUnavailable)
+	}
+	if !strings.Contains(message, "try again") {
+		t.Errorf("message: got %q, want a retry hint", message)
+	}
+}
+
+func TestAPIGetTweet_DisplayTimezone_RendersLocalTime(t *testing.T) {
+	// Arrange
+	tweetCache := cache.NewMemoryCache(time.Minute)
+	tweetCache.Set("user", "123", &domain.Tweet{ID: "123", Content: domain.Content{
+		Text:      "Dated",
+		CreatedAt: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+	}})
+	getTweetUC := usecases.NewGetTweetUseCase(tweetCache, usecases.NewScrapeTweetUseCase(&countingScraper{}))
+	handlers := NewHandlers(getTweetUC, usecases.NewBatchGetTweetsUseCase(getTweetUC, 1))
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	handlers.SetDisplayTimezone(saoPaulo)
+	app := fiber.New()
+	app.Get("/api/tweet/:username/:id", handlers.APIGetTweet)
+
+	for _, tc := range []struct {
+		name         string
+		query        string
+		header       string
+		wantDatetime string
+		wantDisplay  string
+	}{
+		{name: "configured", wantDatetime: "2026-01-01T09:00:00-03:00", wantDisplay: "9:00 AM"},
+		{name: "query", query: "?tz=Asia/Tokyo", wantDatetime: "2026-01-01T21:00:00+09:00", wantDisplay: "9:00 PM"},
+		{name: "header", header: "UTC", wantDatetime: "2026-01-01T12:00:00Z", wantDisplay: "12:00 PM"},
+		{name: "invalid falls back", query: "?tz=Mars/Olympus", wantDatetime: "2026-01-01T09:00:00-03:00", wantDisplay: "9:00 AM"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/tweet/user/123"+tc.query, nil)
+			if tc.header != "" {
+				req.Header.Set(TimezoneHeader, tc.header)
+			}
+
+			// Act
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("app.Test() error = %v", err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+
+			// Assert
+			if !strings.Contains(string(body), `datetime="`+tc.wantDatetime+`"`) {
+				t.Errorf("datetime: want %q in %s", tc.wantDatetime, body)
+			}
+			if !strings.Contains(string(body), tc.wantDisplay) {
+				t.Errorf("display: want %q in %s", tc.wantDisplay, body)
+			}
+		})
+	}
+}
+
+func TestViewTweet_CachedTweet_EmbedsJSONLD(t *testing.T) {
+	// Arrange
+	tweetCache := cache.NewMemoryCache(time.Minute)
+	tweetCache.Set("johndoe", "123", &domain.Tweet{
+		ID:     "123",
+		URL:    "https://x.com/johndoe/status/123",
+		Author: domain.Author{Name: "John Doe", Handle: "johndoe"},
+		Content: domain.Content{
+			Text:      "Read [[LINK:https://example.com/a]] </script>",
+			CreatedAt: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+		},
+	})
+	getTweetUC := usecases.NewGetTweetUseCase(tweetCache, usecases.NewScrapeTweetUseCase(&countingScraper{}))
+	app := fiber.New()
+	app.Get("/:username/status/:id", NewHandlers(getTweetUC, usecases.NewBatchGetTweetsUseCase(getTweetUC, 1)).ViewTweet)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest("GET", "/johndoe/status/123", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	// Assert
+	_, script, found := strings.Cut(string(body), `<script id="tweet-jsonld" type="application/ld+json">`)
+	if !found {
+		t.Fatalf("no JSON-LD script in %s", body)
+	}
+	script, _, _ = strings.Cut(script, "</script>")
+	var got struct {
+		Context       string `json:"@context"`
+		Type          string `json:"@type"`
+		ArticleBody   string `json:"articleBody"`
+		DatePublished string `json:"datePublished"`
+		Author        struct {
+			Type          string `json:"@type"`
+			Name          string `json:"name"`
+			AlternateName string `json:"alternateName"`
+		} `json:"author"`
+	}
+	if err := json.Unmarshal([]byte(script), &got); err != nil {
+		t.Fatalf("invalid JSON-LD %q: %v", script, err)
+	}
+	if got.Context != "https://schema.org" || got.Type != "SocialMediaPosting" {
+		t.Errorf("type: got %q %q", got.Context, got.Type)
+	}
+	if got.ArticleBody != "Read https://example.com/a </script>" {
+		t.Errorf("articleBody: got %q", got.ArticleBody)
+	}
+	if got.DatePublished != "2026-01-01T12:00:00Z" {
+		t.Errorf("datePublished: got %q", got.DatePublished)
+	}
+	if got.Author.Type != "Person" || got.Author.Name != "John Doe" || got.Author.AlternateName != "@johndoe" {
+		t.Errorf("author: got %+v", got.Author)
+	}
+}
+
+func TestViewTweet_PhotoDeepLink_PassesIndexToPage(t *testing.T) {
+	// Arrange
+	getTweetUC := usecases.NewGetTweetUseCase(cache.NewMemoryCache(time.Minute), usecases.NewScrapeTweetUseCase(&countingScraper{}))
+	h := NewHandlers(getTweetUC, usecases.NewBatchGetTweetsUseCase(getTweetUC, 1))
+	app := fiber.New()
+	app.Get("/:username/status/:id", h.ViewTweet)
+	app.Get("/:username/status/:id/photo/:index", h.ViewTweet)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest("GET", "/johndoe/status/123/photo/3", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	// Assert
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if !strings.Contains(string(body), `data-photo="3"`) {
+		t.Errorf("expected photo index 3 on the page, got: %s", body)
+	}
+	if !strings.Contains(string(body), `hx-get="/api/tweet/johndoe/123"`) {
+		t.Errorf("expected the tweet to load from its canonical endpoint, got: %s", body)
+	}
+}
+
+func TestViewTweet_NotCached_NoJSONLDAndNoScrape(t *testing.T) {
+	// Arrange
+	scraper := &countingScraper{}
+	getTweetUC := usecases.NewGetTweetUseCase(cache.NewMemoryCache(time.Minute), usecases.NewScrapeTweetUseCase(scraper))
+	app := fiber.New()
+	app.Get("/:username/status/:id", NewHandlers(getTweetUC, usecases.NewBatchGetTweetsUseCase(getTweetUC, 1)).ViewTweet)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest("GET", "/johndoe/status/123", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	// Assert
+	if strings.Contains(string(body), "application/ld+json") {
+		t.Error("expected no JSON-LD for an uncached tweet")
+	}
+	if scraper.calls != 0 {
+		t.Errorf("scraper calls: got %d, want 0", scraper.calls)
+	}
+}
@@ -0,0 +1,53 @@
+package web
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BrowserPinger checks that the headless browser responds.
+type BrowserPinger interface {
+	Ping(ctx context.Context) error
+}
+
+// UpstreamReporter reports whether Twitter is serving tweets ("ok", "blocked" or "unknown").
+type UpstreamReporter interface {
+	UpstreamStatus() string
+}
+
+// readinessResponse is the JSON body of /readyz.
+type readinessResponse struct {
+	Browser  string `json:"browser"`
+	Upstream string `json:"upstream"`
+}
+
+// SetHealthChecks sets the browser and upstream checks used by Readyz.
+func (h *Handlers) SetHealthChecks(browser BrowserPinger, upstream UpstreamReporter) {
+	h.browser = browser
+	h.upstream = upstream
+}
+
+// Readyz reports browser and upstream health separately. Only a broken
+// browser makes the instance unready (503): when Twitter blocks us, cached
+// tweets can still be served.
+func (h *Handlers) Readyz(c *fiber.Ctx) error {
+	resp := readinessResponse{Browser: "ok", Upstream: "unknown"}
+
+	if h.browser != nil {
+		ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+		defer cancel()
+		if err := h.browser.Ping(ctx); err != nil {
+			resp.Browser = "down"
+		}
+	}
+	if h.upstream != nil {
+		resp.Upstream = h.upstream.UpstreamStatus()
+	}
+
+	if resp.Browser != "ok" {
+		c.Status(fiber.StatusServiceUnavailable)
+	}
+	return c.JSON(resp)
+}
@@ -0,0 +1,90 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type fakePinger struct{ err error }
+
+func (p fakePinger) Ping(ctx context.Context) error { return p.err }
+
+type fakeUpstream string
+
+func (u fakeUpstream) UpstreamStatus() string { return string(u) }
+
+func getReadyz(t *testing.T, h *Handlers) (int, readinessResponse) {
+	t.Helper()
+	app := fiber.New()
+	app.Get("/readyz", h.Readyz)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/readyz", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	var got readinessResponse
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", body, err)
+	}
+	return resp.StatusCode, got
+}
+
+func TestReadyz_BrowserUp_ReturnsOK(t *testing.T) {
+	// Arrange
+	h := NewHandlers(nil, nil)
+	h.SetHealthChecks(fakePinger{}, fakeUpstream("unknown"))
+
+	// Act
+	status, got := getReadyz(t, h)
+
+	// Assert
+	if status != fiber.StatusOK {
+		t.Errorf("status: got %d, want 200", status)
+	}
+	if got.Browser != "ok" || got.Upstream != "unknown" {
+		t.Errorf("body: got %+v, want browser=ok upstream=unknown", got)
+	}
+}
+
+func TestReadyz_BrowserDown_ReturnsUnavailable(t *testing.T) {
+	// Arrange
+	h := NewHandlers(nil, nil)
+	h.SetHealthChecks(fakePinger{err: errors.New("navigation failed")}, fakeUpstream("ok"))
+
+	// Act
+	status, got := getReadyz(t, h)
+
+	// Assert
+	if status != fiber.StatusServiceUnavailable {
+		t.Errorf("status: got %d, want 503", status)
+	}
+	if got.Browser != "down" || got.Upstream != "ok" {
+		t.Errorf("body: got %+v, want browser=down upstream=ok", got)
+	}
+}
+
+func TestReadyz_UpstreamBlocked_StaysReady(t *testing.T) {
+	// Arrange
+	h := NewHandlers(nil, nil)
+	h.SetHealthChecks(fakePinger{}, fakeUpstream("blocked"))
+
+	// Act
+	status, got := getReadyz(t, h)
+
+	// Assert
+	if status != fiber.StatusOK {
+		t.Errorf("status: got %d, want 200", status)
+	}
+	if got.Upstream != "blocked" {
+		t.Errorf("upstream: got %q, want blocked", got.Upstream)
+	}
+}
@@ -0,0 +1,160 @@
+package web
+
+import (
+	"time"
+
+	"sumariza-ai/internal/domain"
+)
+
+// tweetResponse is the JSON representation of a tweet returned by the API.
+type tweetResponse struct {
+	ID             string               `json:"id"`
+	URL            string               `json:"url"`
+	Username       string               `json:"username"`
+	Author         authorResponse       `json:"author"`
+	Text           string               `json:"text"`
+	Tokens         []tokenResponse      `json:"tokens,omitempty"`
+	Media          []mediaResponse      `json:"media,omitempty"`
+	CreatedAt      *time.Time           `json:"created_at,omitempty"`
+	Direction      string               `json:"direction"`
+	Truncated      bool                 `json:"truncated,omitempty"`
+	QuotedTweet    *quotedTweetResponse `json:"quoted_tweet,omitempty"`
+	InReplyToID    string               `json:"in_reply_to_id,omitempty"`
+	InReplyToURL   string               `json:"in_reply_to_url,omitempty"`
+	Replies        []replyResponse      `json:"replies,omitempty"`
+	Metrics        metricsResponse      `json:"metrics"`
+	Partial        bool                 `json:"partial"`
+	PartialReasons []string             `json:"partial_reasons,omitempty"`
+	ContentHash    string               `json:"content_hash"`
+}
+
+// tokenResponse is the JSON representation of a tweet text token.
+type tokenResponse struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+// mediaResponse is the JSON representation of a photo, video or GIF.
+type mediaResponse struct {
+	Type      string `json:"type"`
+	URL       string `json:"url,omitempty"`
+	PosterURL string `json:"poster_url,omitempty"`
+	AltText   string `json:"alt_text,omitempty"`
+	Width     int    `json:"width,omitempty"`
+	Height    int    `json:"height,omitempty"`
+}
+
+// metricsResponse is the JSON representation of tweet engagement counts.
+type metricsResponse struct {
+	Views     int64 `json:"views"`
+	Bookmarks int64 `json:"bookmarks"`
+}
+
+// authorResponse is the JSON representation of a tweet author.
+type authorResponse struct {
+	Name         string `json:"name"`
+	Handle       string `json:"handle"`
+	AvatarURL    string `json:"avatar_url,omitempty"`
+	Verified     bool   `json:"verified"`
+	VerifiedType string `json:"verified_type,omitempty"`
+}
+
+// quotedTweetResponse is the JSON representation of a quoted tweet.
+type quotedTweetResponse struct {
+	ID       string         `json:"id,omitempty"`
+	URL      string         `json:"url,omitempty"`
+	Author   authorResponse `json:"author"`
+	Text     string         `json:"text"`
+	HasMedia bool           `json:"has_media"`
+
+	// Unavailable is set when the quoted tweet was deleted or is private;
+	// the other fields are then empty.
+	Unavailable bool `json:"unavailable,omitempty"`
+}
+
+// replyResponse is the JSON representation of a reply to a tweet.
+type replyResponse struct {
+	ID     string         `json:"id,omitempty"`
+	Author authorResponse `json:"author"`
+	Text   string         `json:"text"`
+}
+
+// TweetJSON returns the JSON representation of a tweet served by the API,
+// for other front ends such as the CLI.
+func TweetJSON(tweet *domain.Tweet) any {
+	return newTweetResponse(tweet)
+}
+
+// newTweetResponse converts a domain tweet to its JSON representation.
+func newTweetResponse(tweet *domain.Tweet) tweetResponse {
+	resp := tweetResponse{
+		ID:        tweet.ID,
+		URL:       tweet.URL,
+		Username:  tweet.Username,
+		Author:    newAuthorResponse(tweet.Author),
+		Text:      tweet.Content.Text,
+		Direction: string(tweet.Content.Direction),
+		Truncated: tweet.Content.Truncated,
+		Metrics: metricsResponse{
+			Views:     tweet.Metrics.Views,
+			Bookmarks: tweet.Metrics.Bookmarks,
+		},
+		InReplyToID:    tweet.Content.InReplyToID,
+		InReplyToURL:   tweet.Content.InReplyToURL,
+		Partial:        tweet.Partial,
+		PartialReasons: tweet.PartialReasons,
+		ContentHash:    tweet.ContentHash(),
+	}
+
+	if !tweet.Content.CreatedAt.IsZero() {
+		createdAt := tweet.Content.CreatedAt.UTC()
+		resp.CreatedAt = &createdAt
+	}
+
+	if quoted := tweet.Content.QuotedTweet; quoted != nil {
+		resp.QuotedTweet = &quotedTweetResponse{
+			ID:          quoted.ID,
+			URL:         quoted.URL,
+			Author:      newAuthorResponse(quoted.Author),
+			Text:        quoted.Text,
+			HasMedia:    quoted.HasMedia,
+			Unavailable: quoted.Unavailable,
+		}
+	}
+
+	for _, token := range tweet.Content.Tokens {
+		resp.Tokens = append(resp.Tokens, tokenResponse{Kind: string(token.Kind), Value: token.Value})
+	}
+
+	for _, item := range tweet.Content.Media {
+		resp.Media = append(resp.Media, mediaResponse{
+			Type:      string(item.Type),
+			URL:       item.URL,
+			PosterURL: item.PosterURL,
+			AltText:   item.AltText,
+			Width:     item.Width,
+			Height:    item.Height,
+		})
+	}
+
+	for _, reply := range tweet.Content.Replies {
+		resp.Replies = append(resp.Replies, replyResponse{
+			ID:     reply.ID,
+			Author: newAuthorResponse(reply.Author),
+			Text:   reply.Text,
+		})
+	}
+
+	return resp
+}
+
+// newAuthorResponse converts a domain author to its JSON representation.
+func newAuthorResponse(author domain.Author) authorResponse {
+	return authorResponse{
+		Name:         author.Name,
+		Handle:       author.Handle,
+		AvatarURL:    author.AvatarURL,
+		Verified:     author.Verified,
+		VerifiedType: string(author.VerifiedType),
+	}
+}
@@ -1,41 +1,132 @@
 package web
 
 import (
+	"container/list"
+	"fmt"
+	"runtime/debug"
+	"strings"
 	"sync"
 	"time"
 
+	"sumariza-ai/pkg/clock"
 	"sumariza-ai/pkg/log"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
 )
 
+const (
+	// defaultMaxTrackedIPs bounds the IPs a RateLimiter remembers, so a flood
+	// of spoofed addresses can't grow its map without limit.
+	defaultMaxTrackedIPs = 10000
+
+	// defaultCleanupInterval is how often stale IP entries are dropped.
+	defaultCleanupInterval = 5 * time.Minute
+)
+
 // RateLimiter tracks scrape requests per IP.
+// At most maxIPs addresses are tracked; recording a new one past the bound
+// evicts the address that scraped least recently.
 type RateLimiter struct {
-	scrapes map[string][]time.Time
-	mu      sync.RWMutex
-	limit   int
-	window  time.Duration
+	scrapes   map[string]*list.Element // values are *ipScrapes
+	recency   *list.List               // front is the most recent scraper
+	mu        sync.RWMutex
+	limit     int
+	window    time.Duration
+	algorithm RateAlgorithm
+	maxIPs    int
+	clock     clock.Clock
+	ticker    *time.Ticker
+}
+
+// ipScrapes holds the scrapes of one IP.
+type ipScrapes struct {
+	ip      string
+	counter scrapeCounter
 }
 
 // NewRateLimiter creates a new rate limiter.
 func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
 	rl := &RateLimiter{
-		scrapes: make(map[string][]time.Time),
+		scrapes: make(map[string]*list.Element),
+		recency: list.New(),
 		limit:   limit,
 		window:  window,
+		maxIPs:  defaultMaxTrackedIPs,
+		clock:   clock.Real(),
+		ticker:  time.NewTicker(defaultCleanupInterval),
 	}
 	go rl.cleanup()
 	return rl
 }
 
+// SetMaxTrackedIPs bounds how many IPs are tracked (default 10000).
+// Zero or negative keeps the default. Excess entries are evicted at once.
+func (rl *RateLimiter) SetMaxTrackedIPs(n int) {
+	if n <= 0 {
+		n = defaultMaxTrackedIPs
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.maxIPs = n
+	rl.evictLocked()
+}
+
+// SetAlgorithm selects how scrapes are counted (default RateTimestamps).
+// Scrapes recorded so far are forgotten.
+func (rl *RateLimiter) SetAlgorithm(algorithm RateAlgorithm) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.algorithm = algorithm
+	rl.scrapes = make(map[string]*list.Element)
+	rl.recency.Init()
+}
+
+// SetCleanupInterval sets how often stale IP entries are removed
+// (default 5 minutes). Zero or negative keeps the current interval.
+func (rl *RateLimiter) SetCleanupInterval(d time.Duration) {
+	if d > 0 {
+		rl.ticker.Reset(d)
+	}
+}
+
+// SetClock replaces the clock used to age scrape records (for tests).
+func (rl *RateLimiter) SetClock(c clock.Clock) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.clock = c
+}
+
 // RecordScrape records a scrape request for the given IP.
 func (rl *RateLimiter) RecordScrape(ip string) {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	now := time.Now()
-	rl.scrapes[ip] = append(rl.scrapes[ip], now)
+	now := rl.clock.Now()
+	if elem, ok := rl.scrapes[ip]; ok {
+		elem.Value.(*ipScrapes).counter.add(now)
+		rl.recency.MoveToFront(elem)
+		return
+	}
+
+	counter := newScrapeCounter(rl.algorithm, rl.window)
+	counter.add(now)
+	rl.scrapes[ip] = rl.recency.PushFront(&ipScrapes{ip: ip, counter: counter})
+	rl.evictLocked()
+}
+
+// evictLocked drops the least recently seen IPs beyond maxIPs.
+// The caller must hold rl.mu.
+func (rl *RateLimiter) evictLocked() {
+	for len(rl.scrapes) > rl.maxIPs {
+		oldest := rl.recency.Back()
+		rl.recency.Remove(oldest)
+		delete(rl.scrapes, oldest.Value.(*ipScrapes).ip)
+	}
 }
 
 // CanScrape checks if the IP is allowed to make another scrape.
@@ -43,20 +134,12 @@ func (rl *RateLimiter) CanScrape(ip string) bool {
 	rl.mu.RLock()
 	defer rl.mu.RUnlock()
 
-	now := time.Now()
-	cutoff := now.Add(-rl.window)
-
-	timestamps := rl.scrapes[ip]
-
-	// Count recent scrapes
-	var recent int
-	for _, t := range timestamps {
-		if t.After(cutoff) {
-			recent++
-		}
+	elem, ok := rl.scrapes[ip]
+	if !ok {
+		return rl.limit > 0
 	}
 
-	return recent < rl.limit
+	return elem.Value.(*ipScrapes).counter.count(rl.clock.Now()) < rl.limit
 }
 
 // Middleware returns a Fiber middleware for rate limiting.
@@ -70,24 +153,22 @@ func (rl *RateLimiter) Middleware() fiber.Handler {
 
 // cleanup periodically removes old entries from the rate limiter.
 func (rl *RateLimiter) cleanup() {
-	ticker := time.NewTicker(5 * time.Minute)
-	for range ticker.C {
-		rl.mu.Lock()
-		cutoff := time.Now().Add(-rl.window)
-		for ip, timestamps := range rl.scrapes {
-			var recent []time.Time
-			for _, t := range timestamps {
-				if t.After(cutoff) {
-					recent = append(recent, t)
-				}
-			}
-			if len(recent) == 0 {
-				delete(rl.scrapes, ip)
-			} else {
-				rl.scrapes[ip] = recent
-			}
+	for range rl.ticker.C {
+		rl.removeStale()
+	}
+}
+
+// removeStale drops scrapes outside the window, and IPs left without any.
+func (rl *RateLimiter) removeStale() {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := rl.clock.Now()
+	for ip, elem := range rl.scrapes {
+		if elem.Value.(*ipScrapes).counter.expire(now) {
+			rl.recency.Remove(elem)
+			delete(rl.scrapes, ip)
 		}
-		rl.mu.Unlock()
 	}
 }
 
@@ -122,6 +203,7 @@ func RequestIDToContextMiddleware() fiber.Handler {
 func RequestLoggerMiddleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		start := time.Now()
+		self := c.Route()
 
 		// Process request
 		err := c.Next()
@@ -132,15 +214,20 @@ func RequestLoggerMiddleware() fiber.Handler {
 		// Get status code
 		status := c.Response().StatusCode()
 
-		// Determine log level based on status
+		// Determine log level based on status. Values read from the request
+		// point into buffers fasthttp reuses once the handler returns, so
+		// they are cloned before reaching the async logger.
 		ctx := c.UserContext()
 		fields := []any{
 			"method", c.Method(),
-			"path", c.Path(),
+			"path", strings.Clone(c.Path()),
+			"route", routePattern(c, self),
 			"status", status,
 			"latency_ms", latency.Milliseconds(),
-			"ip", c.IP(),
-			"user_agent", c.Get("User-Agent"),
+			"bytes_out", responseSize(c),
+			"ip", strings.Clone(c.IP()),
+			"user_agent", strings.Clone(c.Get(fiber.HeaderUserAgent)),
+			"referer", strings.Clone(c.Get(fiber.HeaderReferer)),
 		}
 
 		// Add error if present
@@ -161,3 +248,56 @@ func RequestLoggerMiddleware() fiber.Handler {
 		return err
 	}
 }
+
+// internalErrorMessage is the friendly message returned when a handler panics.
+const internalErrorMessage = "Something went wrong on our side. Please try again in a moment."
+
+// RecoverMiddleware turns a handler panic into a 500 with a friendly message,
+// logging the panic value and stack with the request's context.
+// Replaces Fiber's recover middleware. Must be used AFTER
+// RequestLoggerMiddleware, so the 500 is logged as a request too.
+func RecoverMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			log.GlobalErrorCtx(c.UserContext(), "handler panic recovered",
+				"panic", fmt.Sprint(r),
+				"stack", string(debug.Stack()),
+				"method", c.Method(),
+				"path", c.Path())
+			err = c.Status(fiber.StatusInternalServerError).SendString(internalErrorMessage)
+		}()
+		return c.Next()
+	}
+}
+
+// unmatchedRoute is the route label for requests that matched no handler.
+const unmatchedRoute = "unmatched"
+
+// routePattern returns the matched route template (e.g. /:username/status/:id)
+// so logs aggregate by route instead of by concrete path. If the request never
+// left the middleware's own route, or only reached the NotFound catch-all, no
+// handler matched and unmatchedRoute is used.
+func routePattern(c *fiber.Ctx, middleware *fiber.Route) string {
+	route := c.Route()
+	if route == middleware || c.Locals(unmatchedRoute) != nil {
+		return unmatchedRoute
+	}
+	return route.Path
+}
+
+// responseSize returns the response body size in bytes.
+// Streamed bodies (static files, SSE) are sized from Content-Length when known.
+func responseSize(c *fiber.Ctx) int {
+	resp := c.Response()
+	if resp.IsBodyStream() {
+		if size := resp.Header.ContentLength(); size > 0 {
+			return size
+		}
+		return 0
+	}
+	return len(resp.Body())
+}
@@ -2,11 +2,14 @@ package web
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"sumariza-ai/pkg/clock"
 	"sumariza-ai/pkg/log"
 	"sumariza-ai/pkg/log/transporters"
 
@@ -189,3 +192,230 @@ func TestRequestLoggerMiddleware_Logs500AsError(t *testing.T) {
 		t.Errorf("5xx status should be logged as ERROR, got: %s", output)
 	}
 }
+
+func TestRequestLoggerMiddleware_LogsSizeRefererAndRoute(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New(log.Info, transporters.NewStdoutWithWriter(&buf))
+	log.SetDefault(logger)
+	defer logger.Close()
+
+	app := fiber.New()
+	app.Use(requestid.New(RequestIDConfig()))
+	app.Use(RequestIDToContextMiddleware())
+	app.Use(RequestLoggerMiddleware())
+	app.Get("/:username/status/:id", func(c *fiber.Ctx) error {
+		return c.SendString("hello")
+	})
+
+	req := httptest.NewRequest("GET", "/jack/status/20", nil)
+	req.Header.Set("Referer", "https://example.com/page")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	logger.Close()
+	output := buf.String()
+
+	if !strings.Contains(output, `"bytes_out":5`) {
+		t.Errorf("log should contain bytes_out 5, got: %s", output)
+	}
+	if !strings.Contains(output, `"referer":"https://example.com/page"`) {
+		t.Errorf("log should contain referer, got: %s", output)
+	}
+	if !strings.Contains(output, `"route":"/:username/status/:id"`) {
+		t.Errorf("log should contain route template, got: %s", output)
+	}
+}
+
+func TestRequestLoggerMiddleware_RouteField_UsesPatternNotPath(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New(log.Info, transporters.NewStdoutWithWriter(&buf))
+	log.SetDefault(logger)
+	defer logger.Close()
+
+	app := fiber.New()
+	app.Use(RequestLoggerMiddleware())
+	app.Get("/:username/status/:id", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	for _, path := range []string{"/jack/status/20", "/elonmusk/status/1234567890", "/nope"} {
+		resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+		if err != nil {
+			t.Fatalf("app.Test() error = %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	logger.Close()
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 log lines, got %d: %s", len(lines), buf.String())
+	}
+
+	for i, path := range []string{"/jack/status/20", "/elonmusk/status/1234567890"} {
+		if !strings.Contains(lines[i], `"route":"/:username/status/:id"`) {
+			t.Errorf("line %d should use route pattern, got: %s", i, lines[i])
+		}
+		if !strings.Contains(lines[i], `"path":"`+path+`"`) {
+			t.Errorf("line %d should keep concrete path %s, got: %s", i, path, lines[i])
+		}
+	}
+	if !strings.Contains(lines[2], `"route":"unmatched"`) {
+		t.Errorf("unmatched request should use route 'unmatched', got: %s", lines[2])
+	}
+}
+
+func TestRecoverMiddleware_Panic_LogsContextAndReturns500(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := log.New(log.Info, transporters.NewStdoutWithWriter(&buf))
+	log.SetDefault(logger)
+	defer logger.Close()
+
+	app := setupTestApp()
+	app.Use(RecoverMiddleware())
+	app.Get("/boom", func(c *fiber.Ctx) error {
+		panic("nil map write")
+	})
+
+	req := httptest.NewRequest("GET", "/boom", nil)
+	req.Header.Set("X-Request-ID", "panic-req-42")
+
+	// Act
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	logger.Close()
+
+	// Assert
+	if resp.StatusCode != fiber.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", resp.StatusCode)
+	}
+	if string(body) != internalErrorMessage {
+		t.Errorf("body = %q, want the friendly message", body)
+	}
+	output := buf.String()
+	for _, want := range []string{"ERROR", "handler panic recovered", "panic-req-42", "nil map write", "/boom", "GET", "goroutine"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("log should contain %q, got: %s", want, output)
+		}
+	}
+}
+
+func TestRateLimiter_WindowExpiry_AllowsScrapeAgain(t *testing.T) {
+	// Arrange
+	rl := NewRateLimiter(2, time.Minute)
+	fake := clock.NewFake(time.Now())
+	rl.SetClock(fake)
+	rl.RecordScrape("1.2.3.4")
+	rl.RecordScrape("1.2.3.4")
+
+	// Act & Assert
+	if rl.CanScrape("1.2.3.4") {
+		t.Error("expected limit to be reached")
+	}
+	fake.Advance(time.Minute + time.Second)
+	if !rl.CanScrape("1.2.3.4") {
+		t.Error("expected scrape to be allowed once the window passed")
+	}
+}
+
+// trackedIPs returns how many IPs the limiter currently tracks.
+func (rl *RateLimiter) trackedIPs() int {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+
+	return len(rl.scrapes)
+}
+
+func TestRateLimiter_MaxTrackedIPs_EvictsLeastRecentlySeen(t *testing.T) {
+	// Arrange
+	rl := NewRateLimiter(1, time.Minute)
+	rl.SetMaxTrackedIPs(3)
+	rl.RecordScrape("10.0.0.1")
+	rl.RecordScrape("10.0.0.2")
+	rl.RecordScrape("10.0.0.3")
+	rl.RecordScrape("10.0.0.1") // 10.0.0.2 is now the least recent
+
+	// Act
+	rl.RecordScrape("10.0.0.4")
+	rl.RecordScrape("10.0.0.5")
+
+	// Assert
+	if got := rl.trackedIPs(); got != 3 {
+		t.Errorf("tracked IPs: got %d, want 3", got)
+	}
+	if !rl.CanScrape("10.0.0.2") || !rl.CanScrape("10.0.0.3") {
+		t.Error("expected the least recently seen IPs to be evicted")
+	}
+	for _, ip := range []string{"10.0.0.1", "10.0.0.4", "10.0.0.5"} {
+		if rl.CanScrape(ip) {
+			t.Errorf("expected %s to still be tracked and limited", ip)
+		}
+	}
+}
+
+func TestRateLimiter_Flood_StaysWithinBound(t *testing.T) {
+	// Arrange
+	rl := NewRateLimiter(10, time.Minute)
+	rl.SetMaxTrackedIPs(100)
+
+	// Act
+	for i := 0; i < 10000; i++ {
+		rl.RecordScrape(fmt.Sprintf("203.0.%d.%d", i/256, i%256))
+	}
+
+	// Assert
+	if got := rl.trackedIPs(); got != 100 {
+		t.Errorf("tracked IPs: got %d, want 100", got)
+	}
+}
+
+func TestRateLimiter_RemoveStale_DropsExpiredEntries(t *testing.T) {
+	// Arrange
+	rl := NewRateLimiter(2, time.Minute)
+	fake := clock.NewFake(time.Now())
+	rl.SetClock(fake)
+	rl.RecordScrape("1.1.1.1")
+	fake.Advance(45 * time.Second)
+	rl.RecordScrape("2.2.2.2")
+	fake.Advance(30 * time.Second)
+
+	// Act
+	rl.removeStale()
+
+	// Assert
+	if got := rl.trackedIPs(); got != 1 {
+		t.Errorf("tracked IPs: got %d, want 1", got)
+	}
+	rl.RecordScrape("2.2.2.2")
+	if rl.CanScrape("2.2.2.2") {
+		t.Error("expected the recent scrape of 2.2.2.2 to be kept")
+	}
+}
+
+func TestRateLimiter_SetCleanupInterval_RunsCleanup(t *testing.T) {
+	// Arrange
+	rl := NewRateLimiter(1, time.Millisecond)
+	rl.RecordScrape("1.1.1.1")
+	time.Sleep(5 * time.Millisecond)
+
+	// Act
+	rl.SetCleanupInterval(10 * time.Millisecond)
+
+	// Assert
+	deadline := time.Now().Add(time.Second)
+	for rl.trackedIPs() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("stale entry not removed by the periodic cleanup")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,34 @@
+package web
+
+import (
+	"strings"
+
+	"sumariza-ai/templates/pages"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// notFoundMessage is shown for paths that match no route.
+const notFoundMessage = "This page doesn't exist. Check the link, or paste a tweet URL on the home page."
+
+// NotFound answers requests that matched no route with a 404: a JSON error
+// for API clients and the error page for browsers. Register it last.
+func (h *Handlers) NotFound(c *fiber.Ctx) error {
+	// Keep the request logged under the "unmatched" route label
+	c.Locals(unmatchedRoute, true)
+
+	c.Status(fiber.StatusNotFound)
+	if wantsJSON(c) {
+		return c.JSON(fiber.Map{"error": notFoundMessage})
+	}
+	return render(c, pages.Error(notFoundMessage))
+}
+
+// wantsJSON reports whether the client expects JSON rather than HTML: the
+// path is under /api, or the Accept header prefers JSON.
+func wantsJSON(c *fiber.Ctx) bool {
+	if path := c.Path(); path == "/api" || strings.HasPrefix(path, "/api/") {
+		return true
+	}
+	return c.Accepts(fiber.MIMETextHTML, fiber.MIMEApplicationJSON) == fiber.MIMEApplicationJSON
+}
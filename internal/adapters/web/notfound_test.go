@@ -0,0 +1,96 @@
+package web
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupNotFoundApp() *fiber.App {
+	h := NewHandlers(nil, nil)
+
+	app := fiber.New()
+	app.Get("/robots.txt", h.RobotsTxt)
+	app.Use(h.NotFound)
+	return app
+}
+
+func TestNotFound_UnknownHTMLRoute_RendersErrorPage(t *testing.T) {
+	// Arrange
+	app := setupNotFoundApp()
+	req := httptest.NewRequest("GET", "/no/such/page", nil)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,*/*;q=0.8")
+
+	// Act
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	// Assert
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("status = %d, want 404", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("Content-Type = %q, want text/html", ct)
+	}
+	if !strings.Contains(string(body), "This page doesn&#39;t exist") {
+		t.Errorf("body should render the not found message, got: %s", body)
+	}
+}
+
+func TestNotFound_UnknownAPIRoute_ReturnsJSON(t *testing.T) {
+	// Arrange
+	app := setupNotFoundApp()
+	req := httptest.NewRequest("GET", "/api/v2/tweet/jack/20", nil)
+	req.Header.Set("Accept", "text/html,*/*")
+
+	// Act
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	// Assert
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("status = %d, want 404", resp.StatusCode)
+	}
+	var payload struct {
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatalf("body is not JSON: %v", err)
+	}
+	if payload.Error != notFoundMessage {
+		t.Errorf("error = %q, want %q", payload.Error, notFoundMessage)
+	}
+}
+
+func TestNotFound_AcceptJSON_ReturnsJSON(t *testing.T) {
+	// Arrange
+	app := setupNotFoundApp()
+	req := httptest.NewRequest("GET", "/no/such/page", nil)
+	req.Header.Set("Accept", "application/json")
+
+	// Act
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	// Assert
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("status = %d, want 404", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, fiber.MIMEApplicationJSON) {
+		t.Errorf("Content-Type = %q, want JSON", ct)
+	}
+}
@@ -0,0 +1,19 @@
+package web
+
+import (
+	_ "embed"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// openAPISpec is the hand-written OpenAPI 3 description of the JSON API.
+// Keep its schemas in sync with the JSON tags in json.go and batch.go.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// OpenAPI serves the OpenAPI document.
+func (h *Handlers) OpenAPI(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+	return c.Send(openAPISpec)
+}
@@ -0,0 +1,89 @@
+package web
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type openAPIDoc struct {
+	OpenAPI    string                    `json:"openapi"`
+	Paths      map[string]map[string]any `json:"paths"`
+	Components struct {
+		Schemas map[string]struct {
+			Properties map[string]any `json:"properties"`
+		} `json:"schemas"`
+	} `json:"components"`
+}
+
+func getOpenAPI(t *testing.T) openAPIDoc {
+	t.Helper()
+	app := fiber.New()
+	app.Get("/openapi.json", NewHandlers(nil, nil).OpenAPI)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/openapi.json", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("Content-Type: got %q, want application/json", ct)
+	}
+	var doc openAPIDoc
+	if err := json.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	return doc
+}
+
+func TestOpenAPI_ServesDocumentWithPaths(t *testing.T) {
+	// Act
+	doc := getOpenAPI(t)
+
+	// Assert
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		t.Errorf("openapi: got %q, want 3.x", doc.OpenAPI)
+	}
+	for _, path := range []string{"/api/v1/tweet/{username}/{id}", "/api/v1/tweets"} {
+		if _, ok := doc.Paths[path]; !ok {
+			t.Errorf("missing path %q", path)
+		}
+	}
+	for _, schema := range []string{"Tweet", "Author", "Error"} {
+		if _, ok := doc.Components.Schemas[schema]; !ok {
+			t.Errorf("missing schema %q", schema)
+		}
+	}
+}
+
+func TestOpenAPI_SchemasMatchJSONTags(t *testing.T) {
+	doc := getOpenAPI(t)
+
+	for schema, typ := range map[string]reflect.Type{
+		"Tweet":     reflect.TypeOf(tweetResponse{}),
+		"Author":    reflect.TypeOf(authorResponse{}),
+		"Metrics":   reflect.TypeOf(metricsResponse{}),
+		"BatchItem": reflect.TypeOf(batchItemResponse{}),
+		"Readiness": reflect.TypeOf(readinessResponse{}),
+		"Stats":     reflect.TypeOf(statsResponse{}),
+		"Policy":    reflect.TypeOf(policyResponse{}),
+	} {
+		props := doc.Components.Schemas[schema].Properties
+		for i := 0; i < typ.NumField(); i++ {
+			name := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]
+			if _, ok := props[name]; !ok {
+				t.Errorf("schema %s: missing property %q", schema, name)
+			}
+		}
+		if len(props) != typ.NumField() {
+			t.Errorf("schema %s: got %d properties, want %d", schema, len(props), typ.NumField())
+		}
+	}
+}
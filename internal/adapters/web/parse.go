@@ -0,0 +1,60 @@
+package web
+
+import (
+	"regexp"
+
+	"sumariza-ai/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxParseBodySize caps the HTML accepted by the parse API.
+const maxParseBodySize = 2 << 20
+
+// tweetIDRegex matches a numeric tweet ID.
+var tweetIDRegex = regexp.MustCompile(`^\d+$`)
+
+// TweetParser parses tweet page HTML without scraping.
+type TweetParser interface {
+	// ParseHTML parses the tweet. The bool result reports whether optional
+	// fields were missing.
+	ParseHTML(html, tweetID string) (*domain.Tweet, bool)
+	// HasEssentialContent reports whether the parsed tweet has text, or
+	// media, a poll or a card standing in for it.
+	HasEssentialContent(tweet *domain.Tweet, html string) bool
+}
+
+// SetParser sets the parser behind the parse API. A nil parser disables it.
+func (h *Handlers) SetParser(parser TweetParser) {
+	h.parser = parser
+}
+
+// APIParseTweet parses tweet HTML sent as the raw request body and returns
+// the tweet as JSON. The tweet ID is given by the tweet_id query parameter.
+func (h *Handlers) APIParseTweet(c *fiber.Ctx) error {
+	if h.parser == nil {
+		return fiber.ErrNotFound
+	}
+
+	tweetID := c.Query("tweet_id")
+	if !tweetIDRegex.MatchString(tweetID) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Provide a numeric tweet_id."})
+	}
+
+	body := c.Body()
+	if len(body) > maxParseBodySize {
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{"error": "HTML body is too large."})
+	}
+	if len(body) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Send the tweet HTML as the request body."})
+	}
+
+	html := string(body)
+	tweet, partial := h.parser.ParseHTML(html, tweetID)
+	if !h.parser.HasEssentialContent(tweet, html) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": "No tweet text or media found in the HTML."})
+	}
+	tweet.Partial = partial
+
+	return c.JSON(newTweetResponse(tweet))
+}
@@ -0,0 +1,115 @@
+package web
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sumariza-ai/internal/adapters/scraper"
+	"sumariza-ai/test/fixtures"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupParseApp() *fiber.App {
+	h := NewHandlers(nil, nil)
+	h.SetParser(scraper.NewTwitterScraper(nil, &scraper.SelectorConfig{}))
+
+	app := fiber.New()
+	app.Post("/api/v1/parse", h.APIParseTweet)
+	return app
+}
+
+func postParse(t *testing.T, app *fiber.App, query, body string) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/api/v1/parse"+query, strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/html")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	return resp
+}
+
+func TestAPIParseTweet_FixtureHTML_ReturnsParsedTweet(t *testing.T) {
+	// Arrange
+	app := setupParseApp()
+
+	// Act
+	resp := postParse(t, app, "?tweet_id=200", fixtures.GenerateQuoteTweetWithMedia())
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+
+	// Assert
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status: got %d, want 200 (%s)", resp.StatusCode, data)
+	}
+	var got tweetResponse
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", data, err)
+	}
+	if got.ID != "200" {
+		t.Errorf("id: got %q, want 200", got.ID)
+	}
+	if got.Text != "Quoting this one" {
+		t.Errorf("text: got %q, want 'Quoting this one'", got.Text)
+	}
+	if got.Author.Name != "Outer Person" || got.Author.Handle != "outer" {
+		t.Errorf("author: got %q @%q, want 'Outer Person' @outer", got.Author.Name, got.Author.Handle)
+	}
+	if got.QuotedTweet == nil || got.QuotedTweet.Author.Handle != "quotedauthor" || !got.QuotedTweet.HasMedia {
+		t.Errorf("quoted_tweet: got %+v", got.QuotedTweet)
+	}
+	if !got.Partial {
+		t.Error("expected partial for a main tweet without avatar")
+	}
+}
+
+func TestAPIParseTweet_ImageOnlyTweet_ReturnsParsedTweet(t *testing.T) {
+	// Arrange
+	app := setupParseApp()
+
+	// Act
+	resp := postParse(t, app, "?tweet_id=123", fixtures.GenerateImageOnlyTweet())
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+
+	// Assert
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status: got %d, want 200 (%s)", resp.StatusCode, data)
+	}
+	var got tweetResponse
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", data, err)
+	}
+	if got.Text != "" || len(got.Media) == 0 {
+		t.Errorf("got text %q and %d media items, want no text and media", got.Text, len(got.Media))
+	}
+}
+
+func TestAPIParseTweet_RejectsBadRequests(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		query      string
+		body       string
+		wantStatus int
+	}{
+		{name: "missing tweet_id", query: "", body: fixtures.GenerateBasicTweet(), wantStatus: fiber.StatusBadRequest},
+		{name: "non-numeric tweet_id", query: "?tweet_id=abc", body: fixtures.GenerateBasicTweet(), wantStatus: fiber.StatusBadRequest},
+		{name: "empty body", query: "?tweet_id=1", body: "", wantStatus: fiber.StatusBadRequest},
+		{name: "too large", query: "?tweet_id=1", body: strings.Repeat("a", maxParseBodySize+1), wantStatus: fiber.StatusRequestEntityTooLarge},
+		{name: "no tweet text", query: "?tweet_id=1", body: "<html><body>Log in</body></html>", wantStatus: fiber.StatusUnprocessableEntity},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := postParse(t, setupParseApp(), tc.query, tc.body)
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Errorf("status: got %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+		})
+	}
+}
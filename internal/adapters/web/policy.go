@@ -0,0 +1,65 @@
+package web
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// policyMaxAge is the Cache-Control max-age, in seconds, of /api/v1/policy.
+// The policy only changes on restart.
+const policyMaxAge = 300
+
+// ScrapePolicy is the effective scraping configuration disclosed at
+// /api/v1/policy.
+type ScrapePolicy struct {
+	RateLimit          int           // Scrapes allowed per client IP within RateWindow
+	RateWindow         time.Duration // Rate limit window
+	CacheTTL           time.Duration // How long scraped tweets are served from cache
+	NegativeCacheTTL   time.Duration // How long repeatedly login-gated tweets are not re-scraped (0 = never skipped)
+	AuthEnabled        bool          // Whether scrapes use logged-in Twitter cookies
+	ScreenshotsEnabled bool          // Whether tweet screenshots are taken
+}
+
+// policyResponse is the JSON body of /api/v1/policy. Durations are in seconds.
+type policyResponse struct {
+	RateLimit        rateLimitResponse `json:"rate_limit"`
+	CacheTTL         int64             `json:"cache_ttl"`
+	NegativeCacheTTL int64             `json:"negative_cache_ttl"`
+	Features         featuresResponse  `json:"features"`
+}
+
+type rateLimitResponse struct {
+	Requests int   `json:"requests"`
+	Window   int64 `json:"window"`
+}
+
+type featuresResponse struct {
+	Auth        bool `json:"auth"`
+	Screenshots bool `json:"screenshots"`
+}
+
+// SetScrapePolicy sets the configuration disclosed by Policy.
+func (h *Handlers) SetScrapePolicy(policy ScrapePolicy) {
+	h.policy = policy
+}
+
+// Policy returns the scraping policy (rate limits, cache lifetimes and
+// features) as machine-readable JSON.
+func (h *Handlers) Policy(c *fiber.Ctx) error {
+	p := h.policy
+	c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", policyMaxAge))
+	return c.JSON(policyResponse{
+		RateLimit: rateLimitResponse{
+			Requests: p.RateLimit,
+			Window:   int64(p.RateWindow.Seconds()),
+		},
+		CacheTTL:         int64(p.CacheTTL.Seconds()),
+		NegativeCacheTTL: int64(p.NegativeCacheTTL.Seconds()),
+		Features: featuresResponse{
+			Auth:        p.AuthEnabled,
+			Screenshots: p.ScreenshotsEnabled,
+		},
+	})
+}
@@ -0,0 +1,49 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestPolicy_ReflectsConfiguredValues(t *testing.T) {
+	// Arrange
+	h := NewHandlers(nil, nil)
+	h.SetScrapePolicy(ScrapePolicy{
+		RateLimit:        7,
+		RateWindow:       2 * time.Minute,
+		CacheTTL:         5 * time.Minute,
+		NegativeCacheTTL: 6 * time.Hour,
+		AuthEnabled:      true,
+	})
+	app := fiber.New()
+	app.Get("/api/v1/policy", h.Policy)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/policy", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	// Assert
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	var got policyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	want := policyResponse{
+		RateLimit:        rateLimitResponse{Requests: 7, Window: 120},
+		CacheTTL:         300,
+		NegativeCacheTTL: 21600,
+		Features:         featuresResponse{Auth: true, Screenshots: false},
+	}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
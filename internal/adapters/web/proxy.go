@@ -0,0 +1,29 @@
+package web
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// WithTrustedProxies configures Fiber so c.IP() honors X-Forwarded-For only
+// when the request comes from one of the trusted proxies (IPs or CIDRs).
+// With no trusted proxies the header is ignored and c.IP() is the peer address.
+func WithTrustedProxies(cfg fiber.Config, trustedProxies []string) fiber.Config {
+	var proxies []string
+	for _, proxy := range trustedProxies {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	if len(proxies) == 0 {
+		return cfg
+	}
+
+	cfg.ProxyHeader = fiber.HeaderXForwardedFor
+	cfg.EnableTrustedProxyCheck = true
+	cfg.TrustedProxies = proxies
+	// Return the first valid IP from the header instead of the raw value
+	cfg.EnableIPValidation = true
+	return cfg
+}
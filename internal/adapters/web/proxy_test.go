@@ -0,0 +1,54 @@
+package web
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ipFromApp returns c.IP() as seen by an app configured with the trusted proxies.
+// Requests from app.Test always originate from 0.0.0.0.
+func ipFromApp(t *testing.T, trustedProxies []string, forwardedFor string) string {
+	t.Helper()
+
+	app := fiber.New(WithTrustedProxies(fiber.Config{}, trustedProxies))
+	app.Get("/ip", func(c *fiber.Ctx) error {
+		return c.SendString(c.IP())
+	})
+
+	req := httptest.NewRequest("GET", "/ip", nil)
+	req.Header.Set("X-Forwarded-For", forwardedFor)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func TestWithTrustedProxies_TrustedSource_UsesForwardedFor(t *testing.T) {
+	ip := ipFromApp(t, []string{"0.0.0.0/32"}, "203.0.113.7, 10.0.0.1")
+
+	if ip != "203.0.113.7" {
+		t.Errorf("IP = %q, want 203.0.113.7", ip)
+	}
+}
+
+func TestWithTrustedProxies_UntrustedSource_IgnoresForwardedFor(t *testing.T) {
+	ip := ipFromApp(t, []string{"10.0.0.0/8"}, "203.0.113.7")
+
+	if ip != "0.0.0.0" {
+		t.Errorf("IP = %q, want peer address 0.0.0.0", ip)
+	}
+}
+
+func TestWithTrustedProxies_NoProxies_IgnoresForwardedFor(t *testing.T) {
+	ip := ipFromApp(t, nil, "203.0.113.7")
+
+	if ip != "0.0.0.0" {
+		t.Errorf("IP = %q, want peer address 0.0.0.0", ip)
+	}
+}
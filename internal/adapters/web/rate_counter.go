@@ -0,0 +1,145 @@
+package web
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RateAlgorithm selects how a RateLimiter counts the scrapes of an IP.
+type RateAlgorithm int
+
+const (
+	// RateTimestamps keeps every scrape time within the window (default).
+	// Exact, but memory and CPU per check grow with the IP's activity.
+	RateTimestamps RateAlgorithm = iota
+	// RateWindowCounter counts scrapes in windowBuckets fixed buckets per
+	// window: constant memory and CPU per IP. A scrape may be forgotten up to
+	// one bucket (window/windowBuckets) before it leaves the window.
+	RateWindowCounter
+)
+
+// windowBuckets is how many fixed buckets RateWindowCounter splits a window into.
+const windowBuckets = 10
+
+// ParseRateAlgorithm parses "timestamps" or "counter" (case-insensitive).
+func ParseRateAlgorithm(s string) (RateAlgorithm, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "timestamps":
+		return RateTimestamps, nil
+	case "counter":
+		return RateWindowCounter, nil
+	default:
+		return RateTimestamps, fmt.Errorf("invalid rate limit algorithm %q", s)
+	}
+}
+
+// scrapeCounter counts one IP's scrapes within the limiter's window.
+type scrapeCounter interface {
+	// add records a scrape at now.
+	add(now time.Time)
+	// count returns the scrapes within the window ending at now.
+	count(now time.Time) int
+	// expire forgets scrapes outside the window ending at now and reports
+	// whether none are left.
+	expire(now time.Time) bool
+}
+
+// newScrapeCounter returns an empty counter for the algorithm.
+func newScrapeCounter(algorithm RateAlgorithm, window time.Duration) scrapeCounter {
+	if algorithm == RateWindowCounter {
+		return newBucketCounter(window)
+	}
+	return &timestampCounter{window: window}
+}
+
+// timestampCounter keeps each scrape time.
+type timestampCounter struct {
+	window time.Duration
+	times  []time.Time
+}
+
+func (c *timestampCounter) add(now time.Time) {
+	c.times = append(c.times, now)
+}
+
+func (c *timestampCounter) count(now time.Time) int {
+	cutoff := now.Add(-c.window)
+
+	var recent int
+	for _, t := range c.times {
+		if t.After(cutoff) {
+			recent++
+		}
+	}
+	return recent
+}
+
+func (c *timestampCounter) expire(now time.Time) bool {
+	cutoff := now.Add(-c.window)
+
+	var recent []time.Time
+	for _, t := range c.times {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+	c.times = recent
+	return len(recent) == 0
+}
+
+// bucketCounter counts scrapes in a ring of fixed-width time buckets.
+// A scrape is counted while its bucket is one of the last windowBuckets.
+type bucketCounter struct {
+	width  time.Duration
+	counts [windowBuckets]int
+	latest int64 // index of the most recent bucket written
+}
+
+func newBucketCounter(window time.Duration) *bucketCounter {
+	width := window / windowBuckets
+	if width <= 0 {
+		width = 1
+	}
+	return &bucketCounter{width: width}
+}
+
+// bucket returns the absolute bucket index of t.
+func (c *bucketCounter) bucket(t time.Time) int64 {
+	return t.UnixNano() / int64(c.width)
+}
+
+func (c *bucketCounter) add(now time.Time) {
+	c.advance(c.bucket(now))
+	c.counts[c.latest%windowBuckets]++
+}
+
+// advance moves the ring to bucket b, zeroing the buckets it skips.
+func (c *bucketCounter) advance(b int64) {
+	if b <= c.latest {
+		return
+	}
+	if b-c.latest >= windowBuckets {
+		c.counts = [windowBuckets]int{}
+	} else {
+		for i := c.latest + 1; i <= b; i++ {
+			c.counts[i%windowBuckets] = 0
+		}
+	}
+	c.latest = b
+}
+
+func (c *bucketCounter) count(now time.Time) int {
+	oldest := c.bucket(now) - windowBuckets + 1
+
+	var total int
+	for i := max(oldest, c.latest-windowBuckets+1); i <= c.latest; i++ {
+		total += c.counts[i%windowBuckets]
+	}
+	return total
+}
+
+func (c *bucketCounter) expire(now time.Time) bool {
+	c.advance(c.bucket(now))
+	return c.count(now) == 0
+}
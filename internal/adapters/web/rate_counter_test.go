@@ -0,0 +1,97 @@
+package web
+
+import (
+	"testing"
+	"time"
+
+	"sumariza-ai/pkg/clock"
+)
+
+func TestParseRateAlgorithm(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    RateAlgorithm
+		wantErr bool
+	}{
+		{"timestamps", RateTimestamps, false},
+		{"Counter", RateWindowCounter, false},
+		{" counter ", RateWindowCounter, false},
+		{"leaky-bucket", RateTimestamps, true},
+		{"", RateTimestamps, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRateAlgorithm(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error: got %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// newAlgorithmLimiter returns a limiter using algorithm on a fake clock.
+func newAlgorithmLimiter(algorithm RateAlgorithm, limit int, window time.Duration, start time.Time) (*RateLimiter, *clock.Fake) {
+	rl := NewRateLimiter(limit, window)
+	rl.SetAlgorithm(algorithm)
+	fake := clock.NewFake(start)
+	rl.SetClock(fake)
+	return rl, fake
+}
+
+func TestRateLimiter_WindowCounter_MatchesTimestamps(t *testing.T) {
+	// Arrange - steps land on bucket boundaries (window/10), where both
+	// algorithms see exactly the same scrapes in the window.
+	const limit = 3
+	window := time.Minute
+	bucket := window / windowBuckets
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	exact, exactClock := newAlgorithmLimiter(RateTimestamps, limit, window, start)
+	counter, counterClock := newAlgorithmLimiter(RateWindowCounter, limit, window, start)
+
+	// buckets to advance before each attempt
+	script := []int{0, 0, 1, 0, 2, 3, 4, 1, 0, 0, 10, 0, 0, 0, 5, 5, 0, 25, 1, 1, 1, 1}
+
+	for i, advance := range script {
+		exactClock.Advance(time.Duration(advance) * bucket)
+		counterClock.Advance(time.Duration(advance) * bucket)
+
+		for _, ip := range []string{"1.1.1.1", "2.2.2.2"} {
+			// Act
+			want := exact.CanScrape(ip)
+			got := counter.CanScrape(ip)
+
+			// Assert
+			if got != want {
+				t.Fatalf("step %d, %s: counter allowed=%v, timestamps allowed=%v", i, ip, got, want)
+			}
+			if want {
+				exact.RecordScrape(ip)
+				counter.RecordScrape(ip)
+			}
+		}
+	}
+}
+
+func TestRateLimiter_WindowCounter_LimitsAndExpires(t *testing.T) {
+	// Arrange
+	rl, fake := newAlgorithmLimiter(RateWindowCounter, 2, time.Minute, time.Now())
+	rl.RecordScrape("1.2.3.4")
+	rl.RecordScrape("1.2.3.4")
+
+	// Act & Assert
+	if rl.CanScrape("1.2.3.4") {
+		t.Error("expected limit to be reached")
+	}
+	fake.Advance(time.Minute + time.Second)
+	if !rl.CanScrape("1.2.3.4") {
+		t.Error("expected scrape to be allowed once the window passed")
+	}
+	rl.removeStale()
+	if got := rl.trackedIPs(); got != 0 {
+		t.Errorf("tracked IPs: got %d, want 0", got)
+	}
+}
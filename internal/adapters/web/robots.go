@@ -0,0 +1,33 @@
+package web
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// DefaultRobotsTxt disallows crawling of the whole instance.
+const DefaultRobotsTxt = "User-agent: *\nDisallow: /\n"
+
+// SetIndexing configures the robots.txt body and whether tweet responses
+// carry X-Robots-Tag: noindex. An empty robotsTxt uses DefaultRobotsTxt.
+func (h *Handlers) SetIndexing(robotsTxt string, noIndex bool) {
+	h.robotsTxt = robotsTxt
+	h.noIndex = noIndex
+}
+
+// RobotsTxt serves the configured robots.txt.
+func (h *Handlers) RobotsTxt(c *fiber.Ctx) error {
+	body := h.robotsTxt
+	if body == "" {
+		body = DefaultRobotsTxt
+	}
+	c.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+	return c.SendString(body)
+}
+
+// NoIndex marks the response as not indexable when indexing is disabled.
+func (h *Handlers) NoIndex(c *fiber.Ctx) error {
+	if h.noIndex {
+		c.Set("X-Robots-Tag", "noindex")
+	}
+	return c.Next()
+}
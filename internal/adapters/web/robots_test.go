@@ -0,0 +1,83 @@
+package web
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupIndexingApp(robotsTxt string, noIndex bool) *fiber.App {
+	h := NewHandlers(nil, nil)
+	h.SetIndexing(robotsTxt, noIndex)
+
+	app := fiber.New()
+	app.Get("/robots.txt", h.RobotsTxt)
+	app.Get("/:username/status/:id", h.NoIndex, func(c *fiber.Ctx) error {
+		return c.SendString("tweet")
+	})
+	return app
+}
+
+func TestRobotsTxt_Default_DisallowsAll(t *testing.T) {
+	// Arrange
+	app := setupIndexingApp("", false)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest("GET", "/robots.txt", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	// Assert
+	if string(body) != DefaultRobotsTxt {
+		t.Errorf("body: got %q, want %q", body, DefaultRobotsTxt)
+	}
+}
+
+func TestRobotsTxt_Configured_ServesContent(t *testing.T) {
+	// Arrange
+	robots := "User-agent: *\nAllow: /\n"
+	app := setupIndexingApp(robots, false)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest("GET", "/robots.txt", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	// Assert
+	if string(body) != robots {
+		t.Errorf("body: got %q, want %q", body, robots)
+	}
+}
+
+func TestNoIndex_HeaderFollowsConfig(t *testing.T) {
+	for _, tc := range []struct {
+		noIndex bool
+		want    string
+	}{
+		{noIndex: true, want: "noindex"},
+		{noIndex: false, want: ""},
+	} {
+		// Arrange
+		app := setupIndexingApp("", tc.noIndex)
+
+		// Act
+		resp, err := app.Test(httptest.NewRequest("GET", "/user/status/123", nil))
+		if err != nil {
+			t.Fatalf("app.Test() error = %v", err)
+		}
+		resp.Body.Close()
+
+		// Assert
+		if got := resp.Header.Get("X-Robots-Tag"); got != tc.want {
+			t.Errorf("noIndex=%v: X-Robots-Tag got %q, want %q", tc.noIndex, got, tc.want)
+		}
+	}
+}
@@ -5,21 +5,59 @@ import (
 )
 
 // SetupRoutes configures the application routes.
-func SetupRoutes(app *fiber.App, handlers *Handlers, rateLimiter *RateLimiter) {
+// Static assets are served from staticDir (see ResolveStaticDir).
+func SetupRoutes(app *fiber.App, handlers *Handlers, rateLimiter *RateLimiter, staticDir string) {
 	// Static assets
-	app.Static("/static", "./static")
+	setupStatic(app, staticDir)
+
+	// Readiness probe: browser and upstream (Twitter) health
+	app.Get("/readyz", handlers.Readyz)
+
+	// Process counters as one JSON object (404 unless a registry is set)
+	app.Get("/stats", handlers.Stats)
+
+	// Crawler policy (tweet routes get X-Robots-Tag when NOINDEX=1)
+	app.Get("/robots.txt", handlers.RobotsTxt)
 
 	// Home page
 	app.Get("/", handlers.Home)
 
 	// Tweet view - mirrors Twitter URL structure
 	// Example: /acgfbr/status/2006396789411172607
-	app.Get("/:username/status/:id", handlers.ViewTweet)
+	app.Get("/:username/status/:id", handlers.NoIndex, handlers.ViewTweet)
+
+	// Deep link to one photo of the tweet, emphasized on the view page
+	// Example: /acgfbr/status/2006396789411172607/photo/2
+	app.Get("/:username/status/:id/photo/:index", handlers.NoIndex, handlers.ViewTweet)
 
 	// HTMX endpoint for fetching tweets from form input
-	app.Post("/fetch", handlers.FetchTweet)
+	app.Post("/fetch", handlers.NoIndex, handlers.FetchTweet)
 
 	// API endpoint for HTMX to fetch tweet content (direct URL access)
-	app.Get("/api/tweet/:username/:id", handlers.APIGetTweet)
+	app.Get("/api/tweet/:username/:id", handlers.NoIndex, handlers.APIGetTweet)
+
+	// OpenAPI description of the JSON API
+	app.Get("/openapi.json", handlers.OpenAPI)
+
+	// JSON API (?fresh=1 bypasses the cache read)
+	app.Get("/api/v1/tweet/:username/:id", handlers.NoIndex, handlers.APIGetTweetJSON)
+
+	// Scraping policy disclosure: rate limits, cache lifetimes, features
+	app.Get("/api/v1/policy", handlers.Policy)
+
+	// JSON batch API (up to 20 URLs, fan-out bounded by BATCH_CONCURRENCY)
+	app.Post("/api/v1/tweets", handlers.NoIndex, handlers.APIGetTweetsBatch)
+
+	// Parse API: tweet HTML in, tweet JSON out (no scraping)
+	app.Post("/api/v1/parse", handlers.NoIndex, handlers.APIParseTweet)
+
+	// Admin: pre-populate the cache (requires ADMIN_TOKEN bearer auth)
+	app.Post("/admin/warm", handlers.RequireAdmin, handlers.AdminWarm)
+
+	// Server-Sent Events stream with scrape progress
+	app.Get("/api/v1/tweet/:username/:id/stream", handlers.NoIndex, handlers.StreamTweet)
+
+	// Unknown routes: error page, or a JSON error for API clients (keep last)
+	app.Use(handlers.NotFound)
 }
 
@@ -0,0 +1,77 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"sumariza-ai/internal/usecases"
+	"sumariza-ai/pkg/log"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// StreamTweet streams scrape progress as Server-Sent Events.
+// Emits a "progress" event for each scrape step, then a "done" event with the
+// tweet JSON, or an "error" event with a friendly message. The scrape is
+// canceled once an event can't be written, i.e. the client went away, so a
+// closed EventSource doesn't hold the browser tab.
+func (h *Handlers) StreamTweet(c *fiber.Ctx) error {
+	// Params point into fasthttp buffers that are reused once the handler
+	// returns, so copy them before the stream writer runs
+	username := strings.Clone(c.Params("username"))
+	tweetID := strings.Clone(c.Params("id"))
+	opts := getTweetOptions(c)
+	reqCtx := c.UserContext()
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ctx, cancel := context.WithTimeout(reqCtx, 30*time.Second)
+		defer cancel()
+
+		disconnected := false
+		ctx = usecases.WithProgress(ctx, func(step string) {
+			if err := writeSSE(w, "progress", step); err != nil && !disconnected {
+				disconnected = true
+				log.GlobalDebugCtx(ctx, "stream client disconnected, canceling scrape", "tweet_id", tweetID, "error", err)
+				cancel()
+			}
+		})
+
+		tweet, err := h.getTweet.Execute(ctx, tweetID, username, opts)
+		if disconnected {
+			return
+		}
+		if err != nil {
+			log.GlobalErrorCtx(ctx, "stream tweet failed", "username", username, "tweet_id", tweetID, "error", err)
+			writeSSE(w, "error", h.friendlyError(err))
+			return
+		}
+
+		data, err := json.Marshal(newTweetResponse(tweet))
+		if err != nil {
+			log.GlobalErrorCtx(ctx, "stream tweet marshal failed", "tweet_id", tweetID, "error", err)
+			writeSSE(w, "error", h.friendlyError(err))
+			return
+		}
+		writeSSE(w, usecases.StepDone, string(data))
+	})
+
+	return nil
+}
+
+// writeSSE writes a single Server-Sent Event frame and flushes it. The
+// error is the first write or flush failure, e.g. once the client is gone.
+func writeSSE(w *bufio.Writer, event, data string) error {
+	_, _ = w.WriteString("event: " + event + "\n")
+	for _, line := range strings.Split(data, "\n") {
+		_, _ = w.WriteString("data: " + line + "\n")
+	}
+	_, _ = w.WriteString("\n")
+	return w.Flush()
+}
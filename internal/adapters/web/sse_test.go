@@ -0,0 +1,160 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"sumariza-ai/internal/adapters/cache"
+	"sumariza-ai/internal/domain"
+	"sumariza-ai/internal/usecases"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// stagedScraper is a mock scrape pipeline that reports each progress step.
+type stagedScraper struct {
+	tweet *domain.Tweet
+	err   error
+}
+
+func (s *stagedScraper) Scrape(ctx context.Context, tweetID string) (*domain.Tweet, error) {
+	usecases.ReportProgress(ctx, usecases.StepNavigating)
+	usecases.ReportProgress(ctx, usecases.StepWaiting)
+	if s.err != nil {
+		return nil, s.err
+	}
+	usecases.ReportProgress(ctx, usecases.StepParsing)
+	return s.tweet, nil
+}
+
+func setupStreamApp(scraper usecases.TweetScraper) *fiber.App {
+	getTweetUC := usecases.NewGetTweetUseCase(
+		cache.NewMemoryCache(time.Minute),
+		usecases.NewScrapeTweetUseCase(scraper),
+	)
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Get("/api/v1/tweet/:username/:id/stream", NewHandlers(getTweetUC, usecases.NewBatchGetTweetsUseCase(getTweetUC, 1)).StreamTweet)
+	return app
+}
+
+func TestStreamTweet_EmitsStagedEvents(t *testing.T) {
+	app := setupStreamApp(&stagedScraper{
+		tweet: &domain.Tweet{ID: "123", Content: domain.Content{Text: "Hello SSE"}},
+	})
+
+	req := httptest.NewRequest("GET", "/api/v1/tweet/user/123/stream", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	output := string(body)
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", ct)
+	}
+
+	frames := []string{
+		"event: progress\ndata: navigating\n\n",
+		"event: progress\ndata: waiting\n\n",
+		"event: progress\ndata: parsing\n\n",
+		"event: done\ndata: {",
+	}
+	pos := 0
+	for _, frame := range frames {
+		idx := strings.Index(output[pos:], frame)
+		if idx < 0 {
+			t.Fatalf("frame %q not found in order, got: %s", frame, output)
+		}
+		pos += idx + len(frame)
+	}
+
+	if !strings.Contains(output, `"text":"Hello SSE"`) {
+		t.Errorf("done event should carry the tweet payload, got: %s", output)
+	}
+	if !strings.Contains(output, `"url":"https://x.com/user/status/123"`) {
+		t.Errorf("done event should carry the tweet URL, got: %s", output)
+	}
+}
+
+func TestStreamTweet_ScrapeError_EmitsErrorEvent(t *testing.T) {
+	app := setupStreamApp(&stagedScraper{err: domain.ErrTextNotFound})
+
+	req := httptest.NewRequest("GET", "/api/v1/tweet/user/123/stream", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	output := string(body)
+
+	if !strings.Contains(output, "event: progress\ndata: waiting\n\n") {
+		t.Errorf("expected progress before error, got: %s", output)
+	}
+	if !strings.Contains(output, "event: error\ndata: This tweet couldn't be loaded.") {
+		t.Errorf("expected friendly error event, got: %s", output)
+	}
+	if strings.Contains(output, "event: done") {
+		t.Errorf("did not expect done event on error, got: %s", output)
+	}
+}
+
+// tickingScraper reports progress until its context is canceled, then
+// closes canceled.
+type tickingScraper struct {
+	canceled chan struct{}
+}
+
+func (s *tickingScraper) Scrape(ctx context.Context, tweetID string) (*domain.Tweet, error) {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			close(s.canceled)
+			return nil, ctx.Err()
+		case <-ticker.C:
+			usecases.ReportProgress(ctx, usecases.StepWaiting)
+		}
+	}
+}
+
+func TestStreamTweet_ClientDisconnect_CancelsScrape(t *testing.T) {
+	// Arrange - a real listener, so closing the body closes the connection
+	scraper := &tickingScraper{canceled: make(chan struct{})}
+	app := setupStreamApp(scraper)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	go func() { _ = app.Listener(ln) }()
+	defer func() { _ = app.Shutdown() }()
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/api/v1/tweet/user/123/stream")
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil || line != "event: progress\n" {
+		t.Fatalf("first line: got %q (%v), want a progress event", line, err)
+	}
+
+	// Act - the client goes away mid-stream
+	resp.Body.Close()
+
+	// Assert
+	select {
+	case <-scraper.canceled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("scrape context not canceled after the client disconnected")
+	}
+}
@@ -0,0 +1,60 @@
+package web
+
+import (
+	"os"
+	"path/filepath"
+
+	"sumariza-ai/pkg/log"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/etag"
+)
+
+// DefaultStaticDir is the static assets directory relative to the working directory.
+const DefaultStaticDir = "./static"
+
+// staticMaxAge is the browser cache lifetime of static assets, in seconds.
+// Assets are not fingerprinted, so it stays short and relies on revalidation.
+const staticMaxAge = 3600
+
+// ResolveStaticDir returns the directory static assets are served from.
+// A configured directory (STATIC_DIR) wins when it exists. Otherwise ./static
+// is tried, then static/ next to the executable, so the binary still finds
+// its assets when started from another working directory.
+func ResolveStaticDir(configured string) string {
+	if configured != "" {
+		if isDir(configured) {
+			return configured
+		}
+		log.GlobalWarn("static dir not found, falling back", "dir", configured)
+	}
+
+	if isDir(DefaultStaticDir) {
+		return DefaultStaticDir
+	}
+
+	if exe, err := os.Executable(); err == nil {
+		exeDir := filepath.Join(filepath.Dir(exe), "static")
+		if isDir(exeDir) {
+			return exeDir
+		}
+	}
+
+	log.GlobalWarn("no static dir found, assets will return 404", "dir", DefaultStaticDir)
+	return DefaultStaticDir
+}
+
+// setupStatic serves the files under dir at /static with Cache-Control,
+// ETag and Last-Modified headers. Conditional requests (If-None-Match,
+// If-Modified-Since) for unchanged files get a 304.
+func setupStatic(app *fiber.App, dir string) {
+	app.Use("/static", etag.New())
+	app.Static("/static", dir, fiber.Static{
+		MaxAge: staticMaxAge,
+	})
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,116 @@
+package web
+
+import (
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestSetupStatic_ConfiguredDir_ServesFile(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "app.css"), []byte("body{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	app := fiber.New()
+	setupStatic(app, ResolveStaticDir(dir))
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest("GET", "/static/app.css", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	// Assert
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("status: got %d, want 200", resp.StatusCode)
+	}
+	if string(body) != "body{}" {
+		t.Errorf("body: got %q, want 'body{}'", body)
+	}
+}
+
+func TestResolveStaticDir_MissingConfiguredDir_FallsBack(t *testing.T) {
+	// Arrange
+	missing := filepath.Join(t.TempDir(), "nope")
+
+	// Act
+	dir := ResolveStaticDir(missing)
+
+	// Assert
+	if dir == missing {
+		t.Errorf("expected fallback, got the missing dir %q", dir)
+	}
+}
+
+func TestSetupStatic_SetsCachingHeaders(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "app.css"), []byte("body{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	app := fiber.New()
+	setupStatic(app, dir)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest("GET", "/static/app.css", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	resp.Body.Close()
+
+	// Assert
+	if got := resp.Header.Get("Cache-Control"); got != "public, max-age=3600" {
+		t.Errorf("Cache-Control: got %q, want 'public, max-age=3600'", got)
+	}
+	if resp.Header.Get("ETag") == "" {
+		t.Error("expected ETag header")
+	}
+	if resp.Header.Get("Last-Modified") == "" {
+		t.Error("expected Last-Modified header")
+	}
+}
+
+func TestSetupStatic_ConditionalRequest_ReturnsNotModified(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "app.css"), []byte("body{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	app := fiber.New()
+	setupStatic(app, dir)
+
+	first, err := app.Test(httptest.NewRequest("GET", "/static/app.css", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	first.Body.Close()
+
+	for _, tc := range []struct {
+		header string
+		value  string
+	}{
+		{header: "If-None-Match", value: first.Header.Get("ETag")},
+		{header: "If-Modified-Since", value: first.Header.Get("Last-Modified")},
+	} {
+		// Act
+		req := httptest.NewRequest("GET", "/static/app.css", nil)
+		req.Header.Set(tc.header, tc.value)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("app.Test() error = %v", err)
+		}
+		resp.Body.Close()
+
+		// Assert
+		if resp.StatusCode != fiber.StatusNotModified {
+			t.Errorf("%s: status got %d, want 304", tc.header, resp.StatusCode)
+		}
+	}
+}
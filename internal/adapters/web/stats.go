@@ -0,0 +1,54 @@
+package web
+
+import (
+	"sumariza-ai/pkg/stats"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// statsResponse is the JSON body of /stats.
+type statsResponse struct {
+	Uptime          int64 `json:"uptime"` // Seconds since the process started
+	TotalRequests   int64 `json:"total_requests"`
+	TotalScrapes    int64 `json:"total_scrapes"`
+	CacheHits       int64 `json:"cache_hits"`
+	CacheMisses     int64 `json:"cache_misses"`
+	BrowserStarts   int64 `json:"browser_starts"`
+	CurrentInflight int64 `json:"current_inflight"`
+	DroppedLogs     int64 `json:"dropped_logs"`
+}
+
+// SetStats sets the registry served by Stats. Without one, /stats is a 404.
+func (h *Handlers) SetStats(registry *stats.Registry) {
+	h.stats = registry
+}
+
+// Stats returns the process counters as a single JSON object.
+func (h *Handlers) Stats(c *fiber.Ctx) error {
+	if h.stats == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": notFoundMessage})
+	}
+
+	s := h.stats.Snapshot()
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.JSON(statsResponse{
+		Uptime:          int64(s.Uptime.Seconds()),
+		TotalRequests:   s.TotalRequests,
+		TotalScrapes:    s.TotalScrapes,
+		CacheHits:       s.CacheHits,
+		CacheMisses:     s.CacheMisses,
+		BrowserStarts:   s.BrowserStarts,
+		CurrentInflight: s.CurrentInflight,
+		DroppedLogs:     s.DroppedLogs,
+	})
+}
+
+// StatsMiddleware counts every request and how many are in flight.
+// A nil registry makes it a pass-through.
+func StatsMiddleware(registry *stats.Registry) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		registry.RequestStarted()
+		defer registry.RequestFinished()
+		return c.Next()
+	}
+}
@@ -0,0 +1,98 @@
+package web
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"sumariza-ai/internal/adapters/cache"
+	"sumariza-ai/internal/usecases"
+	"sumariza-ai/pkg/stats"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestStats_AfterActivity_ReportsCounters(t *testing.T) {
+	// Arrange
+	registry := stats.New()
+	registry.SetDroppedLogs(func() int64 { return 2 })
+	registry.BrowserStarted()
+
+	scrapeUC := usecases.NewScrapeTweetUseCase(&countingScraper{text: "Hello"})
+	scrapeUC.SetStats(registry)
+	getTweetUC := usecases.NewGetTweetUseCase(cache.NewMemoryCache(time.Minute), scrapeUC)
+	getTweetUC.SetStats(registry)
+	h := NewHandlers(getTweetUC, usecases.NewBatchGetTweetsUseCase(getTweetUC, 1))
+	h.SetStats(registry)
+
+	app := fiber.New()
+	app.Use(StatsMiddleware(registry))
+	app.Get("/stats", h.Stats)
+	app.Get("/api/v1/tweet/:username/:id", h.APIGetTweetJSON)
+
+	// First request scrapes (miss), the second is served from the cache (hit)
+	for i := 0; i < 2; i++ {
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/tweet/user/123", nil))
+		if err != nil {
+			t.Fatalf("app.Test() error = %v", err)
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest("GET", "/stats", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	// Assert
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	var got map[string]json.Number
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	want := map[string]int64{
+		"total_requests":   3,
+		"total_scrapes":    1,
+		"cache_hits":       1,
+		"cache_misses":     1,
+		"browser_starts":   1,
+		"current_inflight": 1, // the /stats request itself
+		"dropped_logs":     2,
+	}
+	for key, value := range want {
+		n, err := got[key].Int64()
+		if err != nil || n != value {
+			t.Errorf("%s: got %q, want %d", key, got[key], value)
+		}
+	}
+	if uptime, err := got["uptime"].Int64(); err != nil || uptime < 0 {
+		t.Errorf("uptime: got %q, want a non-negative integer", got["uptime"])
+	}
+}
+
+func TestStats_NoRegistry_NotFound(t *testing.T) {
+	// Arrange
+	app := fiber.New()
+	app.Get("/stats", NewHandlers(nil, nil).Stats)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest("GET", "/stats", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	// Assert
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("status = %d, want 404", resp.StatusCode)
+	}
+}
@@ -0,0 +1,42 @@
+package web
+
+import (
+	"time"
+
+	"sumariza-ai/pkg/log"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TimezoneHeader lets a client pick the timezone of HTML timestamps,
+// as an IANA name (e.g. "America/Sao_Paulo"). The tz query parameter
+// takes precedence over it.
+const TimezoneHeader = "X-Timezone"
+
+// SetDisplayTimezone sets the timezone HTML timestamps are rendered in when
+// the request doesn't pick one. A nil location means UTC.
+func (h *Handlers) SetDisplayTimezone(loc *time.Location) {
+	h.displayTZ = loc
+}
+
+// displayLocation returns the timezone to render timestamps in: the tz query
+// parameter, else the X-Timezone header, else the configured default.
+// Unknown names are ignored.
+func (h *Handlers) displayLocation(c *fiber.Ctx) *time.Location {
+	for _, name := range []string{c.Query("tz"), c.Get(TimezoneHeader)} {
+		if name == "" {
+			continue
+		}
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			log.GlobalDebugCtx(c.UserContext(), "invalid display timezone, ignoring", "tz", name)
+			continue
+		}
+		return loc
+	}
+
+	if h.displayTZ == nil {
+		return time.UTC
+	}
+	return h.displayTZ
+}
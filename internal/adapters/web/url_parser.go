@@ -2,6 +2,7 @@ package web
 
 import (
 	"regexp"
+	"strconv"
 
 	"sumariza-ai/internal/domain"
 )
@@ -9,10 +10,16 @@ import (
 // tweetURLRegex matches Twitter/X URLs and extracts username and tweet ID.
 // Accepts twitter.com, x.com, and mobile.twitter.com.
 // Query parameters are preserved in the URL but ignored during parsing.
+// The ID must end the path segment, so "/status/123abc" is rejected instead
+// of being truncated to "123".
 var tweetURLRegex = regexp.MustCompile(
-	`^https?://(twitter\.com|x\.com|mobile\.twitter\.com)/(\w+)/status/(\d+)`,
+	`^https?://(twitter\.com|x\.com|mobile\.twitter\.com)/(\w+)/status/(\d+)(?:[/?#]|$)`,
 )
 
+// photoURLRegex matches the /photo/N suffix of a link to one image of a
+// tweet. Tweets carry at most 4 photos.
+var photoURLRegex = regexp.MustCompile(`/status/\d+/photo/([1-4])(?:[/?#]|$)`)
+
 // ParseTweetURL extracts the username and tweet ID from a Twitter/X URL.
 // Returns domain.ErrInvalidURL if the URL format is invalid.
 func ParseTweetURL(url string) (username string, tweetID string, err error) {
@@ -23,3 +30,14 @@ func ParseTweetURL(url string) (username string, tweetID string, err error) {
 	return matches[2], matches[3], nil
 }
 
+// ParsePhotoIndex returns the 1-based photo index of a link to one image of
+// a tweet (".../status/123/photo/3"), or 0 when the link has none.
+func ParsePhotoIndex(url string) int {
+	matches := photoURLRegex.FindStringSubmatch(url)
+	if matches == nil {
+		return 0
+	}
+	index, _ := strconv.Atoi(matches[1])
+	return index
+}
+
@@ -2,6 +2,7 @@ package web_test
 
 import (
 	"testing"
+	"unicode"
 
 	"sumariza-ai/internal/adapters/web"
 	"sumariza-ai/internal/domain"
@@ -124,6 +125,7 @@ func TestParseTweetURL_InvalidURL_ReturnsError(t *testing.T) {
 		{name: "empty string", url: ""},
 		{name: "twitter without id", url: "https://twitter.com/user/status/"},
 		{name: "non-numeric id", url: "https://twitter.com/user/status/abc"},
+		{name: "id with trailing letters", url: "https://twitter.com/user/status/123abc"},
 	}
 
 	for _, tc := range testCases {
@@ -160,3 +162,96 @@ func TestParseTweetURL_HTTPWithoutS_ReturnsUsernameAndID(t *testing.T) {
 	}
 }
 
+func FuzzParseTweetURL(f *testing.F) {
+	// Seed with the table cases above
+	seeds := []string{
+		"https://twitter.com/elonmusk/status/1234567890123456789",
+		"https://x.com/acgfbr/status/2006396789411172607",
+		"https://mobile.twitter.com/jack/status/20",
+		"https://twitter.com/user/status/123456?s=20",
+		"https://x.com/test/status/789?t=xyz&s=20",
+		"http://twitter.com/user/status/123",
+		"https://google.com",
+		"https://twitter.com/user",
+		"https://twitter.com/user/",
+		"not a url",
+		"",
+		"https://twitter.com/user/status/",
+		"https://twitter.com/user/status/abc",
+		"https://twitter.com/user/status/123abc",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, url string) {
+		username, id, err := web.ParseTweetURL(url)
+		if err != nil {
+			if err != domain.ErrInvalidURL {
+				t.Fatalf("URL %q: expected ErrInvalidURL, got %v", url, err)
+			}
+			return
+		}
+
+		if username == "" || id == "" {
+			t.Fatalf("URL %q: accepted with empty username %q or id %q", url, username, id)
+		}
+		for _, r := range id {
+			if r > unicode.MaxASCII || !unicode.IsDigit(r) {
+				t.Fatalf("URL %q: id %q is not numeric", url, id)
+			}
+		}
+
+		// Canonical URL must round-trip to the same pair
+		canonical := "https://x.com/" + username + "/status/" + id
+		gotUsername, gotID, err := web.ParseTweetURL(canonical)
+		if err != nil {
+			t.Fatalf("canonical URL %q failed to parse: %v", canonical, err)
+		}
+		if gotUsername != username || gotID != id {
+			t.Fatalf("canonical URL %q: got (%q, %q), want (%q, %q)", canonical, gotUsername, gotID, username, id)
+		}
+	})
+}
+
+func TestParsePhotoIndex(t *testing.T) {
+	tests := []struct {
+		url  string
+		want int
+	}{
+		{url: "https://x.com/acgfbr/status/2006396789411172607/photo/3", want: 3},
+		{url: "https://twitter.com/elonmusk/status/123/photo/1?s=20", want: 1},
+		{url: "https://x.com/acgfbr/status/2006396789411172607", want: 0},
+		{url: "https://x.com/acgfbr/status/123/photo/5", want: 0},
+		{url: "https://x.com/acgfbr/status/123/photo/12", want: 0},
+		{url: "https://x.com/acgfbr/status/123/video/1", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			// Act
+			got := web.ParsePhotoIndex(tt.url)
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("ParsePhotoIndex(%q) = %d, want %d", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTweetURL_PhotoURL_ReturnsUsernameAndID(t *testing.T) {
+	// Arrange
+	url := "https://x.com/acgfbr/status/2006396789411172607/photo/2"
+
+	// Act
+	username, id, err := web.ParseTweetURL(url)
+
+	// Assert
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if username != "acgfbr" || id != "2006396789411172607" {
+		t.Errorf("got %q %q", username, id)
+	}
+}
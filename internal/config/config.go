@@ -0,0 +1,351 @@
+// Package config loads the server configuration from the environment.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"sumariza-ai/pkg/log"
+)
+
+// Defaults of settings whose zero value is not the default.
+const (
+	defaultPort                  = "3000"
+	defaultServiceName           = "sumariza-ai"
+	defaultIdleTimeout           = 5 * time.Minute
+	defaultBrowserStartAttempts  = 3
+	defaultBrowserLang           = "en-US"
+	defaultCacheTTL              = 5 * time.Minute
+	defaultEmojiMode             = "keep"
+	defaultGlobalScrapeRPS       = 1.0
+	defaultGlobalScrapeBurst     = 3
+	defaultRateLimit             = 10
+	defaultRateWindow            = time.Minute
+	defaultRateAlgorithm         = "timestamps"
+	defaultNotFoundMaxFailures   = 3
+	defaultNotFoundCoolDown      = 6 * time.Hour
+	defaultBatchConcurrency      = 2
+	defaultAlertFailureThreshold = 5
+	defaultAlertCoolDown         = 30 * time.Minute
+	defaultHTTPTimeout           = 10 * time.Second
+)
+
+// Config is the server configuration. See .env.example for every variable.
+type Config struct {
+	// Server
+	Port           string         // PORT
+	ServiceName    string         // SERVICE_NAME, logged on every entry
+	IsLocal        bool           // IS_LOCAL=1
+	LogLevel       log.Level      // LOG_LEVEL, else Debug locally and Info in production
+	StaticDir      string         // STATIC_DIR, empty for the default lookup
+	TrustedProxies []string       // TRUSTED_PROXIES
+	RobotsTxt      string         // ROBOTS_TXT, with \n turned into newlines
+	NoIndex        bool           // NOINDEX=1
+	DisplayTZ      *time.Location // DISPLAY_TZ
+	AdminToken     string         // ADMIN_TOKEN, empty disables admin endpoints
+	StatsEnabled   bool           // STATS_ENABLED=1
+
+	// Browser
+	IdleTimeout          time.Duration // BROWSER_IDLE_TIMEOUT
+	BrowserStartAttempts int           // BROWSER_START_ATTEMPTS
+	BrowserRestartAfter  int           // BROWSER_RESTART_AFTER, 0 never restarts
+	BrowserLang          string        // BROWSER_LANG, Chrome's UI and Accept-Language locale
+	Proxy                string        // HTTPS_PROXY or HTTP_PROXY, passed to Chrome
+
+	// Scraper
+	ScrapeSettle       time.Duration // SCRAPE_SETTLE_MS
+	EmojiMode          string        // EMOJI_MODE: keep, strip or unicode
+	MaxLinks           int           // MAX_LINKS_PER_TWEET, 0 for the scraper's default
+	BlockedLinkDomains []string      // BLOCKED_LINK_DOMAINS
+	StripLinkParams    []string      // STRIP_LINK_PARAMS
+
+	// Cache
+	CacheTTL            time.Duration // CACHE_TTL_MINUTES
+	CacheStaleGrace     time.Duration // CACHE_STALE_GRACE_MINUTES, 0 disables stale fallback
+	NotFoundMaxFailures int           // NOT_FOUND_MAX_FAILURES, 0 disables negative caching
+	NotFoundCoolDown    time.Duration // NOT_FOUND_COOLDOWN_MINUTES
+
+	// Scrape rate
+	GlobalScrapeRPS          float64       // GLOBAL_SCRAPE_RPS, 0 disables the global limit
+	GlobalScrapeBurst        int           // GLOBAL_SCRAPE_BURST
+	ScrapeMinInterval        time.Duration // SCRAPE_MIN_INTERVAL
+	ScrapeJitter             time.Duration // SCRAPE_JITTER
+	RateLimit                int           // RATE_LIMIT, scrapes per client IP and window
+	RateWindow               time.Duration // RATE_WINDOW
+	RateLimitMaxIPs          int           // RATE_LIMIT_MAX_IPS, 0 for the limiter's default
+	RateLimitCleanupInterval time.Duration // RATE_LIMIT_CLEANUP_INTERVAL, 0 for the limiter's default
+	RateAlgorithm            string        // RATE_LIMIT_ALGORITHM: timestamps or counter
+
+	// Tweets
+	BatchConcurrency int      // BATCH_CONCURRENCY
+	ContentAllowlist []string // CONTENT_ALLOWLIST
+	ContentDenylist  []string // CONTENT_DENYLIST
+
+	// Failure alerts
+	AlertWebhookURL       string        // ALERT_WEBHOOK_URL, empty disables alerts
+	AlertFailureThreshold int           // ALERT_FAILURE_THRESHOLD
+	AlertCoolDown         time.Duration // ALERT_COOLDOWN_MINUTES
+
+	// Outbound HTTP (alert webhooks)
+	HTTPTimeout time.Duration // HTTP_CLIENT_TIMEOUT
+}
+
+// LoadConfig reads the configuration from the environment. Unset variables
+// take their default; invalid ones are logged and take their default too,
+// except impossible values of PORT, CACHE_TTL_MINUTES and RATE_LIMIT, which
+// are returned as an error so a broken deployment fails fast.
+func LoadConfig() (Config, error) {
+	port, portErr := portEnv("PORT", defaultPort)
+	cacheTTL, cacheTTLErr := requiredMinutesEnv("CACHE_TTL_MINUTES", defaultCacheTTL)
+	rateLimit, rateLimitErr := requiredIntEnv("RATE_LIMIT", defaultRateLimit, 1)
+
+	cfg := Config{
+		Port:           port,
+		ServiceName:    ServiceName(),
+		IsLocal:        os.Getenv("IS_LOCAL") == "1",
+		StaticDir:      os.Getenv("STATIC_DIR"),
+		TrustedProxies: listEnv("TRUSTED_PROXIES"),
+		RobotsTxt:      strings.ReplaceAll(os.Getenv("ROBOTS_TXT"), `\n`, "\n"),
+		NoIndex:        os.Getenv("NOINDEX") == "1",
+		DisplayTZ:      locationEnv("DISPLAY_TZ"),
+		AdminToken:     os.Getenv("ADMIN_TOKEN"),
+		StatsEnabled:   os.Getenv("STATS_ENABLED") == "1",
+
+		IdleTimeout:          durationEnv("BROWSER_IDLE_TIMEOUT", defaultIdleTimeout, 1),
+		BrowserStartAttempts: intEnv("BROWSER_START_ATTEMPTS", defaultBrowserStartAttempts, 1),
+		BrowserRestartAfter:  intEnv("BROWSER_RESTART_AFTER", 0, 0),
+		BrowserLang:          stringEnv("BROWSER_LANG", defaultBrowserLang),
+		Proxy:                proxyEnv(),
+
+		ScrapeSettle:       time.Duration(intEnv("SCRAPE_SETTLE_MS", 0, 0)) * time.Millisecond,
+		EmojiMode:          oneOfEnv("EMOJI_MODE", defaultEmojiMode, "keep", "strip", "unicode"),
+		MaxLinks:           intEnv("MAX_LINKS_PER_TWEET", 0, 1),
+		BlockedLinkDomains: listEnv("BLOCKED_LINK_DOMAINS"),
+		StripLinkParams:    listEnv("STRIP_LINK_PARAMS"),
+
+		CacheTTL:            cacheTTL,
+		CacheStaleGrace:     minutesEnv("CACHE_STALE_GRACE_MINUTES", 0),
+		NotFoundMaxFailures: intEnv("NOT_FOUND_MAX_FAILURES", defaultNotFoundMaxFailures, 0),
+		NotFoundCoolDown:    minutesEnv("NOT_FOUND_COOLDOWN_MINUTES", defaultNotFoundCoolDown),
+
+		GlobalScrapeRPS:          floatEnv("GLOBAL_SCRAPE_RPS", defaultGlobalScrapeRPS),
+		GlobalScrapeBurst:        intEnv("GLOBAL_SCRAPE_BURST", defaultGlobalScrapeBurst, 1),
+		ScrapeMinInterval:        durationEnv("SCRAPE_MIN_INTERVAL", 0, 0),
+		ScrapeJitter:             durationEnv("SCRAPE_JITTER", 0, 0),
+		RateLimit:                rateLimit,
+		RateWindow:               durationEnv("RATE_WINDOW", defaultRateWindow, 1),
+		RateLimitMaxIPs:          intEnv("RATE_LIMIT_MAX_IPS", 0, 1),
+		RateLimitCleanupInterval: durationEnv("RATE_LIMIT_CLEANUP_INTERVAL", 0, 0),
+		RateAlgorithm:            oneOfEnv("RATE_LIMIT_ALGORITHM", defaultRateAlgorithm, "timestamps", "counter"),
+
+		BatchConcurrency: intEnv("BATCH_CONCURRENCY", defaultBatchConcurrency, 1),
+		ContentAllowlist: listEnv("CONTENT_ALLOWLIST"),
+		ContentDenylist:  listEnv("CONTENT_DENYLIST"),
+
+		AlertWebhookURL:       os.Getenv("ALERT_WEBHOOK_URL"),
+		AlertFailureThreshold: intEnv("ALERT_FAILURE_THRESHOLD", defaultAlertFailureThreshold, 1),
+		AlertCoolDown:         minutesEnv("ALERT_COOLDOWN_MINUTES", defaultAlertCoolDown),
+
+		HTTPTimeout: durationEnv("HTTP_CLIENT_TIMEOUT", defaultHTTPTimeout, 1),
+	}
+	cfg.LogLevel = logLevelEnv(cfg.IsLocal)
+
+	return cfg, errors.Join(portErr, cacheTTLErr, rateLimitErr)
+}
+
+// ServiceName returns SERVICE_NAME, or sumariza-ai if unset. It is
+// exported so the logger can be created before the rest of the config.
+func ServiceName() string {
+	return stringEnv("SERVICE_NAME", defaultServiceName)
+}
+
+// stringEnv returns the trimmed value of key, or def if it is unset or blank.
+func stringEnv(key, def string) string {
+	if value := strings.TrimSpace(os.Getenv(key)); value != "" {
+		return value
+	}
+	return def
+}
+
+// portEnv parses a TCP port (1-65535), or returns def if key is unset.
+func portEnv(key, def string) (string, error) {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return def, nil
+	}
+
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return def, fmt.Errorf("%s must be a port between 1 and 65535, got %q", key, value)
+	}
+
+	return value, nil
+}
+
+// requiredIntEnv parses an integer of at least minimum, or returns def if key
+// is unset. Invalid values are an error.
+func requiredIntEnv(key string, def, minimum int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return def, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < minimum {
+		return def, fmt.Errorf("%s must be an integer of at least %d, got %q", key, minimum, value)
+	}
+
+	return n, nil
+}
+
+// requiredMinutesEnv parses a non-negative number of minutes, or returns def
+// if key is unset. Invalid values are an error.
+func requiredMinutesEnv(key string, def time.Duration) (time.Duration, error) {
+	minutes, err := requiredIntEnv(key, int(def/time.Minute), 0)
+	if err != nil {
+		return def, fmt.Errorf("%s must be a non-negative number of minutes, got %q", key, os.Getenv(key))
+	}
+	return time.Duration(minutes) * time.Minute, nil
+}
+
+// oneOfEnv returns the lowercase value of key if it is one of allowed,
+// or def.
+func oneOfEnv(key, def string, allowed ...string) string {
+	value := strings.ToLower(strings.TrimSpace(os.Getenv(key)))
+	if value == "" {
+		return def
+	}
+
+	for _, a := range allowed {
+		if value == a {
+			return value
+		}
+	}
+
+	log.GlobalWarn("invalid "+key+", using default", "value", os.Getenv(key))
+	return def
+}
+
+// intEnv parses an integer of at least minimum, or returns def.
+func intEnv(key string, def, minimum int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < minimum {
+		log.GlobalWarn("invalid "+key+", using default", "value", value)
+		return def
+	}
+
+	return n
+}
+
+// floatEnv parses a non-negative number, or returns def.
+func floatEnv(key string, def float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f < 0 {
+		log.GlobalWarn("invalid "+key+", using default", "value", value)
+		return def
+	}
+
+	return f
+}
+
+// minutesEnv parses a non-negative number of minutes, or returns def.
+func minutesEnv(key string, def time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+
+	minutes, err := strconv.Atoi(value)
+	if err != nil || minutes < 0 {
+		log.GlobalWarn("invalid "+key+", using default", "value", value)
+		return def
+	}
+
+	return time.Duration(minutes) * time.Minute
+}
+
+// durationEnv parses a Go duration (e.g. "2s", "500ms") of at least minimum,
+// or returns def.
+func durationEnv(key string, def, minimum time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < minimum {
+		log.GlobalWarn("invalid "+key+", using default", "value", value)
+		return def
+	}
+
+	return d
+}
+
+// locationEnv loads an IANA timezone such as America/Sao_Paulo, or UTC.
+func locationEnv(key string) *time.Location {
+	value := os.Getenv(key)
+	if value == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(value)
+	if err != nil {
+		log.GlobalWarn("invalid "+key+", using default", "value", value)
+		return time.UTC
+	}
+
+	return loc
+}
+
+// listEnv returns the comma-separated values of key, trimmed, without blanks.
+func listEnv(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// proxyEnv returns the outbound proxy Chrome is started with.
+func proxyEnv() string {
+	for _, key := range []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy"} {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// logLevelEnv returns the minimum log level. An explicit LOG_LEVEL wins;
+// otherwise it is Debug in local environments, so developers get verbose
+// logs without extra config, and Info in production.
+func logLevelEnv(isLocal bool) log.Level {
+	if value := os.Getenv("LOG_LEVEL"); value != "" {
+		level, err := log.ParseLevel(value)
+		if err == nil {
+			return level
+		}
+		log.GlobalWarn("invalid LOG_LEVEL, using environment default", "value", value)
+	}
+
+	if isLocal {
+		return log.Debug
+	}
+	return log.Info
+}
@@ -0,0 +1,219 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"sumariza-ai/pkg/log"
+)
+
+// mustLoad loads the config, failing the test on an error.
+func mustLoad(t *testing.T) Config {
+	t.Helper()
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	return cfg
+}
+
+func TestLoadConfig_Fields(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	// Each field: its default, a valid value and an invalid one that falls
+	// back to the default. An empty invalid value means anything is accepted,
+	// or that invalid values are an error (see TestLoadConfig_ImpossibleValues).
+	tests := []struct {
+		key     string
+		field   func(Config) any
+		def     any
+		valid   string
+		want    any
+		invalid string
+	}{
+		{"PORT", func(c Config) any { return c.Port }, "3000", "8080", "8080", ""},
+		{"SERVICE_NAME", func(c Config) any { return c.ServiceName }, "sumariza-ai", "api", "api", ""},
+		{"IS_LOCAL", func(c Config) any { return c.IsLocal }, false, "1", true, ""},
+		{"STATIC_DIR", func(c Config) any { return c.StaticDir }, "", "/app/static", "/app/static", ""},
+		{"TRUSTED_PROXIES", func(c Config) any { return c.TrustedProxies }, []string(nil), "127.0.0.1, 10.0.0.0/8", []string{"127.0.0.1", "10.0.0.0/8"}, ""},
+		{"ROBOTS_TXT", func(c Config) any { return c.RobotsTxt }, "", `User-agent: *\nDisallow: /`, "User-agent: *\nDisallow: /", ""},
+		{"NOINDEX", func(c Config) any { return c.NoIndex }, false, "1", true, ""},
+		{"DISPLAY_TZ", func(c Config) any { return c.DisplayTZ }, time.UTC, "America/Sao_Paulo", saoPaulo, "Mars/Olympus"},
+		{"ADMIN_TOKEN", func(c Config) any { return c.AdminToken }, "", "secret", "secret", ""},
+		{"STATS_ENABLED", func(c Config) any { return c.StatsEnabled }, false, "1", true, ""},
+
+		{"BROWSER_IDLE_TIMEOUT", func(c Config) any { return c.IdleTimeout }, 5 * time.Minute, "90s", 90 * time.Second, "0s"},
+		{"BROWSER_START_ATTEMPTS", func(c Config) any { return c.BrowserStartAttempts }, 3, "5", 5, "0"},
+		{"BROWSER_RESTART_AFTER", func(c Config) any { return c.BrowserRestartAfter }, 0, "500", 500, "-1"},
+		{"BROWSER_LANG", func(c Config) any { return c.BrowserLang }, "en-US", " pt-BR ", "pt-BR", ""},
+
+		{"SCRAPE_SETTLE_MS", func(c Config) any { return c.ScrapeSettle }, time.Duration(0), "500", 500 * time.Millisecond, "soon"},
+		{"EMOJI_MODE", func(c Config) any { return c.EmojiMode }, "keep", "Unicode", "unicode", "sparkly"},
+		{"MAX_LINKS_PER_TWEET", func(c Config) any { return c.MaxLinks }, 0, "10", 10, "0"},
+		{"BLOCKED_LINK_DOMAINS", func(c Config) any { return c.BlockedLinkDomains }, []string(nil), "spam.com,,ads.net", []string{"spam.com", "ads.net"}, ""},
+		{"STRIP_LINK_PARAMS", func(c Config) any { return c.StripLinkParams }, []string(nil), " utm_ ,fbclid", []string{"utm_", "fbclid"}, ""},
+
+		{"CACHE_TTL_MINUTES", func(c Config) any { return c.CacheTTL }, 5 * time.Minute, "15", 15 * time.Minute, ""},
+		{"CACHE_STALE_GRACE_MINUTES", func(c Config) any { return c.CacheStaleGrace }, time.Duration(0), "60", time.Hour, "1h"},
+		{"NOT_FOUND_MAX_FAILURES", func(c Config) any { return c.NotFoundMaxFailures }, 3, "0", 0, "-1"},
+		{"NOT_FOUND_COOLDOWN_MINUTES", func(c Config) any { return c.NotFoundCoolDown }, 6 * time.Hour, "30", 30 * time.Minute, "later"},
+
+		{"GLOBAL_SCRAPE_RPS", func(c Config) any { return c.GlobalScrapeRPS }, 1.0, "0.5", 0.5, "-1"},
+		{"GLOBAL_SCRAPE_BURST", func(c Config) any { return c.GlobalScrapeBurst }, 3, "10", 10, "0"},
+		{"SCRAPE_MIN_INTERVAL", func(c Config) any { return c.ScrapeMinInterval }, time.Duration(0), "2s", 2 * time.Second, "-2s"},
+		{"SCRAPE_JITTER", func(c Config) any { return c.ScrapeJitter }, time.Duration(0), "500ms", 500 * time.Millisecond, "500"},
+		{"RATE_LIMIT", func(c Config) any { return c.RateLimit }, 10, "25", 25, ""},
+		{"RATE_WINDOW", func(c Config) any { return c.RateWindow }, time.Minute, "30s", 30 * time.Second, "60"},
+		{"RATE_LIMIT_MAX_IPS", func(c Config) any { return c.RateLimitMaxIPs }, 0, "500", 500, "0"},
+		{"RATE_LIMIT_CLEANUP_INTERVAL", func(c Config) any { return c.RateLimitCleanupInterval }, time.Duration(0), "10m", 10 * time.Minute, "often"},
+		{"RATE_LIMIT_ALGORITHM", func(c Config) any { return c.RateAlgorithm }, "timestamps", "COUNTER", "counter", "leaky"},
+
+		{"BATCH_CONCURRENCY", func(c Config) any { return c.BatchConcurrency }, 2, "4", 4, "0"},
+		{"CONTENT_ALLOWLIST", func(c Config) any { return c.ContentAllowlist }, []string(nil), "alice,123", []string{"alice", "123"}, ""},
+		{"CONTENT_DENYLIST", func(c Config) any { return c.ContentDenylist }, []string(nil), "bob", []string{"bob"}, ""},
+
+		{"ALERT_WEBHOOK_URL", func(c Config) any { return c.AlertWebhookURL }, "", "https://hooks.example.com/x", "https://hooks.example.com/x", ""},
+		{"ALERT_FAILURE_THRESHOLD", func(c Config) any { return c.AlertFailureThreshold }, 5, "2", 2, "0"},
+		{"ALERT_COOLDOWN_MINUTES", func(c Config) any { return c.AlertCoolDown }, 30 * time.Minute, "5", 5 * time.Minute, "-5"},
+
+		{"HTTP_CLIENT_TIMEOUT", func(c Config) any { return c.HTTPTimeout }, 10 * time.Second, "30s", 30 * time.Second, "0s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			cases := []struct {
+				name  string
+				value string
+				want  any
+			}{
+				{name: "unset", value: "", want: tt.def},
+				{name: "valid", value: tt.valid, want: tt.want},
+			}
+			if tt.invalid != "" {
+				cases = append(cases, struct {
+					name  string
+					value string
+					want  any
+				}{name: "invalid", value: tt.invalid, want: tt.def})
+			}
+
+			for _, tc := range cases {
+				// Arrange
+				t.Setenv(tt.key, tc.value)
+
+				// Act
+				got := tt.field(mustLoad(t))
+
+				// Assert
+				if !reflect.DeepEqual(got, tc.want) {
+					t.Errorf("%s %q: got %v, want %v", tc.name, tc.value, got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadConfig_LogLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		isLocal  string
+		logLevel string
+		want     log.Level
+	}{
+		{name: "local default", isLocal: "1", want: log.Debug},
+		{name: "production default", isLocal: "", want: log.Info},
+		{name: "local override", isLocal: "1", logLevel: "warn", want: log.Warn},
+		{name: "production override", isLocal: "", logLevel: "DEBUG", want: log.Debug},
+		{name: "invalid override falls back to local", isLocal: "1", logLevel: "chatty", want: log.Debug},
+		{name: "invalid override falls back to production", isLocal: "", logLevel: "chatty", want: log.Info},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			t.Setenv("IS_LOCAL", tt.isLocal)
+			t.Setenv("LOG_LEVEL", tt.logLevel)
+
+			// Act
+			got := mustLoad(t).LogLevel
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_Proxy_PrefersHTTPS(t *testing.T) {
+	// Arrange
+	for _, key := range []string{"https_proxy", "http_proxy"} {
+		t.Setenv(key, "")
+	}
+	t.Setenv("HTTP_PROXY", "http://plain:3128")
+	t.Setenv("HTTPS_PROXY", "http://secure:3128")
+
+	// Act
+	got := mustLoad(t).Proxy
+
+	// Assert
+	if got != "http://secure:3128" {
+		t.Errorf("got %q, want the HTTPS proxy", got)
+	}
+}
+
+func TestLoadConfig_ImpossibleValues(t *testing.T) {
+	tests := []struct {
+		key   string
+		value string
+	}{
+		{key: "PORT", value: "http"},
+		{key: "PORT", value: "0"},
+		{key: "PORT", value: "70000"},
+		{key: "CACHE_TTL_MINUTES", value: "-5"},
+		{key: "CACHE_TTL_MINUTES", value: "five"},
+		{key: "RATE_LIMIT", value: "0"},
+		{key: "RATE_LIMIT", value: "-1"},
+		{key: "RATE_LIMIT", value: "many"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			// Arrange
+			t.Setenv(tt.key, tt.value)
+
+			// Act
+			_, err := LoadConfig()
+
+			// Assert
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.key) || !strings.Contains(err.Error(), tt.value) {
+				t.Errorf("error %q should name %s and the value %q", err, tt.key, tt.value)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_ImpossibleValues_AllReported(t *testing.T) {
+	// Arrange
+	t.Setenv("PORT", "-1")
+	t.Setenv("CACHE_TTL_MINUTES", "-1")
+	t.Setenv("RATE_LIMIT", "0")
+
+	// Act
+	_, err := LoadConfig()
+
+	// Assert
+	for _, key := range []string{"PORT", "CACHE_TTL_MINUTES", "RATE_LIMIT"} {
+		if err == nil || !strings.Contains(err.Error(), key) {
+			t.Errorf("expected the error to mention %s, got %v", key, err)
+		}
+	}
+}
@@ -21,5 +21,15 @@ var (
 	// ErrTextNotFound is returned when essential tweet text is not found.
 	// This covers sensitive/age-gated content scenarios.
 	ErrTextNotFound = errors.New("essential tweet text not found")
+
+	// ErrBrowserUnavailable is returned when the headless browser can't be launched.
+	ErrBrowserUnavailable = errors.New("browser unavailable")
+
+	// ErrSensitiveContent is returned when Twitter hides the tweet behind a
+	// sensitive or age-restricted content warning.
+	ErrSensitiveContent = errors.New("tweet hidden behind a sensitive content warning")
+
+	// ErrBlockedContent is returned when the account or tweet is blocked by the content policy.
+	ErrBlockedContent = errors.New("content blocked by policy")
 )
 
@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+// ScrapeStatus classifies the outcome of fetching a tweet.
+type ScrapeStatus string
+
+const (
+	ScrapeSuccess   ScrapeStatus = "success"   // Tweet with every field
+	ScrapePartial   ScrapeStatus = "partial"   // Tweet with optional fields missing
+	ScrapeNotFound  ScrapeStatus = "not_found" // Tweet deleted or never existed
+	ScrapePrivate   ScrapeStatus = "private"   // Tweet from a protected account
+	ScrapeBlocked   ScrapeStatus = "blocked"   // Twitter served the page without the tweet (login wall)
+	ScrapeSensitive ScrapeStatus = "sensitive" // Tweet hidden behind a sensitive content warning
+	ScrapeError     ScrapeStatus = "error"     // Anything else: browser, network, timeout
+)
+
+// ScrapeResult is the typed outcome of a scrape. Tweet is set for
+// ScrapeSuccess and ScrapePartial; Err is set for every other status.
+type ScrapeResult struct {
+	Status ScrapeStatus
+	Tweet  *Tweet
+	Err    error
+
+	// Timeline holds how long each scrape step took, keyed by step name.
+	// Steps that didn't run are absent; nil when the scraper doesn't time them.
+	Timeline map[string]time.Duration
+}
+
+// NewScrapeResult classifies a (tweet, error) pair.
+func NewScrapeResult(tweet *Tweet, err error) ScrapeResult {
+	status := StatusFromError(err)
+	if err == nil && tweet != nil && tweet.Partial {
+		status = ScrapePartial
+	}
+	return ScrapeResult{Status: status, Tweet: tweet, Err: err}
+}
+
+// StatusFromError maps a scrape error to its status; nil is ScrapeSuccess.
+func StatusFromError(err error) ScrapeStatus {
+	switch err {
+	case nil:
+		return ScrapeSuccess
+	case ErrTweetNotFound:
+		return ScrapeNotFound
+	case ErrTweetPrivate:
+		return ScrapePrivate
+	case ErrTextNotFound:
+		return ScrapeBlocked
+	case ErrSensitiveContent:
+		return ScrapeSensitive
+	default:
+		return ScrapeError
+	}
+}
@@ -1,7 +1,14 @@
 // Package domain contains the core business entities and rules.
 package domain
 
-import "time"
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"strconv"
+	"time"
+)
 
 // Tweet represents a single Twitter/X post.
 type Tweet struct {
@@ -10,7 +17,77 @@ type Tweet struct {
 	Username string // Extracted from input URL
 	Author   Author
 	Content  Content
+	Metrics  Metrics
 	Partial  bool // True if some optional data is missing
+
+	// PartialReasons lists the missing fields when Partial is true
+	// (see the PartialReason* constants).
+	PartialReasons []string
+
+	// ResolvedURL is the tweet's canonical permalink as read from the page,
+	// with the author's real handle. Empty when the page didn't expose one.
+	ResolvedURL string
+}
+
+// ContentHash returns a stable hex SHA-256 over the tweet's meaningful
+// content: author handle, text, creation time, media and the quoted tweet.
+// Engagement metrics and scrape metadata are excluded, so the hash only
+// changes when the tweet itself does (e.g. an edit).
+func (t *Tweet) ContentHash() string {
+	createdAt := ""
+	if !t.Content.CreatedAt.IsZero() {
+		createdAt = t.Content.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	h := sha256.New()
+	writeHashField(h, t.Author.Handle)
+	writeHashField(h, t.Content.Text)
+	writeHashField(h, createdAt)
+
+	for _, item := range t.Content.Media {
+		writeHashField(h, "media")
+		writeHashField(h, string(item.Type))
+		writeHashField(h, item.URL)
+		writeHashField(h, item.AltText)
+	}
+
+	if quoted := t.Content.QuotedTweet; quoted != nil {
+		writeHashField(h, "quote")
+		writeHashField(h, quoted.ID)
+		writeHashField(h, quoted.Author.Handle)
+		writeHashField(h, quoted.Text)
+		writeHashField(h, strconv.FormatBool(quoted.HasMedia))
+		writeHashField(h, strconv.FormatBool(quoted.Unavailable))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeHashField writes a length-prefixed field, so adjacent fields can't
+// run into each other ("ab"+"c" vs "a"+"bc").
+func writeHashField(h hash.Hash, field string) {
+	var size [8]byte
+	binary.BigEndian.PutUint64(size[:], uint64(len(field)))
+	h.Write(size[:])
+	h.Write([]byte(field))
+}
+
+// Reasons reported in Tweet.PartialReasons.
+const (
+	PartialReasonAuthorName   = "author_name"
+	PartialReasonAuthorHandle = "author_handle"
+	PartialReasonAvatar       = "avatar"
+
+	// PartialReasonText means the tweet text came from the page's
+	// og:description meta tag, which X truncates and strips of links.
+	PartialReasonText = "text"
+)
+
+// Metrics represents the tweet's engagement counts.
+// Zero means the count was not shown on the page.
+type Metrics struct {
+	Views     int64 // Impressions ("Views" on X)
+	Bookmarks int64
 }
 
 // Author represents the tweet author's information.
@@ -38,17 +115,113 @@ type Content struct {
 	CreatedAt   time.Time
 	QuotedTweet *QuotedTweet  // Limited to 1 level only
 	Direction   TextDirection // LTR or RTL - extracted from Twitter's dir attribute
+
+	// Truncated is true when the page still showed a "Show more" link, so
+	// Text holds only the visible prefix of a long tweet.
+	Truncated bool
+
+	// InReplyToID and InReplyToURL identify the tweet this one replies to,
+	// when the page links it. Both are empty for tweets that aren't replies.
+	InReplyToID  string
+	InReplyToURL string
+
+	// Replies are the replies rendered below the tweet, in Twitter's order.
+	// The author's own thread continuation is not included.
+	Replies []ReplyItem
+
+	// Tokens splits Text into typed segments, in order, for renderers that
+	// style links, mentions, hashtags and emoji differently.
+	Tokens []Token
+
+	// Media lists the tweet's photos, videos and GIFs in display order.
+	// Media of a quoted tweet is not included.
+	Media []MediaItem
 }
 
-// QuotedTweet represents a quoted tweet within the main tweet.
-// Any quotes inside this quoted tweet are ignored (no recursive parsing).
-type QuotedTweet struct {
+// MediaItem is a photo, video or GIF attached to a tweet.
+type MediaItem struct {
+	Type MediaType
+
+	// URL is the photo, or the video file when the page exposes one
+	// (players often stream from a blob: URL instead; URL is then empty).
+	URL string
+
+	// PosterURL is the thumbnail shown before a video or GIF plays.
+	PosterURL string
+
+	// AltText is the description set by the author, if any.
+	AltText string
+
+	// Width and Height are the media's size in pixels, zero when unknown.
+	Width  int
+	Height int
+}
+
+// MediaType is the kind of a MediaItem.
+type MediaType string
+
+const (
+	MediaPhoto MediaType = "photo"
+	MediaVideo MediaType = "video"
+	MediaGIF   MediaType = "gif"
+)
+
+// Token is a segment of tweet text.
+type Token struct {
+	Kind TokenKind
+
+	// Value is the segment's payload: the text itself, a link's full URL,
+	// a mention's handle or a hashtag's tag (both without @ or #), or the
+	// rendered emoji.
+	Value string
+}
+
+// TokenKind is the type of a Token.
+type TokenKind string
+
+const (
+	TokenText    TokenKind = "text"
+	TokenLink    TokenKind = "link"
+	TokenMention TokenKind = "mention"
+	TokenHashtag TokenKind = "hashtag"
+	TokenEmoji   TokenKind = "emoji"
+)
+
+// ReplyItem is a reply shown on the tweet's page.
+type ReplyItem struct {
 	ID     string
-	URL    string
 	Author Author
 	Text   string
 }
 
+// FeedItem is a tweet listed on a profile timeline.
+type FeedItem struct {
+	ID        string
+	Author    Author
+	Text      string
+	CreatedAt time.Time
+
+	// Pinned is true for the tweet the profile pinned to the top. It is
+	// shown first whatever its date, so consumers sorting by CreatedAt
+	// should handle it apart.
+	Pinned bool
+}
+
+// QuotedTweet represents a quoted tweet within the main tweet.
+// Any quotes inside this quoted tweet are ignored (no recursive parsing).
+type QuotedTweet struct {
+	ID       string
+	URL      string
+	Author   Author
+	Text     string
+	HasMedia bool // True if the quoted tweet has images or video
+
+	// Unavailable is true when the quoted tweet was deleted or is private
+	// and Twitter shows a placeholder instead. Only the fact that a quote
+	// existed is known then: every other field is empty.
+	Unavailable bool
+}
+
 // TextDirection represents the text direction (LTR or RTL).
 type TextDirection string
 
@@ -0,0 +1,90 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"sumariza-ai/internal/domain"
+)
+
+func newHashTweet() *domain.Tweet {
+	return &domain.Tweet{
+		ID:       "123",
+		Username: "user",
+		Author:   domain.Author{Name: "User", Handle: "user"},
+		Content: domain.Content{
+			Text:      "Hello world",
+			CreatedAt: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+			Media: []domain.MediaItem{
+				{Type: domain.MediaPhoto, URL: "https://pbs.twimg.com/media/a.jpg", AltText: "A cat"},
+			},
+			QuotedTweet: &domain.QuotedTweet{
+				Author: domain.Author{Handle: "other"},
+				Text:   "Quoted",
+			},
+		},
+		Metrics: domain.Metrics{Views: 10, Bookmarks: 1},
+	}
+}
+
+func TestTweet_ContentHash_StableAcrossScrapes(t *testing.T) {
+	// Arrange: a second scrape of the same tweet with new metrics, a new
+	// avatar URL and the timestamp in another zone
+	first := newHashTweet()
+	second := newHashTweet()
+	second.Metrics = domain.Metrics{Views: 5000, Bookmarks: 40}
+	second.Author.AvatarURL = "https://example.com/new.jpg"
+	second.Content.CreatedAt = first.Content.CreatedAt.In(time.FixedZone("BRT", -3*60*60))
+	second.Partial = true
+
+	// Act
+	firstHash := first.ContentHash()
+	secondHash := second.ContentHash()
+
+	// Assert
+	if firstHash != secondHash {
+		t.Errorf("hash changed for unchanged content: %s vs %s", firstHash, secondHash)
+	}
+	if len(firstHash) != 64 {
+		t.Errorf("expected hex SHA-256, got %q", firstHash)
+	}
+}
+
+func TestTweet_ContentHash_ChangesWithContent(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(*domain.Tweet)
+	}{
+		{name: "text edit", change: func(tw *domain.Tweet) { tw.Content.Text = "Hello world!" }},
+		{name: "quoted text", change: func(tw *domain.Tweet) { tw.Content.QuotedTweet.Text = "Edited" }},
+		{name: "quote removed", change: func(tw *domain.Tweet) { tw.Content.QuotedTweet = nil }},
+		{name: "media url", change: func(tw *domain.Tweet) { tw.Content.Media[0].URL = "https://pbs.twimg.com/media/b.jpg" }},
+		{name: "media alt text", change: func(tw *domain.Tweet) { tw.Content.Media[0].AltText = "A dog" }},
+		{name: "media added", change: func(tw *domain.Tweet) {
+			tw.Content.Media = append(tw.Content.Media, domain.MediaItem{Type: domain.MediaVideo})
+		}},
+		{name: "media removed", change: func(tw *domain.Tweet) { tw.Content.Media = nil }},
+		{name: "quoted media", change: func(tw *domain.Tweet) { tw.Content.QuotedTweet.HasMedia = true }},
+		{name: "field boundary", change: func(tw *domain.Tweet) {
+			tw.Author.Handle = "userHello"
+			tw.Content.Text = " world"
+		}},
+	}
+
+	base := newHashTweet().ContentHash()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tweet := newHashTweet()
+			tt.change(tweet)
+
+			// Act
+			hash := tweet.ContentHash()
+
+			// Assert
+			if hash == base {
+				t.Errorf("expected hash to change after %s", tt.name)
+			}
+		})
+	}
+}
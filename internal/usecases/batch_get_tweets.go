@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"context"
+	"sync"
+
+	"sumariza-ai/internal/domain"
+)
+
+// TweetRef identifies a tweet by author and ID.
+type TweetRef struct {
+	Username string
+	TweetID  string
+}
+
+// BatchResult is the outcome of retrieving one tweet of a batch.
+type BatchResult struct {
+	Ref   TweetRef
+	Tweet *domain.Tweet
+	Err   error
+}
+
+// BatchGetTweetsUseCase retrieves several tweets with bounded concurrency.
+// The bound should match the browser's real parallelism so queued requests
+// don't pile up contexts waiting for the same tab.
+type BatchGetTweetsUseCase struct {
+	getTweet    *GetTweetUseCase
+	concurrency int
+}
+
+// NewBatchGetTweetsUseCase creates a new BatchGetTweetsUseCase.
+// Concurrency below 1 is treated as 1.
+func NewBatchGetTweetsUseCase(getTweet *GetTweetUseCase, concurrency int) *BatchGetTweetsUseCase {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &BatchGetTweetsUseCase{
+		getTweet:    getTweet,
+		concurrency: concurrency,
+	}
+}
+
+// Execute retrieves all refs using a fixed-size worker pool.
+// Results are returned in the same order as refs.
+func (uc *BatchGetTweetsUseCase) Execute(ctx context.Context, refs []TweetRef) []BatchResult {
+	results := make([]BatchResult, len(refs))
+	jobs := make(chan int)
+
+	workers := uc.concurrency
+	if workers > len(refs) {
+		workers = len(refs)
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				ref := refs[i]
+				results[i].Ref = ref
+				if err := ctx.Err(); err != nil {
+					results[i].Err = err
+					continue
+				}
+				results[i].Tweet, results[i].Err = uc.getTweet.Get(ctx, ref.TweetID, ref.Username)
+			}
+		}()
+	}
+
+	for i := range refs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
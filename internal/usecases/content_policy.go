@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"strings"
+
+	"sumariza-ai/internal/domain"
+)
+
+// ContentPolicy decides which accounts and tweets may be served.
+// Entries are either account handles (case-insensitive, optional leading @)
+// or numeric tweet IDs. Deny takes precedence over allow, and an empty
+// allow-list allows everything that is not denied.
+type ContentPolicy struct {
+	allow map[string]struct{}
+	deny  map[string]struct{}
+}
+
+// NewContentPolicy creates a policy from allow and deny entries.
+func NewContentPolicy(allow, deny []string) *ContentPolicy {
+	return &ContentPolicy{
+		allow: policySet(allow),
+		deny:  policySet(deny),
+	}
+}
+
+// Allows reports whether the tweet may be served.
+// A nil policy allows everything.
+func (p *ContentPolicy) Allows(username, tweetID string) bool {
+	if p == nil {
+		return true
+	}
+
+	if p.matches(p.deny, username, tweetID) {
+		return false
+	}
+	if len(p.allow) == 0 {
+		return true
+	}
+	return p.matches(p.allow, username, tweetID)
+}
+
+// AllowsTweet is Allows for a fetched tweet, checked against its actual
+// author. X serves a tweet under any handle in the URL, so the URL's
+// username alone can't enforce the policy. A tweet whose author wasn't
+// scraped is allowed: its URL was already checked.
+func (p *ContentPolicy) AllowsTweet(tweet *domain.Tweet) bool {
+	if p == nil || tweet == nil || tweet.Author.Handle == "" {
+		return true
+	}
+	return p.Allows(tweet.Author.Handle, tweet.ID)
+}
+
+// matches reports whether the username or tweet ID is in the set.
+func (p *ContentPolicy) matches(set map[string]struct{}, username, tweetID string) bool {
+	if _, ok := set[normalizePolicyEntry(username)]; ok {
+		return true
+	}
+	_, ok := set[tweetID]
+	return ok
+}
+
+// policySet builds a lookup set from raw entries, skipping blanks.
+func policySet(entries []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(entries))
+	for _, entry := range entries {
+		entry = normalizePolicyEntry(entry)
+		if entry != "" {
+			set[entry] = struct{}{}
+		}
+	}
+	return set
+}
+
+// normalizePolicyEntry trims whitespace and a leading @ and lowercases handles.
+func normalizePolicyEntry(entry string) string {
+	entry = strings.TrimSpace(entry)
+	entry = strings.TrimPrefix(entry, "@")
+	return strings.ToLower(entry)
+}
@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"sumariza-ai/internal/domain"
+	"sumariza-ai/pkg/log"
+)
+
+// FailureAlert describes a run of consecutive scrape failures.
+type FailureAlert struct {
+	ConsecutiveFailures int
+	LastError           string
+	At                  time.Time
+}
+
+// FailureAlerter notifies operators that scraping keeps failing.
+type FailureAlerter interface {
+	Alert(ctx context.Context, alert FailureAlert) error
+}
+
+// FailureAlertPolicy decides when consecutive scrape failures are alerted.
+type FailureAlertPolicy struct {
+	// Threshold is how many failures in a row trigger an alert.
+	// Zero disables alerting.
+	Threshold int
+
+	// CoolDown is the minimum time between two alerts.
+	CoolDown time.Duration
+}
+
+// failureAlarm counts consecutive scrape failures and alerts once they
+// reach the policy's threshold, at most once per cool-down.
+type failureAlarm struct {
+	alerter FailureAlerter
+	policy  FailureAlertPolicy
+
+	mu        sync.Mutex
+	failures  int
+	lastAlert time.Time
+}
+
+// SetFailureAlerter enables alerting on consecutive scrape failures.
+// A nil alerter disables it.
+func (uc *ScrapeTweetUseCase) SetFailureAlerter(alerter FailureAlerter, policy FailureAlertPolicy) {
+	if alerter == nil || policy.Threshold <= 0 {
+		uc.alarm = nil
+		return
+	}
+	uc.alarm = &failureAlarm{alerter: alerter, policy: policy}
+}
+
+// recordSuccess resets the failure run.
+func (a *failureAlarm) recordSuccess() {
+	if a == nil {
+		return
+	}
+
+	a.mu.Lock()
+	a.failures = 0
+	a.mu.Unlock()
+}
+
+// recordFailure counts err and sends an alert in the background once the
+// threshold is reached and the last alert is older than the cool-down.
+// Failures specific to one tweet (deleted, private, sensitive) and canceled
+// requests say nothing about the scraper's health and are not counted.
+func (a *failureAlarm) recordFailure(ctx context.Context, err error) {
+	if a == nil || !isSystemicFailure(err) {
+		return
+	}
+
+	a.mu.Lock()
+	a.failures++
+	now := time.Now()
+	fire := a.failures >= a.policy.Threshold &&
+		(a.lastAlert.IsZero() || now.Sub(a.lastAlert) >= a.policy.CoolDown)
+	if fire {
+		a.lastAlert = now
+	}
+	alert := FailureAlert{ConsecutiveFailures: a.failures, LastError: err.Error(), At: now}
+	a.mu.Unlock()
+
+	if !fire {
+		return
+	}
+
+	log.GlobalWarnCtx(ctx, "scraping keeps failing, sending alert", "failures", alert.ConsecutiveFailures)
+	alertCtx := context.WithoutCancel(ctx)
+	go func() {
+		if err := a.alerter.Alert(alertCtx, alert); err != nil {
+			log.GlobalErrorCtx(alertCtx, "failure alert not sent", "error", err)
+		}
+	}()
+}
+
+// isSystemicFailure reports whether err hints at a broken scraper rather
+// than an unavailable tweet.
+func isSystemicFailure(err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrTweetNotFound),
+		errors.Is(err, domain.ErrTweetPrivate),
+		errors.Is(err, domain.ErrSensitiveContent),
+		errors.Is(err, context.Canceled):
+		return false
+	}
+	return true
+}
@@ -2,49 +2,222 @@ package usecases
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"sumariza-ai/internal/domain"
+	"sumariza-ai/pkg/clock"
 	"sumariza-ai/pkg/log"
+	"sumariza-ai/pkg/stats"
 )
 
 // TweetCache defines the interface for caching tweets.
 type TweetCache interface {
 	Get(username, tweetID string) (*domain.Tweet, bool)
+	// GetWithMeta is like Get but also returns the entry's timestamps.
+	GetWithMeta(username, tweetID string) (*domain.Tweet, CacheMeta, bool)
 	Set(username, tweetID string, tweet *domain.Tweet)
 }
 
+// CacheMeta describes a cached entry.
+type CacheMeta struct {
+	ScrapedAt time.Time // When the tweet was stored
+	ExpiresAt time.Time // When the entry stops being served
+	Stale     bool      // Served past ExpiresAt because scraping failed
+}
+
 // GetTweetUseCase handles retrieving tweets with cache-first strategy.
 type GetTweetUseCase struct {
 	cache   TweetCache
 	scraper *ScrapeTweetUseCase
+	policy  *ContentPolicy
+
+	// Negative caching of ErrTextNotFound (see SetNotFoundPolicy)
+	failures FailureCache
+	notFound NotFoundPolicy
+
+	// Expired entries served when scraping fails (see SetStaleFallback)
+	stale StaleCache
+
+	onScraped ScrapedHook
+	stats     *stats.Registry
+	clock     clock.Clock
 }
 
+// ScrapedHook receives every freshly scraped tweet, e.g. to feed a search
+// index. It runs in its own goroutine with a context that outlives the
+// request, and must not modify the tweet, which is also cached.
+type ScrapedHook func(ctx context.Context, tweet *domain.Tweet)
+
 // NewGetTweetUseCase creates a new GetTweetUseCase.
 func NewGetTweetUseCase(cache TweetCache, scraper *ScrapeTweetUseCase) *GetTweetUseCase {
 	return &GetTweetUseCase{
 		cache:   cache,
 		scraper: scraper,
+		clock:   clock.Real(),
+	}
+}
+
+// SetPolicy sets the allow/deny policy checked before serving a tweet.
+// A nil policy allows everything.
+func (uc *GetTweetUseCase) SetPolicy(policy *ContentPolicy) {
+	uc.policy = policy
+}
+
+// SetOnScraped sets a hook called after each successful scrape, never for
+// cache hits. It runs asynchronously so it can't slow responses down.
+// A nil hook disables it.
+func (uc *GetTweetUseCase) SetOnScraped(hook ScrapedHook) {
+	uc.onScraped = hook
+}
+
+// SetStats sets the registry counting cache hits and misses. A nil registry
+// disables counting.
+func (uc *GetTweetUseCase) SetStats(registry *stats.Registry) {
+	uc.stats = registry
+}
+
+// SetClock replaces the clock used to age failures and cache entries (for
+// tests). Pass the clock of the cache so both agree on the time.
+func (uc *GetTweetUseCase) SetClock(clk clock.Clock) {
+	uc.clock = clk
+}
+
+// GetTweetOptions controls how a tweet is retrieved.
+// The zero value is the default cache-first behavior.
+type GetTweetOptions struct {
+	// BypassCache skips the cache read and always scrapes.
+	// The fresh result is still written to the cache.
+	BypassCache bool
+
+	// MaxAge rejects cache entries scraped longer ago than this and
+	// re-scrapes instead. Zero accepts any unexpired entry.
+	MaxAge time.Duration
+}
+
+// Get retrieves a tweet with default options (cache first, then scrape).
+func (uc *GetTweetUseCase) Get(ctx context.Context, tweetID, username string) (*domain.Tweet, error) {
+	return uc.Execute(ctx, tweetID, username, GetTweetOptions{})
+}
+
+// Execute retrieves a tweet according to opts, checking cache first before scraping.
+func (uc *GetTweetUseCase) Execute(ctx context.Context, tweetID, username string, opts GetTweetOptions) (*domain.Tweet, error) {
+	tweet, _, err := uc.ExecuteWithMeta(ctx, tweetID, username, opts)
+	return tweet, err
+}
+
+// Cached returns the tweet only if it is already cached and allowed by the
+// policy. It never scrapes, so it suits pages that must render immediately.
+func (uc *GetTweetUseCase) Cached(tweetID, username string) (*domain.Tweet, bool) {
+	if !uc.policy.Allows(username, tweetID) {
+		return nil, false
+	}
+	tweet, found := uc.cache.Get(username, tweetID)
+	if !found || !uc.policy.AllowsTweet(tweet) {
+		return nil, false
 	}
+	return tweet, true
 }
 
-// Execute retrieves a tweet, checking cache first before scraping.
-func (uc *GetTweetUseCase) Execute(ctx context.Context, tweetID, username string) (*domain.Tweet, error) {
+// ExecuteWithMeta is like Execute but also returns the cache entry's metadata
+// when the tweet was served from cache, marked Stale if it had expired and
+// scraping failed. The meta is nil for a fresh scrape.
+func (uc *GetTweetUseCase) ExecuteWithMeta(ctx context.Context, tweetID, username string, opts GetTweetOptions) (*domain.Tweet, *CacheMeta, error) {
+	// Blocked content is never served, even from cache
+	if !uc.policy.Allows(username, tweetID) {
+		log.GlobalInfoCtx(ctx, "tweet blocked by content policy", "username", username, "tweet_id", tweetID)
+		return nil, nil, domain.ErrBlockedContent
+	}
+
 	// Check cache first (key is normalized: /{username}/status/{id})
-	if tweet, found := uc.cache.Get(username, tweetID); found {
-		log.GlobalDebugCtx(ctx, "cache hit", "username", username, "tweet_id", tweetID)
-		return tweet, nil
+	if tweet, meta, found := uc.fromCache(ctx, tweetID, username, opts); found {
+		if err := uc.checkAuthor(ctx, tweet); err != nil {
+			return nil, nil, err
+		}
+		return tweet, &meta, nil
 	}
 
-	log.GlobalDebugCtx(ctx, "cache miss, scraping", "username", username, "tweet_id", tweetID)
+	// Login-gated tweets are not retried until their cool-down ends
+	if uc.coolingDown(ctx, tweetID) {
+		return uc.staleOr(ctx, tweetID, username, domain.ErrTextNotFound)
+	}
 
 	// Cache miss: scrape
 	tweet, err := uc.scraper.Execute(ctx, tweetID, username)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, domain.ErrTextNotFound) {
+			uc.recordNotFound(ctx, tweetID)
+		}
+		return uc.staleOr(ctx, tweetID, username, err)
 	}
+	uc.clearNotFound(tweetID)
 
-	// Store in cache with normalized key
+	// Store in cache with normalized key. A tweet by a blocked author is
+	// cached too, so repeated requests don't scrape it again
 	uc.cache.Set(username, tweetID, tweet)
+	if err := uc.checkAuthor(ctx, tweet); err != nil {
+		return nil, nil, err
+	}
+	uc.notifyScraped(ctx, tweet)
+
+	return tweet, nil, nil
+}
+
+// checkAuthor returns domain.ErrBlockedContent if the policy denies the
+// tweet's actual author, whatever handle the URL used.
+func (uc *GetTweetUseCase) checkAuthor(ctx context.Context, tweet *domain.Tweet) error {
+	if uc.policy.AllowsTweet(tweet) {
+		return nil
+	}
+	log.GlobalInfoCtx(ctx, "tweet blocked by content policy",
+		"author", tweet.Author.Handle,
+		"tweet_id", tweet.ID)
+	return domain.ErrBlockedContent
+}
+
+// notifyScraped runs the scraped hook, if any, in the background.
+// A panicking hook is logged instead of crashing the server.
+func (uc *GetTweetUseCase) notifyScraped(ctx context.Context, tweet *domain.Tweet) {
+	if uc.onScraped == nil {
+		return
+	}
+
+	hookCtx := context.WithoutCancel(ctx)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.GlobalErrorCtx(hookCtx, "scraped hook panicked", "tweet_id", tweet.ID, "panic", r)
+			}
+		}()
+		uc.onScraped(hookCtx, tweet)
+	}()
+}
+
+// fromCache returns the cached tweet if opts allow serving it.
+func (uc *GetTweetUseCase) fromCache(ctx context.Context, tweetID, username string, opts GetTweetOptions) (*domain.Tweet, CacheMeta, bool) {
+	if opts.BypassCache {
+		log.GlobalDebugCtx(ctx, "cache bypassed, scraping", "username", username, "tweet_id", tweetID)
+		return nil, CacheMeta{}, false
+	}
+
+	tweet, meta, found := uc.cache.GetWithMeta(username, tweetID)
+	if !found {
+		log.GlobalDebugCtx(ctx, "cache miss, scraping", "username", username, "tweet_id", tweetID)
+		uc.stats.CacheMiss()
+		return nil, CacheMeta{}, false
+	}
+
+	if age := uc.clock.Now().Sub(meta.ScrapedAt); opts.MaxAge > 0 && age > opts.MaxAge {
+		log.GlobalDebugCtx(ctx, "cache entry too old, scraping",
+			"username", username,
+			"tweet_id", tweetID,
+			"age_ms", age.Milliseconds(),
+			"max_age_ms", opts.MaxAge.Milliseconds())
+		uc.stats.CacheMiss()
+		return nil, CacheMeta{}, false
+	}
 
-	return tweet, nil
+	log.GlobalDebugCtx(ctx, "cache hit", "username", username, "tweet_id", tweetID)
+	uc.stats.CacheHit()
+	return tweet, meta, true
 }
@@ -0,0 +1,76 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"sumariza-ai/pkg/log"
+)
+
+// FailureCache tracks "text not found" scrape failures per tweet ID.
+type FailureCache interface {
+	// RecordFailure counts a failure for tweetID and returns the new count.
+	RecordFailure(tweetID string) int
+	// Failures returns the failure count and when the last failure happened.
+	Failures(tweetID string) (count int, lastFailure time.Time)
+	// ClearFailures forgets the failures of tweetID.
+	ClearFailures(tweetID string)
+}
+
+// NotFoundPolicy stops re-scraping tweets whose text is repeatedly missing,
+// which almost always means the tweet is login-gated.
+type NotFoundPolicy struct {
+	// MaxFailures is how many ErrTextNotFound results trigger the cool-down.
+	// Zero disables the policy.
+	MaxFailures int
+
+	// CoolDown is how long retries stay suspended after the last failure.
+	CoolDown time.Duration
+}
+
+// SetNotFoundPolicy enables negative caching of ErrTextNotFound using failures
+// to store per-tweet failure counts.
+func (uc *GetTweetUseCase) SetNotFoundPolicy(failures FailureCache, policy NotFoundPolicy) {
+	uc.failures = failures
+	uc.notFound = policy
+}
+
+// coolingDown reports whether tweetID failed too often to be scraped again yet.
+func (uc *GetTweetUseCase) coolingDown(ctx context.Context, tweetID string) bool {
+	if uc.failures == nil || uc.notFound.MaxFailures <= 0 {
+		return false
+	}
+
+	count, lastFailure := uc.failures.Failures(tweetID)
+	since := uc.clock.Now().Sub(lastFailure)
+	if count < uc.notFound.MaxFailures || since >= uc.notFound.CoolDown {
+		return false
+	}
+
+	log.GlobalDebugCtx(ctx, "tweet text repeatedly not found, skipping scrape",
+		"tweet_id", tweetID,
+		"failures", count,
+		"retry_in_ms", (uc.notFound.CoolDown - since).Milliseconds())
+	return true
+}
+
+// recordNotFound counts an ErrTextNotFound result for tweetID.
+func (uc *GetTweetUseCase) recordNotFound(ctx context.Context, tweetID string) {
+	if uc.failures == nil || uc.notFound.MaxFailures <= 0 {
+		return
+	}
+
+	if count := uc.failures.RecordFailure(tweetID); count >= uc.notFound.MaxFailures {
+		log.GlobalWarnCtx(ctx, "tweet text not found too often, cooling down",
+			"tweet_id", tweetID,
+			"failures", count,
+			"cool_down", uc.notFound.CoolDown)
+	}
+}
+
+// clearNotFound forgets past failures once tweetID is scraped successfully.
+func (uc *GetTweetUseCase) clearNotFound(tweetID string) {
+	if uc.failures != nil {
+		uc.failures.ClearFailures(tweetID)
+	}
+}
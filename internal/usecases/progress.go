@@ -0,0 +1,32 @@
+package usecases
+
+import "context"
+
+// Progress steps reported while a tweet is being fetched.
+const (
+	StepNavigating = "navigating"
+	StepWaiting    = "waiting"
+	StepParsing    = "parsing"
+	StepDone       = "done"
+)
+
+// ProgressFunc receives progress steps for a single request.
+type ProgressFunc func(step string)
+
+// progressKey is the context key for the request's ProgressFunc.
+type progressKey struct{}
+
+// WithProgress returns a context that reports progress steps to fn.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// ReportProgress sends a progress step to the ProgressFunc in the context, if any.
+func ReportProgress(ctx context.Context, step string) {
+	if ctx == nil {
+		return
+	}
+	if fn, ok := ctx.Value(progressKey{}).(ProgressFunc); ok && fn != nil {
+		fn(step)
+	}
+}
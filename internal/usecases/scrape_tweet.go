@@ -5,6 +5,7 @@ import (
 
 	"sumariza-ai/internal/domain"
 	"sumariza-ai/pkg/log"
+	"sumariza-ai/pkg/stats"
 )
 
 // TweetScraper defines the interface for scraping tweets.
@@ -14,7 +15,11 @@ type TweetScraper interface {
 
 // ScrapeTweetUseCase handles the scraping of a single tweet.
 type ScrapeTweetUseCase struct {
-	scraper TweetScraper
+	scraper  TweetScraper
+	throttle *Throttle
+	spacer   *Spacer
+	alarm    *failureAlarm
+	stats    *stats.Registry
 }
 
 // NewScrapeTweetUseCase creates a new ScrapeTweetUseCase.
@@ -22,16 +27,49 @@ func NewScrapeTweetUseCase(scraper TweetScraper) *ScrapeTweetUseCase {
 	return &ScrapeTweetUseCase{scraper: scraper}
 }
 
+// SetThrottle sets the global rate limit every scrape must pass.
+// A nil throttle disables it.
+func (uc *ScrapeTweetUseCase) SetThrottle(throttle *Throttle) {
+	uc.throttle = throttle
+}
+
+// SetSpacer sets the minimum delay between consecutive scrapes.
+// A nil spacer disables it.
+func (uc *ScrapeTweetUseCase) SetSpacer(spacer *Spacer) {
+	uc.spacer = spacer
+}
+
+// SetStats sets the registry counting scrape attempts. A nil registry
+// disables counting.
+func (uc *ScrapeTweetUseCase) SetStats(registry *stats.Registry) {
+	uc.stats = registry
+}
+
 // Execute scrapes a tweet and sets the username and URL.
 func (uc *ScrapeTweetUseCase) Execute(ctx context.Context, tweetID, username string) (*domain.Tweet, error) {
+	if err := uc.throttle.Wait(ctx); err != nil {
+		log.GlobalWarnCtx(ctx, "scrape throttled", "tweet_id", tweetID, "error", err)
+		return nil, err
+	}
+	if err := uc.spacer.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	uc.stats.ScrapeStarted()
 	tweet, err := uc.scraper.Scrape(ctx, tweetID)
 	if err != nil {
+		uc.alarm.recordFailure(ctx, err)
 		return nil, err
 	}
+	uc.alarm.recordSuccess()
 
-	// Set username from input URL
+	// Set username from input URL. The input username may be wrong (X
+	// redirects any handle to the tweet), so prefer the page's permalink.
 	tweet.Username = username
-	tweet.URL = "https://x.com/" + username + "/status/" + tweetID
+	tweet.URL = tweet.ResolvedURL
+	if tweet.URL == "" {
+		tweet.URL = "https://x.com/" + username + "/status/" + tweetID
+	}
 
 	// Log if partial data (for debugging)
 	if tweet.Partial {
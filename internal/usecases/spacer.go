@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Spacer keeps consecutive scrapes at least a minimum interval apart, plus a
+// random jitter, so back-to-back navigations don't look bot-like.
+type Spacer struct {
+	mu          sync.Mutex
+	minInterval time.Duration
+	jitter      time.Duration
+	next        time.Time // earliest start of the next scrape
+}
+
+// NewSpacer creates a spacer waiting minInterval plus up to jitter between scrapes.
+func NewSpacer(minInterval, jitter time.Duration) *Spacer {
+	return &Spacer{
+		minInterval: minInterval,
+		jitter:      jitter,
+	}
+}
+
+// Wait blocks until the next scrape may start. Concurrent callers get
+// successive slots. A nil Spacer never blocks.
+func (s *Spacer) Wait(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	wait := time.Until(s.reserve())
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reserve returns the start of the caller's slot and books the following one.
+func (s *Spacer) reserve() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	slot := time.Now()
+	if s.next.After(slot) {
+		slot = s.next
+	}
+
+	gap := s.minInterval
+	if s.jitter > 0 {
+		gap += time.Duration(rand.Int63n(int64(s.jitter)))
+	}
+	s.next = slot.Add(gap)
+
+	return slot
+}
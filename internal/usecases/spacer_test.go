@@ -0,0 +1,79 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"sumariza-ai/internal/domain"
+	"sumariza-ai/internal/usecases"
+)
+
+func TestSpacer_TwoRapidScrapes_AreSpacedByMinInterval(t *testing.T) {
+	// Arrange
+	minInterval := 50 * time.Millisecond
+	spacer := usecases.NewSpacer(minInterval, 20*time.Millisecond)
+
+	// Act
+	first := time.Now()
+	if err := spacer.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := spacer.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gap := time.Since(first)
+
+	// Assert
+	if gap < minInterval {
+		t.Errorf("gap: got %v, want at least %v", gap, minInterval)
+	}
+}
+
+func TestSpacer_FirstScrape_DoesNotWait(t *testing.T) {
+	spacer := usecases.NewSpacer(time.Hour, 0)
+	start := time.Now()
+
+	if err := spacer.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("first scrape waited %v, want no wait", elapsed)
+	}
+}
+
+func TestSpacer_ContextCanceled_StopsWaiting(t *testing.T) {
+	// Arrange
+	spacer := usecases.NewSpacer(time.Hour, 0)
+	_ = spacer.Wait(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := spacer.Wait(ctx)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error: got %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestScrapeTweetUseCase_Spacer_SpacesScrapes(t *testing.T) {
+	// Arrange
+	minInterval := 50 * time.Millisecond
+	mockScraper := &MockScraper{tweet: &domain.Tweet{ID: "123"}}
+	uc := usecases.NewScrapeTweetUseCase(mockScraper)
+	uc.SetSpacer(usecases.NewSpacer(minInterval, 0))
+
+	// Act
+	start := time.Now()
+	_, _ = uc.Execute(context.Background(), "123", "user")
+	_, _ = uc.Execute(context.Background(), "123", "user")
+
+	// Assert
+	if gap := time.Since(start); gap < minInterval {
+		t.Errorf("two scrapes took %v, want at least %v", gap, minInterval)
+	}
+}
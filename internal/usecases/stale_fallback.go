@@ -0,0 +1,46 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"sumariza-ai/internal/domain"
+	"sumariza-ai/pkg/log"
+)
+
+// StaleCache returns cache entries that may have expired.
+type StaleCache interface {
+	// GetStale returns the tweet while it is kept past expiry, with
+	// meta.Stale set once it has expired.
+	GetStale(username, tweetID string) (*domain.Tweet, CacheMeta, bool)
+}
+
+// SetStaleFallback serves expired entries from cache when scraping fails,
+// so an outage degrades to old data instead of errors. A nil cache disables it.
+func (uc *GetTweetUseCase) SetStaleFallback(cache StaleCache) {
+	uc.stale = cache
+}
+
+// staleOr returns the stale cache entry of the tweet in place of the scrape
+// error err. Tweets that are gone (deleted, private, sensitive) keep the error.
+func (uc *GetTweetUseCase) staleOr(ctx context.Context, tweetID, username string, err error) (*domain.Tweet, *CacheMeta, error) {
+	if uc.stale == nil || errors.Is(err, domain.ErrTweetNotFound) ||
+		errors.Is(err, domain.ErrTweetPrivate) || errors.Is(err, domain.ErrSensitiveContent) {
+		return nil, nil, err
+	}
+
+	tweet, meta, found := uc.stale.GetStale(username, tweetID)
+	if !found {
+		return nil, nil, err
+	}
+	if blocked := uc.checkAuthor(ctx, tweet); blocked != nil {
+		return nil, nil, blocked
+	}
+
+	log.GlobalWarnCtx(ctx, "scrape failed, serving stale cache entry",
+		"username", username,
+		"tweet_id", tweetID,
+		"expired_at", meta.ExpiresAt,
+		"error", err)
+	return tweet, &meta, nil
+}
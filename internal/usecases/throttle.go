@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"sumariza-ai/internal/domain"
+)
+
+// Throttle is a token bucket capping the aggregate scrape rate against
+// Twitter, independent of which client asked for the scrape.
+type Throttle struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewThrottle creates a throttle allowing rps scrapes per second on average,
+// with bursts of up to burst scrapes. The bucket starts full.
+func NewThrottle(rps float64, burst int) *Throttle {
+	if burst < 1 {
+		burst = 1
+	}
+	return &Throttle{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a scrape is allowed. When the wait would outlast the
+// ctx deadline it returns domain.ErrRateLimited right away instead.
+// A nil Throttle never blocks.
+func (t *Throttle) Wait(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+
+	wait, err := t.reserve(ctx)
+	if err != nil || wait <= 0 {
+		return err
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		t.cancelReservation()
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token, possibly ahead of time, and returns how long to
+// wait until it is actually available.
+func (t *Throttle) reserve(ctx context.Context) (time.Duration, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	t.tokens += now.Sub(t.last).Seconds() * t.rate
+	if t.tokens > t.burst {
+		t.tokens = t.burst
+	}
+	t.last = now
+
+	var wait time.Duration
+	if t.tokens < 1 {
+		if t.rate <= 0 {
+			return 0, domain.ErrRateLimited
+		}
+		wait = time.Duration((1 - t.tokens) / t.rate * float64(time.Second))
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && now.Add(wait).After(deadline) {
+		return 0, domain.ErrRateLimited
+	}
+
+	t.tokens--
+	return wait, nil
+}
+
+// cancelReservation gives back a token taken by an abandoned wait.
+func (t *Throttle) cancelReservation() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.tokens++
+}
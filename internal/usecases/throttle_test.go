@@ -0,0 +1,106 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"sumariza-ai/internal/domain"
+	"sumariza-ai/internal/usecases"
+)
+
+func TestThrottle_Burst_AggregateRateIsCapped(t *testing.T) {
+	// Arrange - 20/s with a burst of 2: 6 scrapes need at least 200ms
+	throttle := usecases.NewThrottle(20, 2)
+	var wg sync.WaitGroup
+	start := time.Now()
+
+	// Act
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := throttle.Wait(context.Background()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	if elapsed := time.Since(start); elapsed < 190*time.Millisecond {
+		t.Errorf("6 scrapes took %v, want at least 200ms", elapsed)
+	}
+}
+
+func TestThrottle_WithinBurst_DoesNotBlock(t *testing.T) {
+	// Arrange
+	throttle := usecases.NewThrottle(0.1, 3)
+	start := time.Now()
+
+	// Act
+	for i := 0; i < 3; i++ {
+		if err := throttle.Wait(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// Assert
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("burst took %v, want no wait", elapsed)
+	}
+}
+
+func TestThrottle_WaitPastDeadline_ReturnsRateLimited(t *testing.T) {
+	// Arrange - one token, refilled every 10s
+	throttle := usecases.NewThrottle(0.1, 1)
+	_ = throttle.Wait(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Act
+	start := time.Now()
+	err := throttle.Wait(ctx)
+
+	// Assert
+	if !errors.Is(err, domain.ErrRateLimited) {
+		t.Errorf("error: got %v, want ErrRateLimited", err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("rejected wait took %v, want immediate", elapsed)
+	}
+}
+
+func TestThrottle_Nil_NeverBlocks(t *testing.T) {
+	var throttle *usecases.Throttle
+
+	if err := throttle.Wait(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestScrapeTweetUseCase_Throttled_SkipsScraper(t *testing.T) {
+	// Arrange
+	mockScraper := &MockScraper{tweet: &domain.Tweet{ID: "123"}}
+	uc := usecases.NewScrapeTweetUseCase(mockScraper)
+	uc.SetThrottle(usecases.NewThrottle(0.1, 1))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Act
+	_, first := uc.Execute(ctx, "123", "user")
+	_, second := uc.Execute(ctx, "123", "user")
+
+	// Assert
+	if first != nil {
+		t.Errorf("first scrape: unexpected error %v", first)
+	}
+	if !errors.Is(second, domain.ErrRateLimited) {
+		t.Errorf("second scrape: got %v, want ErrRateLimited", second)
+	}
+	if mockScraper.calls != 1 {
+		t.Errorf("scraper calls: got %d, want 1", mockScraper.calls)
+	}
+}
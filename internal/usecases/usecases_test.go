@@ -3,19 +3,25 @@ package usecases_test
 import (
 	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"sumariza-ai/internal/domain"
 	"sumariza-ai/internal/usecases"
+	"sumariza-ai/pkg/clock"
 )
 
 // MockScraper is a mock implementation of TweetScraper.
 type MockScraper struct {
 	tweet *domain.Tweet
 	err   error
+	calls int
 }
 
 func (m *MockScraper) Scrape(ctx context.Context, tweetID string) (*domain.Tweet, error) {
+	m.calls++
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -24,22 +30,42 @@ func (m *MockScraper) Scrape(ctx context.Context, tweetID string) (*domain.Tweet
 
 // MockCache is a mock implementation of TweetCache.
 type MockCache struct {
-	tweets map[string]*domain.Tweet
+	mu        sync.Mutex
+	tweets    map[string]*domain.Tweet
+	scrapedAt map[string]time.Time
 }
 
 func NewMockCache() *MockCache {
-	return &MockCache{tweets: make(map[string]*domain.Tweet)}
+	return &MockCache{
+		tweets:    make(map[string]*domain.Tweet),
+		scrapedAt: make(map[string]time.Time),
+	}
 }
 
 func (m *MockCache) Get(username, tweetID string) (*domain.Tweet, bool) {
+	tweet, _, found := m.GetWithMeta(username, tweetID)
+	return tweet, found
+}
+
+func (m *MockCache) GetWithMeta(username, tweetID string) (*domain.Tweet, usecases.CacheMeta, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	key := "/" + username + "/status/" + tweetID
 	tweet, found := m.tweets[key]
-	return tweet, found
+	return tweet, usecases.CacheMeta{ScrapedAt: m.scrapedAt[key]}, found
 }
 
 func (m *MockCache) Set(username, tweetID string, tweet *domain.Tweet) {
+	m.SetAged(username, tweetID, tweet, 0)
+}
+
+// SetAged stores a tweet as if it had been scraped age ago.
+func (m *MockCache) SetAged(username, tweetID string, tweet *domain.Tweet, age time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	key := "/" + username + "/status/" + tweetID
 	m.tweets[key] = tweet
+	m.scrapedAt[key] = time.Now().Add(-age)
 }
 
 // ScrapeTweetUseCase tests
@@ -69,6 +95,32 @@ func TestScrapeTweetUseCase_Execute_Success(t *testing.T) {
 	}
 }
 
+func TestScrapeTweetUseCase_Execute_ResolvedURL_UsedAsURL(t *testing.T) {
+	// Arrange
+	mockScraper := &MockScraper{
+		tweet: &domain.Tweet{
+			ID:          "123",
+			ResolvedURL: "https://x.com/realhandle/status/123",
+			Content:     domain.Content{Text: "Hello world"},
+		},
+	}
+	uc := usecases.NewScrapeTweetUseCase(mockScraper)
+
+	// Act
+	tweet, err := uc.Execute(context.Background(), "123", "wronguser")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tweet.URL != "https://x.com/realhandle/status/123" {
+		t.Errorf("URL: got %v, want https://x.com/realhandle/status/123", tweet.URL)
+	}
+	if tweet.Username != "wronguser" {
+		t.Errorf("Username: got %v, want wronguser", tweet.Username)
+	}
+}
+
 func TestScrapeTweetUseCase_Execute_ScraperError(t *testing.T) {
 	// Arrange
 	expectedErr := errors.New("scraping failed")
@@ -103,7 +155,7 @@ func TestGetTweetUseCase_Execute_CacheHit(t *testing.T) {
 	uc := usecases.NewGetTweetUseCase(cache, scrapeUC)
 
 	// Act
-	tweet, err := uc.Execute(context.Background(), "123", "testuser")
+	tweet, err := uc.Get(context.Background(), "123", "testuser")
 
 	// Assert
 	if err != nil {
@@ -127,7 +179,7 @@ func TestGetTweetUseCase_Execute_CacheMiss(t *testing.T) {
 	uc := usecases.NewGetTweetUseCase(cache, scrapeUC)
 
 	// Act
-	tweet, err := uc.Execute(context.Background(), "456", "newuser")
+	tweet, err := uc.Get(context.Background(), "456", "newuser")
 
 	// Assert
 	if err != nil {
@@ -141,6 +193,111 @@ func TestGetTweetUseCase_Execute_CacheMiss(t *testing.T) {
 	}
 }
 
+func TestGetTweetUseCase_Cached_NeverScrapes(t *testing.T) {
+	// Arrange
+	cache := NewMockCache()
+	cache.Set("testuser", "123", &domain.Tweet{ID: "123", Content: domain.Content{Text: "Cached tweet"}})
+	mockScraper := &MockScraper{tweet: &domain.Tweet{ID: "456"}}
+	uc := usecases.NewGetTweetUseCase(cache, usecases.NewScrapeTweetUseCase(mockScraper))
+
+	// Act
+	hit, hitFound := uc.Cached("123", "testuser")
+	_, missFound := uc.Cached("456", "testuser")
+
+	// Assert
+	if !hitFound || hit.Content.Text != "Cached tweet" {
+		t.Errorf("hit: got %v, %v; want the cached tweet", hit, hitFound)
+	}
+	if missFound {
+		t.Error("miss: expected not found")
+	}
+	if mockScraper.calls != 0 {
+		t.Errorf("scraper calls: got %d, want 0", mockScraper.calls)
+	}
+}
+
+func TestGetTweetUseCase_Cached_BlockedByPolicy(t *testing.T) {
+	// Arrange
+	cache := NewMockCache()
+	cache.Set("blockeduser", "123", &domain.Tweet{ID: "123"})
+	uc := usecases.NewGetTweetUseCase(cache, usecases.NewScrapeTweetUseCase(&MockScraper{}))
+	uc.SetPolicy(usecases.NewContentPolicy(nil, []string{"@BlockedUser"}))
+
+	// Act
+	_, found := uc.Cached("123", "blockeduser")
+
+	// Assert
+	if found {
+		t.Error("expected a blocked tweet not to be served from cache")
+	}
+}
+
+func TestGetTweetUseCase_OnScraped_FiresOnceOnMissNotOnHit(t *testing.T) {
+	// Arrange
+	cache := NewMockCache()
+	mockScraper := &MockScraper{
+		tweet: &domain.Tweet{ID: "456", Content: domain.Content{Text: "Fresh tweet"}},
+	}
+	uc := usecases.NewGetTweetUseCase(cache, usecases.NewScrapeTweetUseCase(mockScraper))
+	scraped := make(chan *domain.Tweet, 2)
+	uc.SetOnScraped(func(ctx context.Context, tweet *domain.Tweet) {
+		scraped <- tweet
+	})
+
+	// Act
+	_, missErr := uc.Get(context.Background(), "456", "newuser")
+	_, hitErr := uc.Get(context.Background(), "456", "newuser")
+
+	// Assert
+	if missErr != nil || hitErr != nil {
+		t.Fatalf("unexpected errors: miss %v, hit %v", missErr, hitErr)
+	}
+	select {
+	case tweet := <-scraped:
+		if tweet.ID != "456" {
+			t.Errorf("hook tweet ID: got %v, want 456", tweet.ID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("hook did not fire on cache miss")
+	}
+	select {
+	case <-scraped:
+		t.Error("hook fired on cache hit")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestGetTweetUseCase_OnScraped_DoesNotBlockResponse(t *testing.T) {
+	// Arrange
+	cache := NewMockCache()
+	mockScraper := &MockScraper{
+		tweet: &domain.Tweet{ID: "456", Content: domain.Content{Text: "Fresh tweet"}},
+	}
+	uc := usecases.NewGetTweetUseCase(cache, usecases.NewScrapeTweetUseCase(mockScraper))
+	release := make(chan struct{})
+	defer close(release)
+	uc.SetOnScraped(func(ctx context.Context, tweet *domain.Tweet) {
+		<-release
+	})
+
+	// Act
+	done := make(chan error, 1)
+	go func() {
+		_, err := uc.Get(context.Background(), "456", "newuser")
+		done <- err
+	}()
+
+	// Assert
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Get blocked on the scraped hook")
+	}
+}
+
 func TestGetTweetUseCase_Execute_CacheMiss_StoresInCache(t *testing.T) {
 	// Arrange
 	cache := NewMockCache()
@@ -154,7 +311,7 @@ func TestGetTweetUseCase_Execute_CacheMiss_StoresInCache(t *testing.T) {
 	uc := usecases.NewGetTweetUseCase(cache, scrapeUC)
 
 	// Act
-	_, _ = uc.Execute(context.Background(), "789", "user")
+	_, _ = uc.Get(context.Background(), "789", "user")
 
 	// Verify cache was populated
 	cachedTweet, found := cache.Get("user", "789")
@@ -176,10 +333,618 @@ func TestGetTweetUseCase_Execute_ScraperError(t *testing.T) {
 	uc := usecases.NewGetTweetUseCase(cache, scrapeUC)
 
 	// Act
-	_, err := uc.Execute(context.Background(), "999", "user")
+	_, err := uc.Get(context.Background(), "999", "user")
 
 	// Assert
 	if err != domain.ErrScrapingFailed {
 		t.Errorf("expected ErrScrapingFailed, got %v", err)
 	}
 }
+
+// ContentPolicy tests
+
+func TestGetTweetUseCase_Execute_DenyListHit_ReturnsBlocked(t *testing.T) {
+	// Arrange
+	mockScraper := &MockScraper{
+		tweet: &domain.Tweet{ID: "123", Content: domain.Content{Text: "Blocked"}},
+	}
+	uc := usecases.NewGetTweetUseCase(NewMockCache(), usecases.NewScrapeTweetUseCase(mockScraper))
+	uc.SetPolicy(usecases.NewContentPolicy(nil, []string{"@BlockedUser"}))
+
+	// Act
+	_, err := uc.Get(context.Background(), "123", "blockeduser")
+
+	// Assert
+	if err != domain.ErrBlockedContent {
+		t.Errorf("expected ErrBlockedContent, got %v", err)
+	}
+	if mockScraper.calls != 0 {
+		t.Errorf("scraper calls: got %d, want 0", mockScraper.calls)
+	}
+}
+
+func TestGetTweetUseCase_Execute_DeniedAuthorUnderOtherHandle_ReturnsBlocked(t *testing.T) {
+	// Arrange - X serves the tweet under whatever handle the URL uses
+	cache := NewMockCache()
+	mockScraper := &MockScraper{
+		tweet: &domain.Tweet{ID: "123", Author: domain.Author{Handle: "BlockedUser"}, Content: domain.Content{Text: "Blocked"}},
+	}
+	uc := usecases.NewGetTweetUseCase(cache, usecases.NewScrapeTweetUseCase(mockScraper))
+	uc.SetPolicy(usecases.NewContentPolicy(nil, []string{"@blockeduser"}))
+
+	// Act
+	_, scrapeErr := uc.Get(context.Background(), "123", "anything")
+	_, cacheErr := uc.Get(context.Background(), "123", "anything")
+	_, cachedFound := uc.Cached("123", "anything")
+
+	// Assert
+	if scrapeErr != domain.ErrBlockedContent {
+		t.Errorf("after scrape: expected ErrBlockedContent, got %v", scrapeErr)
+	}
+	if cacheErr != domain.ErrBlockedContent {
+		t.Errorf("cache hit: expected ErrBlockedContent, got %v", cacheErr)
+	}
+	if cachedFound {
+		t.Error("Cached: expected the blocked tweet not to be served")
+	}
+	if mockScraper.calls != 1 {
+		t.Errorf("scraper calls: got %d, want 1 (the blocked tweet is cached)", mockScraper.calls)
+	}
+}
+
+func TestGetTweetUseCase_Execute_DenyTakesPrecedence(t *testing.T) {
+	// Arrange
+	mockScraper := &MockScraper{
+		tweet: &domain.Tweet{ID: "123", Content: domain.Content{Text: "Blocked"}},
+	}
+	uc := usecases.NewGetTweetUseCase(NewMockCache(), usecases.NewScrapeTweetUseCase(mockScraper))
+	uc.SetPolicy(usecases.NewContentPolicy([]string{"user"}, []string{"123"}))
+
+	// Act
+	_, err := uc.Get(context.Background(), "123", "user")
+
+	// Assert
+	if err != domain.ErrBlockedContent {
+		t.Errorf("expected ErrBlockedContent, got %v", err)
+	}
+}
+
+func TestGetTweetUseCase_Execute_AllowListMiss_ReturnsBlocked(t *testing.T) {
+	// Arrange
+	mockScraper := &MockScraper{
+		tweet: &domain.Tweet{ID: "123", Content: domain.Content{Text: "Not curated"}},
+	}
+	uc := usecases.NewGetTweetUseCase(NewMockCache(), usecases.NewScrapeTweetUseCase(mockScraper))
+	uc.SetPolicy(usecases.NewContentPolicy([]string{"curated"}, nil))
+
+	// Act
+	_, err := uc.Get(context.Background(), "123", "someone")
+
+	// Assert
+	if err != domain.ErrBlockedContent {
+		t.Errorf("expected ErrBlockedContent, got %v", err)
+	}
+	if mockScraper.calls != 0 {
+		t.Errorf("scraper calls: got %d, want 0", mockScraper.calls)
+	}
+}
+
+func TestGetTweetUseCase_Execute_AllowListHit_Scrapes(t *testing.T) {
+	// Arrange
+	mockScraper := &MockScraper{
+		tweet: &domain.Tweet{ID: "123", Content: domain.Content{Text: "Curated"}},
+	}
+	uc := usecases.NewGetTweetUseCase(NewMockCache(), usecases.NewScrapeTweetUseCase(mockScraper))
+	uc.SetPolicy(usecases.NewContentPolicy([]string{"Curated"}, nil))
+
+	// Act
+	tweet, err := uc.Get(context.Background(), "123", "curated")
+
+	// Assert
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if tweet == nil || tweet.Content.Text != "Curated" {
+		t.Errorf("expected curated tweet, got %v", tweet)
+	}
+}
+
+// Progress tests
+
+func TestReportProgress_WithProgress_CallsFunc(t *testing.T) {
+	// Arrange
+	var steps []string
+	ctx := usecases.WithProgress(context.Background(), func(step string) {
+		steps = append(steps, step)
+	})
+
+	// Act
+	usecases.ReportProgress(ctx, usecases.StepNavigating)
+	usecases.ReportProgress(ctx, usecases.StepParsing)
+
+	// Assert
+	if len(steps) != 2 || steps[0] != usecases.StepNavigating || steps[1] != usecases.StepParsing {
+		t.Errorf("steps: got %v, want [navigating parsing]", steps)
+	}
+}
+
+func TestReportProgress_WithoutProgress_DoesNothing(t *testing.T) {
+	// Act & Assert - must not panic
+	usecases.ReportProgress(context.Background(), usecases.StepDone)
+}
+
+// BypassCache tests
+
+func TestGetTweetUseCase_Execute_BypassCache_ScrapesDespiteHit(t *testing.T) {
+	// Arrange
+	cache := NewMockCache()
+	cache.Set("user", "123", &domain.Tweet{ID: "123", Content: domain.Content{Text: "Cached tweet"}})
+	mockScraper := &MockScraper{
+		tweet: &domain.Tweet{ID: "123", Content: domain.Content{Text: "Fresh tweet"}},
+	}
+	uc := usecases.NewGetTweetUseCase(cache, usecases.NewScrapeTweetUseCase(mockScraper))
+
+	// Act
+	tweet, err := uc.Execute(context.Background(), "123", "user", usecases.GetTweetOptions{BypassCache: true})
+
+	// Assert
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if mockScraper.calls != 1 {
+		t.Errorf("scraper calls: got %d, want 1", mockScraper.calls)
+	}
+	if tweet.Content.Text != "Fresh tweet" {
+		t.Errorf("expected fresh tweet, got %v", tweet.Content.Text)
+	}
+}
+
+func TestGetTweetUseCase_Execute_BypassCache_StillWritesCache(t *testing.T) {
+	// Arrange
+	cache := NewMockCache()
+	cache.Set("user", "123", &domain.Tweet{ID: "123", Content: domain.Content{Text: "Cached tweet"}})
+	mockScraper := &MockScraper{
+		tweet: &domain.Tweet{ID: "123", Content: domain.Content{Text: "Fresh tweet"}},
+	}
+	uc := usecases.NewGetTweetUseCase(cache, usecases.NewScrapeTweetUseCase(mockScraper))
+
+	// Act
+	_, _ = uc.Execute(context.Background(), "123", "user", usecases.GetTweetOptions{BypassCache: true})
+	cachedTweet, found := cache.Get("user", "123")
+
+	// Assert
+	if !found || cachedTweet.Content.Text != "Fresh tweet" {
+		t.Errorf("expected fresh tweet in cache, got %v", cachedTweet)
+	}
+}
+
+// MaxAge tests
+
+func TestGetTweetUseCase_Execute_MaxAge_RescrapesOldEntry(t *testing.T) {
+	// Arrange
+	cache := NewMockCache()
+	cache.SetAged("user", "123", &domain.Tweet{ID: "123", Content: domain.Content{Text: "Old tweet"}}, 4*time.Minute)
+	mockScraper := &MockScraper{
+		tweet: &domain.Tweet{ID: "123", Content: domain.Content{Text: "Fresh tweet"}},
+	}
+	uc := usecases.NewGetTweetUseCase(cache, usecases.NewScrapeTweetUseCase(mockScraper))
+
+	// Act
+	tweet, err := uc.Execute(context.Background(), "123", "user", usecases.GetTweetOptions{MaxAge: 30 * time.Second})
+
+	// Assert
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if mockScraper.calls != 1 {
+		t.Errorf("scraper calls: got %d, want 1", mockScraper.calls)
+	}
+	if tweet.Content.Text != "Fresh tweet" {
+		t.Errorf("expected fresh tweet, got %v", tweet.Content.Text)
+	}
+}
+
+func TestGetTweetUseCase_Execute_MaxAge_ServesYoungEntry(t *testing.T) {
+	// Arrange
+	cache := NewMockCache()
+	cache.SetAged("user", "123", &domain.Tweet{ID: "123", Content: domain.Content{Text: "Cached tweet"}}, 10*time.Second)
+	mockScraper := &MockScraper{
+		tweet: &domain.Tweet{ID: "123", Content: domain.Content{Text: "Fresh tweet"}},
+	}
+	uc := usecases.NewGetTweetUseCase(cache, usecases.NewScrapeTweetUseCase(mockScraper))
+
+	// Act
+	tweet, err := uc.Execute(context.Background(), "123", "user", usecases.GetTweetOptions{MaxAge: time.Minute})
+
+	// Assert
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if mockScraper.calls != 0 {
+		t.Errorf("scraper calls: got %d, want 0", mockScraper.calls)
+	}
+	if tweet.Content.Text != "Cached tweet" {
+		t.Errorf("expected cached tweet, got %v", tweet.Content.Text)
+	}
+}
+
+func TestGetTweetUseCase_Execute_MaxAge_UsesInjectedClock(t *testing.T) {
+	// Arrange
+	cache := NewMockCache()
+	cache.Set("user", "123", &domain.Tweet{ID: "123", Content: domain.Content{Text: "Cached tweet"}})
+	mockScraper := &MockScraper{
+		tweet: &domain.Tweet{ID: "123", Content: domain.Content{Text: "Fresh tweet"}},
+	}
+	fake := clock.NewFake(time.Now())
+	uc := usecases.NewGetTweetUseCase(cache, usecases.NewScrapeTweetUseCase(mockScraper))
+	uc.SetClock(fake)
+
+	// Act - only the fake clock ages the entry past MaxAge
+	fake.Advance(time.Hour)
+	tweet, err := uc.Execute(context.Background(), "123", "user", usecases.GetTweetOptions{MaxAge: time.Minute})
+
+	// Assert
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if mockScraper.calls != 1 {
+		t.Errorf("scraper calls: got %d, want 1", mockScraper.calls)
+	}
+	if tweet.Content.Text != "Fresh tweet" {
+		t.Errorf("expected fresh tweet, got %v", tweet.Content.Text)
+	}
+}
+
+// BatchGetTweetsUseCase tests
+
+// ConcurrencyScraper counts how many scrapes run at the same time.
+type ConcurrencyScraper struct {
+	current int32
+	max     int32
+	total   int32
+}
+
+func (s *ConcurrencyScraper) Scrape(ctx context.Context, tweetID string) (*domain.Tweet, error) {
+	current := atomic.AddInt32(&s.current, 1)
+	defer atomic.AddInt32(&s.current, -1)
+	atomic.AddInt32(&s.total, 1)
+
+	for {
+		max := atomic.LoadInt32(&s.max)
+		if current <= max || atomic.CompareAndSwapInt32(&s.max, max, current) {
+			break
+		}
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	return &domain.Tweet{ID: tweetID, Content: domain.Content{Text: "Tweet " + tweetID}}, nil
+}
+
+func TestBatchGetTweetsUseCase_Execute_RespectsConcurrencyCap(t *testing.T) {
+	// Arrange
+	scraper := &ConcurrencyScraper{}
+	getTweetUC := usecases.NewGetTweetUseCase(NewMockCache(), usecases.NewScrapeTweetUseCase(scraper))
+	uc := usecases.NewBatchGetTweetsUseCase(getTweetUC, 3)
+
+	var refs []usecases.TweetRef
+	for i := 0; i < 20; i++ {
+		refs = append(refs, usecases.TweetRef{Username: "user", TweetID: string(rune('a' + i))})
+	}
+
+	// Act
+	results := uc.Execute(context.Background(), refs)
+
+	// Assert
+	if scraper.max > 3 {
+		t.Errorf("max concurrent scrapes: got %d, want <= 3", scraper.max)
+	}
+	if scraper.total != 20 {
+		t.Errorf("total scrapes: got %d, want 20", scraper.total)
+	}
+	for i, result := range results {
+		if result.Err != nil {
+			t.Errorf("result %d: unexpected error %v", i, result.Err)
+		}
+		if result.Ref != refs[i] || result.Tweet.ID != refs[i].TweetID {
+			t.Errorf("result %d: out of order, got %+v for ref %+v", i, result.Ref, refs[i])
+		}
+	}
+}
+
+func TestBatchGetTweetsUseCase_Execute_CanceledContext_ReturnsErrors(t *testing.T) {
+	// Arrange
+	scraper := &ConcurrencyScraper{}
+	getTweetUC := usecases.NewGetTweetUseCase(NewMockCache(), usecases.NewScrapeTweetUseCase(scraper))
+	uc := usecases.NewBatchGetTweetsUseCase(getTweetUC, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	results := uc.Execute(ctx, []usecases.TweetRef{{Username: "user", TweetID: "1"}, {Username: "user", TweetID: "2"}})
+
+	// Assert
+	for i, result := range results {
+		if result.Err != context.Canceled {
+			t.Errorf("result %d: got %v, want context.Canceled", i, result.Err)
+		}
+	}
+	if scraper.total != 0 {
+		t.Errorf("total scrapes: got %d, want 0", scraper.total)
+	}
+}
+
+// MockFailureCache is a mock implementation of FailureCache.
+type MockFailureCache struct {
+	counts map[string]int
+	last   map[string]time.Time
+}
+
+func NewMockFailureCache() *MockFailureCache {
+	return &MockFailureCache{counts: make(map[string]int), last: make(map[string]time.Time)}
+}
+
+func (m *MockFailureCache) RecordFailure(tweetID string) int {
+	m.counts[tweetID]++
+	m.last[tweetID] = time.Now()
+	return m.counts[tweetID]
+}
+
+func (m *MockFailureCache) Failures(tweetID string) (int, time.Time) {
+	return m.counts[tweetID], m.last[tweetID]
+}
+
+func (m *MockFailureCache) ClearFailures(tweetID string) {
+	delete(m.counts, tweetID)
+	delete(m.last, tweetID)
+}
+
+func TestGetTweetUseCase_NotFoundPolicy_StopsRetryingAfterThreshold(t *testing.T) {
+	// Arrange
+	mockScraper := &MockScraper{err: domain.ErrTextNotFound}
+	uc := usecases.NewGetTweetUseCase(NewMockCache(), usecases.NewScrapeTweetUseCase(mockScraper))
+	uc.SetNotFoundPolicy(NewMockFailureCache(), usecases.NotFoundPolicy{MaxFailures: 2, CoolDown: time.Hour})
+
+	// Act
+	for i := 0; i < 5; i++ {
+		_, err := uc.Get(context.Background(), "123", "user")
+		if !errors.Is(err, domain.ErrTextNotFound) {
+			t.Fatalf("attempt %d: got %v, want ErrTextNotFound", i, err)
+		}
+	}
+
+	// Assert
+	if mockScraper.calls != 2 {
+		t.Errorf("scraper calls: got %d, want 2", mockScraper.calls)
+	}
+}
+
+func TestGetTweetUseCase_NotFoundPolicy_RetriesAfterCoolDown(t *testing.T) {
+	// Arrange
+	mockScraper := &MockScraper{err: domain.ErrTextNotFound}
+	failures := NewMockFailureCache()
+	uc := usecases.NewGetTweetUseCase(NewMockCache(), usecases.NewScrapeTweetUseCase(mockScraper))
+	uc.SetNotFoundPolicy(failures, usecases.NotFoundPolicy{MaxFailures: 1, CoolDown: time.Hour})
+	_, _ = uc.Get(context.Background(), "123", "user")
+
+	// Act - the last failure is now older than the cool-down
+	failures.last["123"] = time.Now().Add(-2 * time.Hour)
+	_, _ = uc.Get(context.Background(), "123", "user")
+
+	// Assert
+	if mockScraper.calls != 2 {
+		t.Errorf("scraper calls: got %d, want 2", mockScraper.calls)
+	}
+}
+
+func TestGetTweetUseCase_NotFoundPolicy_UsesInjectedClock(t *testing.T) {
+	// Arrange
+	mockScraper := &MockScraper{err: domain.ErrTextNotFound}
+	failures := NewMockFailureCache()
+	fake := clock.NewFake(time.Now())
+	uc := usecases.NewGetTweetUseCase(NewMockCache(), usecases.NewScrapeTweetUseCase(mockScraper))
+	uc.SetClock(fake)
+	uc.SetNotFoundPolicy(failures, usecases.NotFoundPolicy{MaxFailures: 1, CoolDown: time.Hour})
+	_, _ = uc.Get(context.Background(), "123", "user")
+
+	// Act - only the fake clock moves past the cool-down
+	fake.Advance(2 * time.Hour)
+	_, _ = uc.Get(context.Background(), "123", "user")
+
+	// Assert
+	if mockScraper.calls != 2 {
+		t.Errorf("scraper calls: got %d, want 2", mockScraper.calls)
+	}
+}
+
+func TestGetTweetUseCase_NotFoundPolicy_OtherErrorsNotCounted(t *testing.T) {
+	// Arrange
+	mockScraper := &MockScraper{err: domain.ErrScrapingFailed}
+	failures := NewMockFailureCache()
+	uc := usecases.NewGetTweetUseCase(NewMockCache(), usecases.NewScrapeTweetUseCase(mockScraper))
+	uc.SetNotFoundPolicy(failures, usecases.NotFoundPolicy{MaxFailures: 1, CoolDown: time.Hour})
+
+	// Act
+	_, _ = uc.Get(context.Background(), "123", "user")
+	_, _ = uc.Get(context.Background(), "123", "user")
+
+	// Assert
+	if mockScraper.calls != 2 {
+		t.Errorf("scraper calls: got %d, want 2", mockScraper.calls)
+	}
+	if count, _ := failures.Failures("123"); count != 0 {
+		t.Errorf("failures: got %d, want 0", count)
+	}
+}
+
+func TestGetTweetUseCase_NotFoundPolicy_SuccessClearsFailures(t *testing.T) {
+	// Arrange
+	mockScraper := &MockScraper{tweet: &domain.Tweet{ID: "123", Content: domain.Content{Text: "Back"}}}
+	failures := NewMockFailureCache()
+	failures.RecordFailure("123")
+	uc := usecases.NewGetTweetUseCase(NewMockCache(), usecases.NewScrapeTweetUseCase(mockScraper))
+	uc.SetNotFoundPolicy(failures, usecases.NotFoundPolicy{MaxFailures: 2, CoolDown: time.Hour})
+
+	// Act
+	_, err := uc.Get(context.Background(), "123", "user")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count, _ := failures.Failures("123"); count != 0 {
+		t.Errorf("failures: got %d, want 0", count)
+	}
+}
+
+func TestGetTweetUseCase_ExecuteWithMeta_HitReturnsMetaMissDoesNot(t *testing.T) {
+	// Arrange
+	mockCache := NewMockCache()
+	mockCache.SetAged("user", "1", &domain.Tweet{ID: "1"}, time.Minute)
+	mockScraper := &MockScraper{tweet: &domain.Tweet{ID: "2"}}
+	uc := usecases.NewGetTweetUseCase(mockCache, usecases.NewScrapeTweetUseCase(mockScraper))
+
+	// Act
+	_, hitMeta, hitErr := uc.ExecuteWithMeta(context.Background(), "1", "user", usecases.GetTweetOptions{})
+	_, missMeta, missErr := uc.ExecuteWithMeta(context.Background(), "2", "user", usecases.GetTweetOptions{})
+
+	// Assert
+	if hitErr != nil || missErr != nil {
+		t.Fatalf("unexpected errors: %v, %v", hitErr, missErr)
+	}
+	if hitMeta == nil {
+		t.Fatal("expected cache meta on a hit")
+	}
+	if age := time.Since(hitMeta.ScrapedAt); age < time.Minute {
+		t.Errorf("ScrapedAt age: got %v, want at least 1m", age)
+	}
+	if missMeta != nil {
+		t.Errorf("expected nil meta on a miss, got %+v", missMeta)
+	}
+}
+
+// MockStaleCache returns its tweet as an expired cache entry.
+type MockStaleCache struct {
+	tweet *domain.Tweet
+}
+
+func (m *MockStaleCache) GetStale(username, tweetID string) (*domain.Tweet, usecases.CacheMeta, bool) {
+	if m.tweet == nil {
+		return nil, usecases.CacheMeta{}, false
+	}
+	return m.tweet, usecases.CacheMeta{ExpiresAt: time.Now().Add(-time.Minute), Stale: true}, true
+}
+
+func TestGetTweetUseCase_StaleFallback_ScraperError_ServesStaleEntry(t *testing.T) {
+	// Arrange
+	stale := &domain.Tweet{ID: "123", Content: domain.Content{Text: "Old"}}
+	mockScraper := &MockScraper{err: domain.ErrScrapingFailed}
+	uc := usecases.NewGetTweetUseCase(NewMockCache(), usecases.NewScrapeTweetUseCase(mockScraper))
+	uc.SetStaleFallback(&MockStaleCache{tweet: stale})
+
+	// Act
+	tweet, meta, err := uc.ExecuteWithMeta(context.Background(), "123", "user", usecases.GetTweetOptions{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tweet != stale {
+		t.Errorf("got %+v, want the stale tweet", tweet)
+	}
+	if meta == nil || !meta.Stale {
+		t.Errorf("expected meta marked stale, got %+v", meta)
+	}
+}
+
+func TestGetTweetUseCase_StaleFallback_DeletedTweet_ReturnsError(t *testing.T) {
+	// Arrange
+	mockScraper := &MockScraper{err: domain.ErrTweetNotFound}
+	uc := usecases.NewGetTweetUseCase(NewMockCache(), usecases.NewScrapeTweetUseCase(mockScraper))
+	uc.SetStaleFallback(&MockStaleCache{tweet: &domain.Tweet{ID: "123"}})
+
+	// Act
+	_, err := uc.Get(context.Background(), "123", "user")
+
+	// Assert
+	if !errors.Is(err, domain.ErrTweetNotFound) {
+		t.Errorf("got %v, want ErrTweetNotFound", err)
+	}
+}
+
+// MockAlerter records failure alerts.
+type MockAlerter struct {
+	alerts chan usecases.FailureAlert
+}
+
+func NewMockAlerter() *MockAlerter {
+	return &MockAlerter{alerts: make(chan usecases.FailureAlert, 10)}
+}
+
+func (m *MockAlerter) Alert(ctx context.Context, alert usecases.FailureAlert) error {
+	m.alerts <- alert
+	return nil
+}
+
+// count waits briefly for in-flight alerts and returns how many were sent.
+func (m *MockAlerter) count() int {
+	time.Sleep(50 * time.Millisecond)
+	return len(m.alerts)
+}
+
+func TestScrapeTweetUseCase_FailureAlert_SuccessResetsRun(t *testing.T) {
+	// Arrange
+	mockScraper := &MockScraper{err: domain.ErrScrapingFailed}
+	alerter := NewMockAlerter()
+	uc := usecases.NewScrapeTweetUseCase(mockScraper)
+	uc.SetFailureAlerter(alerter, usecases.FailureAlertPolicy{Threshold: 2, CoolDown: time.Hour})
+
+	// Act - failure, success, failure never makes two in a row
+	_, _ = uc.Execute(context.Background(), "123", "user")
+	mockScraper.err = nil
+	mockScraper.tweet = &domain.Tweet{ID: "123"}
+	_, _ = uc.Execute(context.Background(), "123", "user")
+	mockScraper.err = domain.ErrScrapingFailed
+	_, _ = uc.Execute(context.Background(), "123", "user")
+
+	// Assert
+	if got := alerter.count(); got != 0 {
+		t.Errorf("alerts: got %d, want 0", got)
+	}
+}
+
+func TestScrapeTweetUseCase_FailureAlert_UnavailableTweetsNotCounted(t *testing.T) {
+	// Arrange
+	mockScraper := &MockScraper{err: domain.ErrTweetNotFound}
+	alerter := NewMockAlerter()
+	uc := usecases.NewScrapeTweetUseCase(mockScraper)
+	uc.SetFailureAlerter(alerter, usecases.FailureAlertPolicy{Threshold: 1, CoolDown: time.Hour})
+
+	// Act
+	_, _ = uc.Execute(context.Background(), "123", "user")
+	mockScraper.err = domain.ErrTweetPrivate
+	_, _ = uc.Execute(context.Background(), "123", "user")
+
+	// Assert
+	if got := alerter.count(); got != 0 {
+		t.Errorf("alerts: got %d, want 0", got)
+	}
+}
+
+func TestScrapeTweetUseCase_FailureAlert_AlertsAgainAfterCoolDown(t *testing.T) {
+	// Arrange
+	mockScraper := &MockScraper{err: domain.ErrTextNotFound}
+	alerter := NewMockAlerter()
+	uc := usecases.NewScrapeTweetUseCase(mockScraper)
+	uc.SetFailureAlerter(alerter, usecases.FailureAlertPolicy{Threshold: 1, CoolDown: 20 * time.Millisecond})
+
+	// Act
+	_, _ = uc.Execute(context.Background(), "123", "user")
+	_, _ = uc.Execute(context.Background(), "123", "user")
+	time.Sleep(30 * time.Millisecond)
+	_, _ = uc.Execute(context.Background(), "123", "user")
+
+	// Assert
+	if got := alerter.count(); got != 2 {
+		t.Errorf("alerts: got %d, want 2", got)
+	}
+}
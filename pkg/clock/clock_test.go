@@ -0,0 +1,72 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFake_AfterFunc_FiresOnlyOnceDue(t *testing.T) {
+	// Arrange
+	c := NewFake(time.Unix(0, 0))
+	fired := 0
+	c.AfterFunc(time.Minute, func() { fired++ })
+
+	// Act & Assert
+	c.Advance(59 * time.Second)
+	if fired != 0 {
+		t.Fatalf("fired before due: %d", fired)
+	}
+	c.Advance(time.Second)
+	if fired != 1 {
+		t.Fatalf("fired: got %d, want 1", fired)
+	}
+	c.Advance(time.Hour)
+	if fired != 1 {
+		t.Errorf("fired again: got %d, want 1", fired)
+	}
+}
+
+func TestFake_Stop_PreventsFiring(t *testing.T) {
+	// Arrange
+	c := NewFake(time.Unix(0, 0))
+	fired := false
+	timer := c.AfterFunc(time.Second, func() { fired = true })
+
+	// Act
+	stopped := timer.Stop()
+	c.Advance(time.Minute)
+
+	// Assert
+	if !stopped {
+		t.Error("Stop() should report the timer was pending")
+	}
+	if fired {
+		t.Error("stopped timer fired")
+	}
+	if timer.Stop() {
+		t.Error("second Stop() should return false")
+	}
+}
+
+func TestFake_After_DeliversAdvancedTime(t *testing.T) {
+	// Arrange
+	start := time.Unix(0, 0)
+	c := NewFake(start)
+	ch := c.After(time.Second)
+
+	// Act
+	c.Advance(2 * time.Second)
+
+	// Assert
+	select {
+	case got := <-ch:
+		if !got.Equal(start.Add(2 * time.Second)) {
+			t.Errorf("time: got %v, want %v", got, start.Add(2*time.Second))
+		}
+	default:
+		t.Fatal("After channel did not fire")
+	}
+	if now := c.Now(); !now.Equal(start.Add(2 * time.Second)) {
+		t.Errorf("Now: got %v", now)
+	}
+}
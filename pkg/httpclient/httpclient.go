@@ -0,0 +1,55 @@
+// Package httpclient builds the http.Client shared by every outbound HTTP
+// call, so a corporate proxy, a timeout or a test transport is set in one
+// place.
+package httpclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultTimeout bounds a whole request when Options.Timeout is zero.
+const DefaultTimeout = 10 * time.Second
+
+// Options configures the client built by New.
+type Options struct {
+	// Timeout bounds a whole request (connect, headers and body).
+	// Zero uses DefaultTimeout.
+	Timeout time.Duration
+
+	// Proxy is the URL every request is sent through, e.g.
+	// http://proxy.corp:3128. Empty uses the environment: HTTPS_PROXY or
+	// HTTP_PROXY, except for hosts listed in NO_PROXY.
+	Proxy string
+
+	// Transport replaces the default transport, e.g. with a stub in tests.
+	// Proxy is ignored when it is set.
+	Transport http.RoundTripper
+}
+
+// New returns a client configured by opts. It fails if opts.Proxy is not an
+// absolute URL.
+func New(opts Options) (*http.Client, error) {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	transport := opts.Transport
+	if transport == nil {
+		t := http.DefaultTransport.(*http.Transport).Clone()
+		t.Proxy = http.ProxyFromEnvironment
+		if opts.Proxy != "" {
+			proxy, err := url.Parse(opts.Proxy)
+			if err != nil || proxy.Scheme == "" || proxy.Host == "" {
+				return nil, fmt.Errorf("invalid proxy URL %q", opts.Proxy)
+			}
+			t.Proxy = http.ProxyURL(proxy)
+		}
+		transport = t
+	}
+
+	return &http.Client{Timeout: timeout, Transport: transport}, nil
+}
@@ -0,0 +1,106 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNew_InjectedTransport_IsUsed(t *testing.T) {
+	// Arrange
+	var got string
+	client, err := New(Options{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		got = req.URL.String()
+		return httptest.NewRecorder().Result(), nil
+	})})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// Act
+	resp, err := client.Get("https://example.com/ping")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	resp.Body.Close()
+	if got != "https://example.com/ping" {
+		t.Errorf("transport saw %q, want the request", got)
+	}
+}
+
+func TestNew_Proxy_RoutesRequestsThroughIt(t *testing.T) {
+	// Arrange
+	client, err := New(Options{Proxy: "http://proxy.corp:3128"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/", nil)
+
+	// Act
+	proxy, err := client.Transport.(*http.Transport).Proxy(req)
+
+	// Assert
+	if err != nil || proxy == nil || proxy.Host != "proxy.corp:3128" {
+		t.Errorf("proxy = %v (err %v), want proxy.corp:3128", proxy, err)
+	}
+}
+
+func TestNew_Defaults(t *testing.T) {
+	// Act
+	client, err := New(Options{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if client.Timeout != DefaultTimeout {
+		t.Errorf("Timeout = %v, want %v", client.Timeout, DefaultTimeout)
+	}
+}
+
+func TestNew_NoProxyOption_UsesEnvironment(t *testing.T) {
+	// Arrange - http.ProxyFromEnvironment reads these once per process,
+	// so no other test may resolve a proxy from the environment
+	t.Setenv("HTTPS_PROXY", "http://env-proxy.corp:3128")
+	t.Setenv("NO_PROXY", "internal.corp")
+	client, err := New(Options{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	proxyFor := client.Transport.(*http.Transport).Proxy
+
+	// Act
+	external, err := proxyFor(httptest.NewRequest(http.MethodGet, "https://example.com/", nil))
+	if err != nil {
+		t.Fatalf("Proxy() error = %v", err)
+	}
+	internal, err := proxyFor(httptest.NewRequest(http.MethodGet, "https://hooks.internal.corp/", nil))
+	if err != nil {
+		t.Fatalf("Proxy() error = %v", err)
+	}
+
+	// Assert
+	if external == nil || external.Host != "env-proxy.corp:3128" {
+		t.Errorf("external host: proxy = %v, want env-proxy.corp:3128", external)
+	}
+	if internal != nil {
+		t.Errorf("NO_PROXY host: proxy = %v, want a direct connection", internal)
+	}
+}
+
+func TestNew_InvalidProxy_ReturnsError(t *testing.T) {
+	for _, proxy := range []string{"proxy.corp:3128", "://nope"} {
+		if _, err := New(Options{Proxy: proxy}); err == nil {
+			t.Errorf("expected an error for proxy %q", proxy)
+		}
+	}
+}
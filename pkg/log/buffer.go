@@ -9,22 +9,37 @@ import (
 
 // Buffer provides asynchronous log delivery with a ring buffer.
 // When the buffer is full, oldest entries are dropped.
+// A buffer created with capacity 0 is synchronous instead: Send delivers
+// the entry on the calling goroutine before returning.
 type Buffer struct {
 	entries      chan Entry
 	transporters []Transporter
 	dropped      int64
 	closed       int32
 	done         chan struct{}
+	flush        chan chan struct{}
 	wg           sync.WaitGroup
+	sync         bool
+	syncMu       sync.Mutex // serializes inline delivery in sync mode
 }
 
 // NewBuffer creates a new async buffer with the given capacity.
 // Logs are sent to all provided transporters.
+// A capacity of 0 (or less) creates a synchronous buffer with no worker.
 func NewBuffer(capacity int, transporters ...Transporter) *Buffer {
+	if capacity <= 0 {
+		return &Buffer{
+			transporters: transporters,
+			done:         make(chan struct{}),
+			sync:         true,
+		}
+	}
+
 	b := &Buffer{
 		entries:      make(chan Entry, capacity),
 		transporters: transporters,
 		done:         make(chan struct{}),
+		flush:        make(chan chan struct{}),
 	}
 
 	b.wg.Add(1)
@@ -35,12 +50,20 @@ func NewBuffer(capacity int, transporters ...Transporter) *Buffer {
 
 // Send queues an entry for async delivery.
 // If the buffer is full, the oldest entry is dropped.
+// In sync mode the entry is delivered before Send returns.
 // Safe to call from multiple goroutines.
 func (b *Buffer) Send(entry Entry) {
 	if atomic.LoadInt32(&b.closed) == 1 {
 		return
 	}
 
+	if b.sync {
+		b.syncMu.Lock()
+		b.deliver(entry)
+		b.syncMu.Unlock()
+		return
+	}
+
 	select {
 	case b.entries <- entry:
 		// Successfully queued
@@ -66,6 +89,26 @@ func (b *Buffer) DroppedCount() int64 {
 	return atomic.LoadInt64(&b.dropped)
 }
 
+// Flush blocks until every entry queued before the call has been delivered.
+// Unlike Close, the buffer stays usable afterward.
+// Safe to call concurrently with Send; returns immediately once closed.
+func (b *Buffer) Flush() {
+	if b.sync {
+		// Wait for any inline delivery in progress
+		b.syncMu.Lock()
+		b.syncMu.Unlock()
+		return
+	}
+
+	ack := make(chan struct{})
+	select {
+	case b.flush <- ack:
+		<-ack
+	case <-b.done:
+		// Close drains the queue itself
+	}
+}
+
 // Close stops the worker and flushes remaining entries.
 // Safe to call multiple times.
 func (b *Buffer) Close() {
@@ -76,7 +119,11 @@ func (b *Buffer) Close() {
 	close(b.done)
 	b.wg.Wait()
 
-	// Flush remaining entries
+	b.drain()
+}
+
+// drain delivers queued entries until the queue is empty.
+func (b *Buffer) drain() {
 	for {
 		select {
 		case entry := <-b.entries:
@@ -95,6 +142,9 @@ func (b *Buffer) worker() {
 		select {
 		case entry := <-b.entries:
 			b.deliver(entry)
+		case ack := <-b.flush:
+			b.drain()
+			close(ack)
 		case <-b.done:
 			return
 		}
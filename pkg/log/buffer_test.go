@@ -215,3 +215,124 @@ func TestBuffer_Concurrent_Send_Safe(t *testing.T) {
 		t.Errorf("delivered(%d) + dropped(%d) = %d, want >= %d", len(entries), dropped, total, sent)
 	}
 }
+
+func TestBuffer_SyncMode_DeliversImmediately(t *testing.T) {
+	transport := &testTransporter{}
+	buf := NewBuffer(0, transport)
+	defer buf.Close()
+
+	buf.Send(*NewEntry(Info, "first"))
+	buf.Send(*NewEntry(Info, "second"))
+
+	entries := transport.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("entries count = %d, want 2", len(entries))
+	}
+	if entries[0].Message != "first" || entries[1].Message != "second" {
+		t.Errorf("messages = %q, %q, want in send order", entries[0].Message, entries[1].Message)
+	}
+}
+
+func TestBuffer_SyncMode_ConcurrentSend(t *testing.T) {
+	transport := &testTransporter{}
+	buf := NewBuffer(0, transport)
+	defer buf.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				buf.Send(*NewEntry(Info, "concurrent"))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := len(transport.Entries()); got != 100 {
+		t.Errorf("entries count = %d, want 100", got)
+	}
+	if buf.DroppedCount() != 0 {
+		t.Errorf("DroppedCount = %d, want 0", buf.DroppedCount())
+	}
+}
+
+func TestBuffer_SyncMode_IgnoresSendAfterClose(t *testing.T) {
+	transport := &testTransporter{}
+	buf := NewBuffer(0, transport)
+
+	buf.Close()
+	buf.Send(*NewEntry(Info, "after close"))
+
+	if got := len(transport.Entries()); got != 0 {
+		t.Errorf("entries count = %d, want 0", got)
+	}
+}
+
+func TestBuffer_Flush_DeliversPendingWithoutClose(t *testing.T) {
+	transport := &testTransporter{delay: time.Millisecond}
+	buf := NewBuffer(100, transport)
+	defer buf.Close()
+
+	for i := 0; i < 20; i++ {
+		buf.Send(*NewEntry(Info, "pending"))
+	}
+	buf.Flush()
+
+	if got := len(transport.Entries()); got != 20 {
+		t.Fatalf("entries count after Flush = %d, want 20", got)
+	}
+
+	// Buffer is still usable after Flush
+	buf.Send(*NewEntry(Info, "after flush"))
+	buf.Flush()
+
+	if got := len(transport.Entries()); got != 21 {
+		t.Errorf("entries count = %d, want 21", got)
+	}
+}
+
+func TestBuffer_Flush_ConcurrentWithSend(t *testing.T) {
+	transport := &testTransporter{}
+	buf := NewBuffer(1000, transport)
+	defer buf.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				buf.Send(*NewEntry(Info, "concurrent"))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			buf.Flush()
+		}()
+	}
+	wg.Wait()
+	buf.Flush()
+
+	if got := len(transport.Entries()); got != 250 {
+		t.Errorf("entries count = %d, want 250", got)
+	}
+}
+
+func TestBuffer_Flush_AfterCloseReturns(t *testing.T) {
+	buf := NewBuffer(10, &testTransporter{})
+	buf.Close()
+
+	done := make(chan struct{})
+	go func() {
+		buf.Flush()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Flush blocked after Close")
+	}
+}
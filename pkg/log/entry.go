@@ -2,9 +2,74 @@ package log
 
 import (
 	"encoding/json"
+	"sync/atomic"
 	"time"
 )
 
+// TimeFormatNano is RFC3339 with a fixed nine-digit fraction. Unlike
+// time.RFC3339Nano it never trims trailing zeros, so timestamps keep
+// sub-second order when sorted as strings.
+const TimeFormatNano = "2006-01-02T15:04:05.000000000Z07:00"
+
+// FieldNames configures the JSON keys of an entry's built-in fields and the
+// timestamp layout, so output can match an existing log schema.
+type FieldNames struct {
+	Timestamp  string
+	Level      string
+	Message    string
+	Caller     string
+	RequestID  string
+	TimeFormat string // time.Format layout
+}
+
+// DefaultFieldNames returns the keys and timestamp layout used unless
+// SetFieldNames overrides them.
+func DefaultFieldNames() FieldNames {
+	return FieldNames{
+		Timestamp:  "timestamp",
+		Level:      "level",
+		Message:    "msg",
+		Caller:     "caller",
+		RequestID:  "request_id",
+		TimeFormat: TimeFormatNano,
+	}
+}
+
+var fieldNames atomic.Pointer[FieldNames]
+
+// SetFieldNames changes the keys and timestamp layout of every entry
+// marshaled afterward. Empty members keep their default.
+func SetFieldNames(names FieldNames) {
+	defaults := DefaultFieldNames()
+	if names.Timestamp == "" {
+		names.Timestamp = defaults.Timestamp
+	}
+	if names.Level == "" {
+		names.Level = defaults.Level
+	}
+	if names.Message == "" {
+		names.Message = defaults.Message
+	}
+	if names.Caller == "" {
+		names.Caller = defaults.Caller
+	}
+	if names.RequestID == "" {
+		names.RequestID = defaults.RequestID
+	}
+	if names.TimeFormat == "" {
+		names.TimeFormat = defaults.TimeFormat
+	}
+	fieldNames.Store(&names)
+}
+
+// currentFieldNames returns the configured field names.
+func currentFieldNames() FieldNames {
+	if names := fieldNames.Load(); names != nil {
+		return *names
+	}
+	return DefaultFieldNames()
+}
+
 // Entry represents a structured log entry.
 type Entry struct {
 	Timestamp time.Time
@@ -43,19 +108,21 @@ func (e *Entry) With(keysAndValues ...any) *Entry {
 // Fields are flattened into the root object.
 // Empty optional fields (caller, request_id) are omitted.
 // Error values are automatically converted to strings.
+// Keys and the timestamp layout follow SetFieldNames.
 func (e Entry) MarshalJSON() ([]byte, error) {
+	names := currentFieldNames()
 	m := make(map[string]any)
 
-	m["timestamp"] = e.Timestamp.UTC().Format(time.RFC3339)
-	m["level"] = e.Level.String()
-	m["msg"] = e.Message
+	m[names.Timestamp] = e.Timestamp.UTC().Format(names.TimeFormat)
+	m[names.Level] = e.Level.String()
+	m[names.Message] = e.Message
 
 	if e.Caller != "" {
-		m["caller"] = e.Caller
+		m[names.Caller] = e.Caller
 	}
 
 	if e.RequestID != "" {
-		m["request_id"] = e.RequestID
+		m[names.RequestID] = e.RequestID
 	}
 
 	// Flatten fields into root, converting errors to strings
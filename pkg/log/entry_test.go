@@ -27,9 +27,9 @@ func TestEntry_MarshalJSON_ContainsAllFields(t *testing.T) {
 		t.Fatalf("json.Unmarshal() error = %v", err)
 	}
 
-	// Check timestamp is ISO8601
-	if result["timestamp"] != "2026-01-03T12:00:00Z" {
-		t.Errorf("timestamp = %v, want %v", result["timestamp"], "2026-01-03T12:00:00Z")
+	// Check timestamp is ISO8601 with nanoseconds
+	if result["timestamp"] != "2026-01-03T12:00:00.000000000Z" {
+		t.Errorf("timestamp = %v, want %v", result["timestamp"], "2026-01-03T12:00:00.000000000Z")
 	}
 
 	// Check level is string
@@ -204,3 +204,95 @@ type testError struct {
 func (e testError) Error() string {
 	return e.msg
 }
+
+func TestEntry_MarshalJSON_CustomFieldNames(t *testing.T) {
+	SetFieldNames(FieldNames{
+		Timestamp:  "time",
+		Message:    "message",
+		RequestID:  "trace_id",
+		TimeFormat: time.RFC3339Nano,
+	})
+	defer SetFieldNames(DefaultFieldNames())
+
+	entry := Entry{
+		Timestamp: time.Date(2026, 1, 3, 12, 0, 0, 123456789, time.UTC),
+		Level:     Warn,
+		Caller:    "main.go:42",
+		RequestID: "req-123",
+		Message:   "rekeyed",
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if result["time"] != "2026-01-03T12:00:00.123456789Z" {
+		t.Errorf("time = %v, want %v", result["time"], "2026-01-03T12:00:00.123456789Z")
+	}
+	if result["message"] != "rekeyed" {
+		t.Errorf("message = %v, want %v", result["message"], "rekeyed")
+	}
+	if result["trace_id"] != "req-123" {
+		t.Errorf("trace_id = %v, want %v", result["trace_id"], "req-123")
+	}
+	// Unset names keep their default
+	if result["level"] != "WARN" {
+		t.Errorf("level = %v, want %v", result["level"], "WARN")
+	}
+	if result["caller"] != "main.go:42" {
+		t.Errorf("caller = %v, want %v", result["caller"], "main.go:42")
+	}
+	for _, key := range []string{"timestamp", "msg", "request_id"} {
+		if _, ok := result[key]; ok {
+			t.Errorf("default key %q should be replaced", key)
+		}
+	}
+}
+
+func TestDefaultFieldNames_MatchesDefaultOutput(t *testing.T) {
+	names := DefaultFieldNames()
+
+	if names.Timestamp != "timestamp" || names.Message != "msg" || names.TimeFormat != TimeFormatNano {
+		t.Errorf("DefaultFieldNames() = %+v", names)
+	}
+}
+
+func TestEntry_MarshalJSON_TimestampKeepsSubSecondOrder(t *testing.T) {
+	base := time.Date(2026, 1, 3, 12, 0, 0, 0, time.UTC)
+	// Two rapid entries within the same second, the first on a whole second
+	first := Entry{Timestamp: base, Level: Info, Message: "first"}
+	second := Entry{Timestamp: base.Add(1500 * time.Nanosecond), Level: Info, Message: "second"}
+
+	timestamps := make([]string, 0, 2)
+	for _, entry := range []Entry{first, second} {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+		var result map[string]any
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+		ts, _ := result["timestamp"].(string)
+
+		// Still valid RFC3339 / ISO8601, with no precision lost
+		parsed, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			t.Fatalf("timestamp %q is not RFC3339: %v", ts, err)
+		}
+		if !parsed.Equal(entry.Timestamp) {
+			t.Errorf("timestamp %q parses to %v, want %v", ts, parsed, entry.Timestamp)
+		}
+		timestamps = append(timestamps, ts)
+	}
+
+	if timestamps[0] >= timestamps[1] {
+		t.Errorf("timestamps sort out of order: %q >= %q", timestamps[0], timestamps[1])
+	}
+}
@@ -3,6 +3,7 @@ package log
 import (
 	"context"
 	"fmt"
+	"os"
 	"runtime"
 	"sync"
 )
@@ -12,6 +13,8 @@ type Logger struct {
 	level      Level
 	buffer     *Buffer
 	baseFields map[string]any
+	callerSkip int // extra frames skipped when reporting the caller
+	callerMode CallerMode
 	mu         sync.RWMutex
 }
 
@@ -24,6 +27,32 @@ func New(level Level, transporters ...Transporter) *Logger {
 	}
 }
 
+// NewWithDefaults creates a logger whose entries all carry the instance's
+// hostname and pid, and the given service name, to tell replicas apart.
+func NewWithDefaults(level Level, service string, transporters ...Transporter) *Logger {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	l := New(level, transporters...)
+	l.baseFields["hostname"] = hostname
+	l.baseFields["pid"] = os.Getpid()
+	l.baseFields["service"] = service
+	return l
+}
+
+// NewSync creates a logger that delivers every entry to the transporters
+// before the logging call returns. Useful for tests and short-lived CLIs
+// that would otherwise exit before the async worker flushes.
+func NewSync(level Level, transporters ...Transporter) *Logger {
+	return &Logger{
+		level:      level,
+		buffer:     NewBuffer(0, transporters...),
+		baseFields: make(map[string]any),
+	}
+}
+
 // SetLevel changes the minimum log level.
 func (l *Logger) SetLevel(level Level) {
 	l.mu.Lock()
@@ -31,6 +60,23 @@ func (l *Logger) SetLevel(level Level) {
 	l.mu.Unlock()
 }
 
+// CallerMode controls how much of the source path the caller field keeps.
+type CallerMode int
+
+const (
+	// CallerFile keeps only the base filename, e.g. "parser.go:42".
+	CallerFile CallerMode = iota
+	// CallerPath keeps the parent directory too, e.g. "scraper/parser.go:42".
+	CallerPath
+)
+
+// SetCallerMode changes how the caller field is formatted.
+func (l *Logger) SetCallerMode(mode CallerMode) {
+	l.mu.Lock()
+	l.callerMode = mode
+	l.mu.Unlock()
+}
+
 // With creates a child logger with additional base fields.
 func (l *Logger) With(keysAndValues ...any) *Logger {
 	l.mu.RLock()
@@ -38,6 +84,7 @@ func (l *Logger) With(keysAndValues ...any) *Logger {
 	for k, v := range l.baseFields {
 		newFields[k] = v
 	}
+	callerMode := l.callerMode
 	l.mu.RUnlock()
 
 	for i := 0; i+1 < len(keysAndValues); i += 2 {
@@ -50,9 +97,30 @@ func (l *Logger) With(keysAndValues ...any) *Logger {
 		level:      l.level,
 		buffer:     l.buffer,
 		baseFields: newFields,
+		callerSkip: l.callerSkip,
+		callerMode: callerMode,
 	}
 }
 
+// WithCallerSkip creates a child logger that skips skip more stack frames
+// when reporting the caller. Helpers wrapping the logger use it so entries
+// point at the helper's caller instead of the helper itself.
+func (l *Logger) WithCallerSkip(skip int) *Logger {
+	child := l.With()
+	child.callerSkip += skip
+	return child
+}
+
+// Flush blocks until all pending entries are delivered, keeping the logger open.
+func (l *Logger) Flush() {
+	l.buffer.Flush()
+}
+
+// DroppedCount returns how many entries were dropped because the buffer was full.
+func (l *Logger) DroppedCount() int64 {
+	return l.buffer.DroppedCount()
+}
+
 // Close shuts down the logger and flushes remaining entries.
 func (l *Logger) Close() {
 	l.buffer.Close()
@@ -62,6 +130,7 @@ func (l *Logger) Close() {
 func (l *Logger) log(level Level, ctx context.Context, msg string, keysAndValues ...any) {
 	l.mu.RLock()
 	minLevel := l.level
+	callerMode := l.callerMode
 	l.mu.RUnlock()
 
 	if !minLevel.Enables(level) {
@@ -69,7 +138,7 @@ func (l *Logger) log(level Level, ctx context.Context, msg string, keysAndValues
 	}
 
 	entry := NewEntry(level, msg)
-	entry.Caller = getCaller(3)
+	entry.Caller = getCaller(3+l.callerSkip, callerMode)
 
 	// Add base fields
 	l.mu.RLock()
@@ -98,18 +167,25 @@ func (l *Logger) log(level Level, ctx context.Context, msg string, keysAndValues
 }
 
 // getCaller returns the file:line of the caller.
-func getCaller(skip int) string {
+func getCaller(skip int, mode CallerMode) string {
 	_, file, line, ok := runtime.Caller(skip)
 	if !ok {
 		return ""
 	}
 
-	// Get just the filename, not full path
+	// Keep the last segment, or the last two with CallerPath
+	segments := 1
+	if mode == CallerPath {
+		segments = 2
+	}
 	short := file
 	for i := len(file) - 1; i > 0; i-- {
 		if file[i] == '/' {
-			short = file[i+1:]
-			break
+			segments--
+			if segments == 0 {
+				short = file[i+1:]
+				break
+			}
 		}
 	}
 
@@ -215,63 +291,64 @@ func (n *noopTransporter) Write(Entry) error { return nil }
 func (n *noopTransporter) Close() error      { return nil }
 
 // Global convenience functions
+// They call log directly, so the reported caller is their own caller.
 
 // GlobalTrace logs at Trace level using the global logger.
 func GlobalTrace(msg string, keysAndValues ...any) {
-	Default().Trace(msg, keysAndValues...)
+	Default().log(Trace, nil, msg, keysAndValues...)
 }
 
 // GlobalDebug logs at Debug level using the global logger.
 func GlobalDebug(msg string, keysAndValues ...any) {
-	Default().Debug(msg, keysAndValues...)
+	Default().log(Debug, nil, msg, keysAndValues...)
 }
 
 // GlobalInfo logs at Info level using the global logger.
 func GlobalInfo(msg string, keysAndValues ...any) {
-	Default().Info(msg, keysAndValues...)
+	Default().log(Info, nil, msg, keysAndValues...)
 }
 
 // GlobalWarn logs at Warn level using the global logger.
 func GlobalWarn(msg string, keysAndValues ...any) {
-	Default().Warn(msg, keysAndValues...)
+	Default().log(Warn, nil, msg, keysAndValues...)
 }
 
 // GlobalError logs at Error level using the global logger.
 func GlobalError(msg string, keysAndValues ...any) {
-	Default().Error(msg, keysAndValues...)
+	Default().log(Error, nil, msg, keysAndValues...)
 }
 
 // GlobalFatal logs at Fatal level using the global logger.
 func GlobalFatal(msg string, keysAndValues ...any) {
-	Default().Fatal(msg, keysAndValues...)
+	Default().log(Fatal, nil, msg, keysAndValues...)
 }
 
 // GlobalTraceCtx logs at Trace level with context using the global logger.
 func GlobalTraceCtx(ctx context.Context, msg string, keysAndValues ...any) {
-	Default().TraceCtx(ctx, msg, keysAndValues...)
+	Default().log(Trace, ctx, msg, keysAndValues...)
 }
 
 // GlobalDebugCtx logs at Debug level with context using the global logger.
 func GlobalDebugCtx(ctx context.Context, msg string, keysAndValues ...any) {
-	Default().DebugCtx(ctx, msg, keysAndValues...)
+	Default().log(Debug, ctx, msg, keysAndValues...)
 }
 
 // GlobalInfoCtx logs at Info level with context using the global logger.
 func GlobalInfoCtx(ctx context.Context, msg string, keysAndValues ...any) {
-	Default().InfoCtx(ctx, msg, keysAndValues...)
+	Default().log(Info, ctx, msg, keysAndValues...)
 }
 
 // GlobalWarnCtx logs at Warn level with context using the global logger.
 func GlobalWarnCtx(ctx context.Context, msg string, keysAndValues ...any) {
-	Default().WarnCtx(ctx, msg, keysAndValues...)
+	Default().log(Warn, ctx, msg, keysAndValues...)
 }
 
 // GlobalErrorCtx logs at Error level with context using the global logger.
 func GlobalErrorCtx(ctx context.Context, msg string, keysAndValues ...any) {
-	Default().ErrorCtx(ctx, msg, keysAndValues...)
+	Default().log(Error, ctx, msg, keysAndValues...)
 }
 
 // GlobalFatalCtx logs at Fatal level with context using the global logger.
 func GlobalFatalCtx(ctx context.Context, msg string, keysAndValues ...any) {
-	Default().FatalCtx(ctx, msg, keysAndValues...)
+	Default().log(Fatal, ctx, msg, keysAndValues...)
 }
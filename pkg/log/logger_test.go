@@ -2,6 +2,9 @@ package log
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"runtime"
 	"strings"
 	"sync"
 	"testing"
@@ -72,6 +75,30 @@ func TestLogger_Info_CreatesInfoEntry(t *testing.T) {
 	}
 }
 
+func TestNewWithDefaults_AddsHostPidAndService(t *testing.T) {
+	capture := &captureTransporter{}
+	logger := NewWithDefaults(Info, "sumariza-test", capture)
+	defer logger.Close()
+
+	logger.Info("test message")
+	time.Sleep(50 * time.Millisecond)
+
+	entry := capture.Last()
+	if entry == nil {
+		t.Fatal("no entry captured")
+	}
+	hostname, _ := os.Hostname()
+	if hostname != "" && entry.Fields["hostname"] != hostname {
+		t.Errorf("hostname = %v, want %q", entry.Fields["hostname"], hostname)
+	}
+	if entry.Fields["pid"] != os.Getpid() {
+		t.Errorf("pid = %v, want %d", entry.Fields["pid"], os.Getpid())
+	}
+	if entry.Fields["service"] != "sumariza-test" {
+		t.Errorf("service = %v, want %q", entry.Fields["service"], "sumariza-test")
+	}
+}
+
 func TestLogger_Error_CreatesErrorEntry(t *testing.T) {
 	logger, capture := setupTestLogger()
 	defer logger.Close()
@@ -185,6 +212,88 @@ func TestLogger_Info_IncludesCaller(t *testing.T) {
 	}
 }
 
+func TestLogger_CallerPath_IncludesParentDir(t *testing.T) {
+	logger, capture := setupTestLogger()
+	defer logger.Close()
+
+	logger.SetCallerMode(CallerPath)
+	logger.Info("test")
+	time.Sleep(50 * time.Millisecond)
+
+	entry := capture.Last()
+	if entry == nil {
+		t.Fatal("no entry captured")
+	}
+	if !strings.HasPrefix(entry.Caller, "log/logger_test.go:") {
+		t.Errorf("Caller = %q, should start with 'log/logger_test.go:'", entry.Caller)
+	}
+}
+
+func TestLogger_CallerFile_OmitsParentDir(t *testing.T) {
+	logger, capture := setupTestLogger()
+	defer logger.Close()
+
+	logger.Info("test")
+	time.Sleep(50 * time.Millisecond)
+
+	entry := capture.Last()
+	if entry == nil {
+		t.Fatal("no entry captured")
+	}
+	if strings.Contains(entry.Caller, "/") {
+		t.Errorf("Caller = %q, should not contain a directory", entry.Caller)
+	}
+}
+
+// logViaWrapper is a one-level helper around the logger.
+func logViaWrapper(logger *Logger, msg string) {
+	logger.WithCallerSkip(1).Info(msg)
+}
+
+// nextLine returns "logger_test.go:<line>" for the line after its call site.
+func nextLine() string {
+	_, _, line, _ := runtime.Caller(1)
+	return fmt.Sprintf("logger_test.go:%d", line+1)
+}
+
+func TestLogger_WithCallerSkip_ReportsWrapperCaller(t *testing.T) {
+	logger, capture := setupTestLogger()
+	defer logger.Close()
+
+	want := nextLine()
+	logViaWrapper(logger, "wrapped")
+	time.Sleep(50 * time.Millisecond)
+
+	entry := capture.Last()
+	if entry == nil {
+		t.Fatal("no entry captured")
+	}
+	if entry.Caller != want {
+		t.Errorf("Caller = %q, want %q", entry.Caller, want)
+	}
+}
+
+func TestLogger_WithCallerSkip_KeptByWith(t *testing.T) {
+	logger, capture := setupTestLogger()
+	defer logger.Close()
+
+	child := logger.WithCallerSkip(1).With("component", "scraper")
+	if child.callerSkip != 1 {
+		t.Fatalf("callerSkip = %d, want 1", child.callerSkip)
+	}
+
+	child.Info("from child")
+	time.Sleep(50 * time.Millisecond)
+
+	entry := capture.Last()
+	if entry == nil {
+		t.Fatal("no entry captured")
+	}
+	if entry.Fields["component"] != "scraper" {
+		t.Errorf("Fields[component] = %v, want %q", entry.Fields["component"], "scraper")
+	}
+}
+
 func TestLogger_With_CreatesChildLogger(t *testing.T) {
 	logger, capture := setupTestLogger()
 	defer logger.Close()
@@ -238,6 +347,38 @@ func TestLogger_Fatal_LogsAtFatalLevel(t *testing.T) {
 	}
 }
 
+func TestNewSync_EntriesVisibleWithoutSleep(t *testing.T) {
+	capture := &captureTransporter{}
+	logger := NewSync(Info, capture)
+	defer logger.Close()
+
+	logger.Info("sync message", "key", "value")
+
+	entry := capture.Last()
+	if entry == nil {
+		t.Fatal("no entry captured")
+	}
+	if entry.Message != "sync message" {
+		t.Errorf("Message = %q, want %q", entry.Message, "sync message")
+	}
+	if entry.Fields["key"] != "value" {
+		t.Errorf("Fields[key] = %v, want %q", entry.Fields["key"], "value")
+	}
+}
+
+func TestLogger_Flush_DeliversWithoutClose(t *testing.T) {
+	logger, capture := setupTestLogger()
+	defer logger.Close()
+
+	logger.Info("one")
+	logger.Info("two")
+	logger.Flush()
+
+	if got := len(capture.Entries()); got != 2 {
+		t.Errorf("entries count = %d, want 2", got)
+	}
+}
+
 // Test global logger functions
 func TestGlobal_SetDefault_ConfiguresGlobal(t *testing.T) {
 	capture := &captureTransporter{}
@@ -259,3 +400,22 @@ func TestGlobal_SetDefault_ConfiguresGlobal(t *testing.T) {
 
 	logger.Close()
 }
+
+func TestGlobal_ReportsCallSite(t *testing.T) {
+	capture := &captureTransporter{}
+	logger := New(Info, capture)
+	SetDefault(logger)
+	defer logger.Close()
+
+	want := nextLine()
+	GlobalInfo("global caller")
+	time.Sleep(50 * time.Millisecond)
+
+	entry := capture.Last()
+	if entry == nil {
+		t.Fatal("no entry captured via global")
+	}
+	if entry.Caller != want {
+		t.Errorf("Caller = %q, want %q", entry.Caller, want)
+	}
+}
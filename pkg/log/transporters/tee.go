@@ -0,0 +1,48 @@
+package transporters
+
+import (
+	"errors"
+	"fmt"
+
+	"sumariza-ai/pkg/log"
+)
+
+// Tee forwards every entry to several transporters, so a dynamically built
+// set of destinations can be passed to log.New as one.
+type Tee struct {
+	transporters []log.Transporter
+}
+
+// NewTee creates a transporter that writes to each of transporters in order.
+func NewTee(transporters ...log.Transporter) *Tee {
+	return &Tee{transporters: transporters}
+}
+
+// Name returns the transporter identifier.
+func (t *Tee) Name() string {
+	return "tee"
+}
+
+// Write sends the entry to every wrapped transporter, even if some fail.
+// The returned error joins each failure, prefixed with the transporter name.
+func (t *Tee) Write(entry log.Entry) error {
+	var errs []error
+	for _, tr := range t.transporters {
+		if err := tr.Write(entry); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", tr.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes every wrapped transporter, even if some fail.
+// The returned error joins each failure, prefixed with the transporter name.
+func (t *Tee) Close() error {
+	var errs []error
+	for _, tr := range t.transporters {
+		if err := tr.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", tr.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
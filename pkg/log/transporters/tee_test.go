@@ -0,0 +1,91 @@
+package transporters
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"sumariza-ai/pkg/log"
+)
+
+// fakeTransporter records entries and returns the configured errors.
+type fakeTransporter struct {
+	name     string
+	entries  []log.Entry
+	writeErr error
+	closeErr error
+	closed   bool
+}
+
+func (f *fakeTransporter) Name() string { return f.name }
+
+func (f *fakeTransporter) Write(entry log.Entry) error {
+	f.entries = append(f.entries, entry)
+	return f.writeErr
+}
+
+func (f *fakeTransporter) Close() error {
+	f.closed = true
+	return f.closeErr
+}
+
+func TestTee_ImplementsTransporter(t *testing.T) {
+	var _ log.Transporter = &Tee{}
+}
+
+func TestTee_Name_ReturnsTee(t *testing.T) {
+	if name := NewTee().Name(); name != "tee" {
+		t.Errorf("Name() = %q, want %q", name, "tee")
+	}
+}
+
+func TestTee_Write_AllTransportersReceiveEntry(t *testing.T) {
+	a := &fakeTransporter{name: "a", writeErr: errors.New("disk full")}
+	b := &fakeTransporter{name: "b"}
+	tee := NewTee(a, b)
+
+	entry := log.Entry{Timestamp: time.Now(), Level: log.Info, Message: "fan out"}
+	err := tee.Write(entry)
+
+	if len(a.entries) != 1 || len(b.entries) != 1 {
+		t.Fatalf("entries: a=%d b=%d, want 1 each", len(a.entries), len(b.entries))
+	}
+	if b.entries[0].Message != "fan out" {
+		t.Errorf("b received %q, want 'fan out'", b.entries[0].Message)
+	}
+	if err == nil || !strings.Contains(err.Error(), "a: disk full") {
+		t.Errorf("Write() error = %v, want a's failure", err)
+	}
+}
+
+func TestTee_Close_ReportsErrorAndClosesAll(t *testing.T) {
+	closeErr := errors.New("flush failed")
+	a := &fakeTransporter{name: "a"}
+	b := &fakeTransporter{name: "b", closeErr: closeErr}
+	c := &fakeTransporter{name: "c"}
+	tee := NewTee(a, b, c)
+
+	err := tee.Close()
+
+	if !errors.Is(err, closeErr) {
+		t.Errorf("Close() error = %v, want %v", err, closeErr)
+	}
+	if err != nil && !strings.HasPrefix(err.Error(), "b: ") {
+		t.Errorf("Close() error = %q, want it prefixed with the transporter name", err)
+	}
+	if !a.closed || !b.closed || !c.closed {
+		t.Errorf("closed: a=%v b=%v c=%v, want all", a.closed, b.closed, c.closed)
+	}
+}
+
+func TestTee_NoErrors_ReturnsNil(t *testing.T) {
+	tee := NewTee(&fakeTransporter{name: "a"}, &fakeTransporter{name: "b"})
+
+	if err := tee.Write(log.Entry{Message: "ok"}); err != nil {
+		t.Errorf("Write() error = %v", err)
+	}
+	if err := tee.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
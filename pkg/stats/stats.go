@@ -0,0 +1,129 @@
+// Package stats collects process-wide counters for a lightweight /stats
+// endpoint, without pulling in a metrics library.
+package stats
+
+import (
+	"sync/atomic"
+	"time"
+
+	"sumariza-ai/pkg/clock"
+)
+
+// Registry holds the counters. Every method is safe for concurrent use, and
+// a nil *Registry ignores updates, so components can take one optionally.
+type Registry struct {
+	clock   clock.Clock
+	started time.Time
+
+	requests      atomic.Int64
+	inflight      atomic.Int64
+	scrapes       atomic.Int64
+	cacheHits     atomic.Int64
+	cacheMisses   atomic.Int64
+	browserStarts atomic.Int64
+
+	// droppedLogs reports log entries dropped by the logger, if set
+	droppedLogs atomic.Pointer[func() int64]
+}
+
+// Snapshot is a point-in-time copy of the counters.
+type Snapshot struct {
+	Uptime          time.Duration
+	TotalRequests   int64
+	CurrentInflight int64
+	TotalScrapes    int64
+	CacheHits       int64
+	CacheMisses     int64
+	BrowserStarts   int64
+	DroppedLogs     int64
+}
+
+// New creates a registry whose uptime starts now.
+func New() *Registry {
+	return NewWithClock(clock.Real())
+}
+
+// NewWithClock creates a registry measuring uptime with c (for tests).
+func NewWithClock(c clock.Clock) *Registry {
+	return &Registry{clock: c, started: c.Now()}
+}
+
+// SetDroppedLogs sets the source of the dropped log entries count,
+// e.g. (*log.Logger).DroppedCount.
+func (r *Registry) SetDroppedLogs(count func() int64) {
+	if r == nil {
+		return
+	}
+	r.droppedLogs.Store(&count)
+}
+
+// RequestStarted counts an HTTP request and marks it in flight.
+func (r *Registry) RequestStarted() {
+	if r == nil {
+		return
+	}
+	r.requests.Add(1)
+	r.inflight.Add(1)
+}
+
+// RequestFinished marks a request started with RequestStarted as done.
+func (r *Registry) RequestFinished() {
+	if r == nil {
+		return
+	}
+	r.inflight.Add(-1)
+}
+
+// ScrapeStarted counts a scrape attempt.
+func (r *Registry) ScrapeStarted() {
+	if r == nil {
+		return
+	}
+	r.scrapes.Add(1)
+}
+
+// CacheHit counts a tweet served from the cache.
+func (r *Registry) CacheHit() {
+	if r == nil {
+		return
+	}
+	r.cacheHits.Add(1)
+}
+
+// CacheMiss counts a cache lookup that had to scrape.
+func (r *Registry) CacheMiss() {
+	if r == nil {
+		return
+	}
+	r.cacheMisses.Add(1)
+}
+
+// BrowserStarted counts a successful browser launch.
+func (r *Registry) BrowserStarted() {
+	if r == nil {
+		return
+	}
+	r.browserStarts.Add(1)
+}
+
+// Snapshot returns the current counters. Counters are read one by one, so
+// a snapshot taken under load may mix values from slightly different moments.
+func (r *Registry) Snapshot() Snapshot {
+	if r == nil {
+		return Snapshot{}
+	}
+
+	s := Snapshot{
+		Uptime:          r.clock.Now().Sub(r.started),
+		TotalRequests:   r.requests.Load(),
+		CurrentInflight: r.inflight.Load(),
+		TotalScrapes:    r.scrapes.Load(),
+		CacheHits:       r.cacheHits.Load(),
+		CacheMisses:     r.cacheMisses.Load(),
+		BrowserStarts:   r.browserStarts.Load(),
+	}
+	if count := r.droppedLogs.Load(); count != nil {
+		s.DroppedLogs = (*count)()
+	}
+	return s
+}
@@ -0,0 +1,81 @@
+package stats
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"sumariza-ai/pkg/clock"
+)
+
+func TestRegistry_Snapshot_ReflectsActivity(t *testing.T) {
+	// Arrange
+	fake := clock.NewFake(time.Unix(0, 0))
+	r := NewWithClock(fake)
+	r.SetDroppedLogs(func() int64 { return 7 })
+
+	// Act
+	r.RequestStarted()
+	r.RequestStarted()
+	r.RequestFinished()
+	r.ScrapeStarted()
+	r.CacheHit()
+	r.CacheMiss()
+	r.CacheMiss()
+	r.BrowserStarted()
+	fake.Advance(90 * time.Second)
+	got := r.Snapshot()
+
+	// Assert
+	want := Snapshot{
+		Uptime:          90 * time.Second,
+		TotalRequests:   2,
+		CurrentInflight: 1,
+		TotalScrapes:    1,
+		CacheHits:       1,
+		CacheMisses:     2,
+		BrowserStarts:   1,
+		DroppedLogs:     7,
+	}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestRegistry_ConcurrentUpdates_AreCounted(t *testing.T) {
+	// Arrange
+	r := New()
+	var wg sync.WaitGroup
+
+	// Act
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.RequestStarted()
+			r.CacheHit()
+			r.RequestFinished()
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	s := r.Snapshot()
+	if s.TotalRequests != 50 || s.CacheHits != 50 || s.CurrentInflight != 0 {
+		t.Errorf("got %+v", s)
+	}
+}
+
+func TestRegistry_Nil_IgnoresUpdates(t *testing.T) {
+	// Arrange
+	var r *Registry
+
+	// Act
+	r.RequestStarted()
+	r.CacheHit()
+
+	// Assert
+	if got := r.Snapshot(); got != (Snapshot{}) {
+		t.Errorf("got %+v, want zero", got)
+	}
+}
@@ -1,6 +1,11 @@
 // Package fixtures provides HTML test fixtures for testing the parser.
 package fixtures
 
+import (
+	"fmt"
+	"strings"
+)
+
 // GenerateBasicTweet creates HTML fixture for a simple text tweet.
 func GenerateBasicTweet() string {
 	return `
@@ -26,6 +31,32 @@ func GenerateBasicTweet() string {
 `
 }
 
+// GenerateTweetWithCanonicalURL creates HTML fixture whose og:url meta
+// carries the author's real handle (@realhandle), tweet ID 555.
+func GenerateTweetWithCanonicalURL() string {
+	return `
+<!DOCTYPE html>
+<html>
+<head>
+<title>Tweet</title>
+<meta property="og:url" content="https://x.com/realhandle/status/555"/>
+</head>
+<body>
+<article data-testid="tweet">
+    <div data-testid="User-Name">
+        <span>Real Person</span>
+        <span>@realhandle</span>
+    </div>
+    <div data-testid="tweetText" dir="ltr">
+        The handle in the request URL was wrong.
+    </div>
+    <time datetime="2026-01-01T12:00:00Z">12:00 PM · Jan 1, 2026</time>
+</article>
+</body>
+</html>
+`
+}
+
 // GeneratePartialTweet creates HTML fixture with missing optional fields.
 func GeneratePartialTweet() string {
 	return `
@@ -116,6 +147,227 @@ func GenerateQuoteTweet() string {
 `
 }
 
+// GenerateQuoteTweetWithMedia creates HTML fixture where a plain outer tweet
+// quotes a verified author's tweet that has an image.
+func GenerateQuoteTweetWithMedia() string {
+	return `
+<!DOCTYPE html>
+<html>
+<head><title>Tweet</title></head>
+<body>
+<article data-testid="tweet">
+    <div data-testid="User-Name"><div><div><span>Outer Person</span><span>@outer</span></div></div></div>
+    <div data-testid="tweetText" dir="ltr">Quoting this one</div>
+    <div data-testid="quoteTweet">
+        <div data-testid="Tweet-User-Avatar"><div><img src="https://pbs.twimg.com/profile_images/quoted.jpg"/></div></div>
+        <div data-testid="User-Name"><div><div><span>Quoted Author</span><svg data-testid="icon-verified"></svg><span>@quotedauthor</span></div></div></div>
+        <div data-testid="tweetText" dir="ltr">Look at this photo</div>
+        <div data-testid="tweetPhoto"><img src="https://pbs.twimg.com/media/photo.jpg"/></div>
+    </div>
+    <time datetime="2026-01-01T16:00:00Z">4:00 PM · Jan 1, 2026</time>
+</article>
+</body>
+</html>
+`
+}
+
+// GenerateQuoteTweetUnavailable creates HTML fixture where the outer tweet
+// quotes a deleted tweet, shown as Twitter's "unavailable" placeholder.
+func GenerateQuoteTweetUnavailable() string {
+	return `
+<!DOCTYPE html>
+<html>
+<head><title>Tweet</title></head>
+<body>
+<article data-testid="tweet">
+    <div data-testid="User-Name"><div><div><span>Outer Person</span><span>@outer</span></div></div></div>
+    <div data-testid="tweetText" dir="ltr">Remember this?</div>
+    <div data-testid="quoteTweet">
+        <div><span>This post is unavailable.</span> <a href="https://help.x.com/rules-and-policies/notices-on-x" role="link"><span>Learn more</span></a></div>
+    </div>
+    <time datetime="2026-01-01T16:00:00Z">4:00 PM · Jan 1, 2026</time>
+</article>
+</body>
+</html>
+`
+}
+
+// GenerateQuoteTweetDifferentAuthors creates HTML fixture where the page also
+// shows another account before the tweet, and the main tweet (no avatar)
+// quotes a different author (with avatar).
+func GenerateQuoteTweetDifferentAuthors() string {
+	return `
+<!DOCTYPE html>
+<html>
+<head><title>Tweet</title></head>
+<body>
+<aside aria-label="Relevant people">
+    <div data-testid="User-Name"><div><div><span>Sidebar Person</span><span>@sidebar</span></div></div></div>
+</aside>
+<article data-testid="tweet">
+    <div data-testid="User-Name"><div><div><span>Main Author</span><span>@mainauthor</span></div></div></div>
+    <div data-testid="tweetText" dir="ltr">My take on this</div>
+    <div data-testid="quoteTweet">
+        <article data-testid="tweet">
+            <div data-testid="Tweet-User-Avatar"><div><img src="https://pbs.twimg.com/profile_images/quoted.jpg"/></div></div>
+            <div data-testid="User-Name"><div><div><span>Quoted Author</span><svg data-testid="icon-verified"></svg><span>@quotedauthor</span></div></div></div>
+            <div data-testid="tweetText" dir="rtl">النص المقتبس</div>
+            <time datetime="2025-06-01T08:00:00Z">Jun 1, 2025</time>
+        </article>
+    </div>
+    <time datetime="2026-01-01T16:00:00Z">4:00 PM · Jan 1, 2026</time>
+</article>
+</body>
+</html>
+`
+}
+
+// GenerateProtectedAccountPage creates HTML fixture for the page Twitter shows
+// instead of a tweet from a protected account.
+func GenerateProtectedAccountPage() string {
+	return `
+<!DOCTYPE html>
+<html>
+<head><title>X</title></head>
+<body>
+<main role="main">
+    <div data-testid="emptyState">
+        <div><span>These posts are protected</span></div>
+        <div><span>Only approved followers can see @privateuser’s posts. To request access, click Follow.</span></div>
+    </div>
+</main>
+</body>
+</html>
+`
+}
+
+// GenerateCompleteTweet creates HTML fixture with every author field present,
+// in the nesting Twitter uses.
+func GenerateCompleteTweet() string {
+	return `
+<!DOCTYPE html>
+<html>
+<head><title>Tweet</title></head>
+<body>
+<article data-testid="tweet">
+    <div data-testid="Tweet-User-Avatar"><div><img src="https://pbs.twimg.com/profile_images/jane.jpg"/></div></div>
+    <div data-testid="User-Name"><div><div><span>Jane Roe</span><span>@janeroe</span></div></div></div>
+    <div data-testid="tweetText" dir="ltr">Everything is here.</div>
+    <time datetime="2026-01-01T12:00:00Z">12:00 PM · Jan 1, 2026</time>
+</article>
+</body>
+</html>
+`
+}
+
+// GenerateNotFoundPage creates HTML fixture for the page Twitter shows for a
+// deleted or nonexistent tweet.
+func GenerateNotFoundPage() string {
+	return `
+<!DOCTYPE html>
+<html>
+<head><title>X</title></head>
+<body>
+<main role="main">
+    <div data-testid="error-detail">
+        <span>Hmm...this page doesn’t exist. Try searching for something else.</span>
+    </div>
+</main>
+</body>
+</html>
+`
+}
+
+// GenerateLoginWallPage creates HTML fixture for a page that renders the tweet
+// shell but hides the text behind a login prompt.
+func GenerateLoginWallPage() string {
+	return `
+<!DOCTYPE html>
+<html>
+<head><title>X</title></head>
+<body>
+<article data-testid="tweet">
+    <div data-testid="User-Name"><div><div><span>Jane Roe</span><span>@janeroe</span></div></div></div>
+</article>
+<div data-testid="sheetDialog"><span>Log in to see more</span></div>
+</body>
+</html>
+`
+}
+
+// GenerateSensitiveContentTweet creates HTML fixture for a tweet whose text is
+// hidden behind an age-restricted content warning, followed by a visible reply.
+func GenerateSensitiveContentTweet() string {
+	return `
+<!DOCTYPE html>
+<html>
+<head><title>X</title></head>
+<body>
+<article data-testid="tweet">
+    <div data-testid="User-Name"><div><div><span>Jane Roe</span><span>@janeroe</span></div></div></div>
+    <div role="button" tabindex="0">
+        <span>Age-restricted adult content. This content might not be appropriate for people under 18 years old. To view this media, you’ll need to log in to X.</span>
+        <span>Learn more</span>
+    </div>
+    <time datetime="2026-01-01T12:00:00Z">12:00 PM · Jan 1, 2026</time>
+</article>
+<article data-testid="tweet">
+    <div data-testid="User-Name"><div><div><span>Replier</span><span>@replier</span></div></div></div>
+    <div data-testid="tweetText" dir="ltr">Visible reply text</div>
+</article>
+</body>
+</html>
+`
+}
+
+// GenerateSensitiveMediaTweet creates HTML fixture for a tweet with visible
+// text whose image is covered by a sensitive media warning.
+func GenerateSensitiveMediaTweet() string {
+	return `
+<!DOCTYPE html>
+<html>
+<head><title>X</title></head>
+<body>
+<article data-testid="tweet">
+    <div data-testid="User-Name"><div><div><span>Jane Roe</span><span>@janeroe</span></div></div></div>
+    <div data-testid="tweetText" dir="ltr">Photos from the event</div>
+    <div><span>Content warning: Sensitive</span><span>The post author flagged this post as showing sensitive content.</span><button><span>Show</span></button></div>
+    <time datetime="2026-01-01T12:00:00Z">12:00 PM · Jan 1, 2026</time>
+</article>
+</body>
+</html>
+`
+}
+
+// GenerateTweetWithMetrics creates HTML fixture with views and bookmarks counts.
+// The aria-labels carry full numbers while the visible text is abbreviated.
+func GenerateTweetWithMetrics() string {
+	return `
+<!DOCTYPE html>
+<html>
+<head><title>Tweet</title></head>
+<body>
+<article data-testid="tweet">
+    <div data-testid="User-Name">
+        <span>John Doe</span>
+        <a href="/johndoe/status/321">@johndoe</a>
+    </div>
+    <div data-testid="tweetText" dir="ltr">
+        A tweet people actually read.
+    </div>
+    <time datetime="2026-01-01T12:00:00Z">12:00 PM · Jan 1, 2026</time>
+    <a href="/johndoe/status/321/analytics" aria-label="12,345,678 views. View post analytics">
+        <span>12.3M</span>
+    </a>
+    <div role="group" aria-label="10 replies, 250 reposts, 1,234 likes, 1,024 bookmarks, 12,345,678 views">
+        <button data-testid="bookmark" aria-label="1,024 Bookmarks. Bookmark"><span>1K</span></button>
+    </div>
+</article>
+</body>
+</html>
+`
+}
+
 // GenerateEmptyTweet creates HTML fixture with no tweet text (error case).
 func GenerateEmptyTweet() string {
 	return `
@@ -130,3 +382,322 @@ func GenerateEmptyTweet() string {
 </html>
 `
 }
+
+// GenerateMetaOnlyTweet creates HTML fixture where the tweetText container
+// is missing but the Open Graph meta tags still carry the text.
+func GenerateMetaOnlyTweet() string {
+	return `
+<!DOCTYPE html>
+<html>
+<head>
+<title>John Doe on X</title>
+<meta property="og:title" content="John Doe on X"/>
+<meta property="og:description" content="“Recovered from the meta tags &amp; still readable.”"/>
+</head>
+<body>
+<article data-testid="tweet">
+    <div data-testid="User-Name">
+        <span>John Doe</span>
+        <a href="/johndoe/status/789">@johndoe</a>
+    </div>
+    <time datetime="2026-01-01T12:00:00Z">12:00 PM · Jan 1, 2026</time>
+</article>
+</body>
+</html>
+`
+}
+
+// GenerateTruncatedLongTweet creates HTML fixture for a long tweet rendered
+// with only its prefix and a "Show more" link.
+func GenerateTruncatedLongTweet() string {
+	return `
+<!DOCTYPE html>
+<html>
+<head><title>Tweet</title></head>
+<body>
+<article data-testid="tweet">
+    <div data-testid="User-Name">
+        <span>John Doe</span>
+        <a href="/johndoe/status/900">@johndoe</a>
+    </div>
+    <div data-testid="tweetText" dir="ltr">
+        <span>This long post starts here and keeps going well past the limit</span>
+    </div>
+    <button data-testid="tweet-text-show-more-link" type="button">Show more</button>
+    <time datetime="2026-01-01T12:00:00Z">12:00 PM · Jan 1, 2026</time>
+</article>
+</body>
+</html>
+`
+}
+
+// GenerateTweetWithReplies creates HTML fixture for a tweet by @johndoe
+// (ID 600) followed by his own thread continuation and two replies, from
+// @alice (ID 602, quoting another tweet) and @bob (ID 603).
+func GenerateTweetWithReplies() string {
+	return `
+<!DOCTYPE html>
+<html>
+<head><title>Tweet</title></head>
+<body>
+<article data-testid="tweet">
+    <div data-testid="User-Name"><div><div><span>John Doe</span><span>@johndoe</span></div></div></div>
+    <div data-testid="tweetText" dir="ltr">What should I build next?</div>
+    <a href="/johndoe/status/600"><time datetime="2026-01-01T12:00:00Z">12:00 PM · Jan 1, 2026</time></a>
+</article>
+<article data-testid="tweet">
+    <div data-testid="User-Name"><div><div><span>John Doe</span><span>@johndoe</span></div></div></div>
+    <a href="/johndoe/status/601"><time datetime="2026-01-01T12:05:00Z">5m</time></a>
+    <div data-testid="tweetText" dir="ltr">Thread continues: ideas welcome.</div>
+</article>
+<article data-testid="tweet">
+    <div data-testid="User-Name"><div><div><span>Alice</span><span>@alice</span></div></div></div>
+    <a href="/alice/status/602"><time datetime="2026-01-01T12:05:00Z">5m</time></a>
+    <div data-testid="tweetText" dir="ltr">A tweet summarizer!</div>
+    <div data-testid="quoteTweet">
+        <div data-testid="User-Name"><span>Other</span><span>@other</span></div>
+        <div data-testid="tweetText" dir="ltr">Quoted inside a reply</div>
+    </div>
+</article>
+<article data-testid="tweet">
+    <div data-testid="User-Name"><div><div><span>Bob</span><span>@bob</span></div></div></div>
+    <a href="/bob/status/603"><time datetime="2026-01-01T12:05:00Z">5m</time></a>
+    <div data-testid="tweetText" dir="ltr">Another todo app.</div>
+</article>
+</body>
+</html>
+`
+}
+
+// GenerateProfileTimelineWithPinned creates HTML fixture for @johndoe's
+// profile timeline: a pinned tweet from 2025 (ID 800), then a repost of
+// @alice (ID 801, also carrying a social context) and a recent tweet (ID 802).
+func GenerateProfileTimelineWithPinned() string {
+	return `
+<!DOCTYPE html>
+<html>
+<head><title>John Doe (@johndoe) / X</title></head>
+<body>
+<article data-testid="tweet">
+    <div data-testid="socialContext"><span>Pinned</span></div>
+    <div data-testid="User-Name"><div><div><span>John Doe</span><span>@johndoe</span></div></div></div>
+    <a href="/johndoe/status/800"><time datetime="2025-03-01T09:00:00Z">Mar 1, 2025</time></a>
+    <div data-testid="tweetText" dir="ltr">Start here: what I work on.</div>
+</article>
+<article data-testid="tweet">
+    <div data-testid="socialContext"><a href="/johndoe"><span>John Doe reposted</span></a></div>
+    <div data-testid="User-Name"><div><div><span>Alice</span><span>@alice</span></div></div></div>
+    <a href="/alice/status/801"><time datetime="2026-01-02T10:00:00Z">Jan 2</time></a>
+    <div data-testid="tweetText" dir="ltr">Pinned tweets are hard to sort.</div>
+</article>
+<article data-testid="tweet">
+    <div data-testid="User-Name"><div><div><span>John Doe</span><span>@johndoe</span></div></div></div>
+    <a href="/johndoe/status/802"><time datetime="2026-01-03T08:00:00Z">Jan 3</time></a>
+    <div data-testid="tweetText" dir="ltr">Shipped the feed today.</div>
+</article>
+</body>
+</html>
+`
+}
+
+// GenerateReplyTweet creates HTML fixture for a reply (ID 700 by @johndoe)
+// whose article links its parent tweet, ID 650 by @janeroe.
+func GenerateReplyTweet() string {
+	return `
+<!DOCTYPE html>
+<html>
+<head><title>Tweet</title></head>
+<body>
+<article data-testid="tweet">
+    <div data-testid="User-Name"><div><div><span>John Doe</span><span>@johndoe</span></div></div></div>
+    <div dir="ltr"><span>Replying to </span><a href="/janeroe" role="link">@janeroe</a><span> · </span><a href="/janeroe/status/650" role="link"><span>Show original post</span></a></div>
+    <div data-testid="tweetText" dir="ltr">Agreed, shipping it today.</div>
+    <a href="/johndoe/status/700"><time datetime="2026-01-01T12:00:00Z">12:00 PM · Jan 1, 2026</time></a>
+    <a href="/johndoe/status/700/analytics" aria-label="42 views. View post analytics"><span>42</span></a>
+</article>
+</body>
+</html>
+`
+}
+
+// GenerateTweetWithDuplicateImages creates HTML fixture for a photo grid
+// that renders its two photos several times at different sizes, the way
+// Twitter mixes thumbnails and full renditions.
+func GenerateTweetWithDuplicateImages() string {
+	return `
+<!DOCTYPE html>
+<html>
+<head><title>Tweet</title></head>
+<body>
+<article data-testid="tweet">
+    <div data-testid="User-Name"><div><div><span>John Doe</span><span>@johndoe</span></div></div></div>
+    <div data-testid="tweetText" dir="ltr">Two photos from the trip</div>
+    <div data-testid="tweetPhoto"><img alt="Image" src="https://pbs.twimg.com/media/AAA111?format=jpg&amp;name=small"/></div>
+    <div data-testid="tweetPhoto"><img alt="Image" src="https://pbs.twimg.com/media/BBB222?format=jpg&amp;name=small"/></div>
+    <div data-testid="tweetPhoto"><img alt="Image" src="https://pbs.twimg.com/media/AAA111?format=jpg&amp;name=large"/></div>
+    <div data-testid="tweetPhoto"><img alt="Image" src="https://pbs.twimg.com/media/BBB222.jpg"/></div>
+    <div data-testid="tweetPhoto"><img alt="Image" src="https://pbs.twimg.com/media/AAA111?format=png&amp;name=900x900"/></div>
+</article>
+</body>
+</html>
+`
+}
+
+// GenerateTweetWithRichText creates HTML fixture whose text mixes plain
+// text, a mention, a link, a hashtag and emoji, across two lines.
+func GenerateTweetWithRichText() string {
+	return `
+<!DOCTYPE html>
+<html>
+<head><title>Tweet</title></head>
+<body>
+<article data-testid="tweet">
+    <div data-testid="User-Name"><div><div><span>John Doe</span><span>@johndoe</span></div></div></div>
+    <div data-testid="tweetText" dir="ltr"><span>Thanks </span><a href="/teammate">@teammate</a><span> for the fix </span><img alt="🙌" src="https://abs-0.twimg.com/emoji/v2/svg/1f64c.svg"/><span>
+Notes: </span><a href="https://t.co/AbC123"><span>https://</span>example.com/notes<span>…</span></a><span> </span><a href="/hashtag/golang?src=hashtag_click">#golang</a></div>
+    <time datetime="2026-01-01T12:00:00Z">12:00 PM · Jan 1, 2026</time>
+</article>
+</body>
+</html>
+`
+}
+
+// GenerateImageOnlyTweet creates HTML fixture for a tweet by @johndoe
+// (ID 900) posted with a photo and no text.
+func GenerateImageOnlyTweet() string {
+	return `
+<!DOCTYPE html>
+<html>
+<head><title>Tweet</title></head>
+<body>
+<article data-testid="tweet">
+    <div data-testid="Tweet-User-Avatar"><div><img src="https://pbs.twimg.com/profile_images/johndoe.jpg"/></div></div>
+    <div data-testid="User-Name"><div><div><span>John Doe</span><span>@johndoe</span></div></div></div>
+    <div data-testid="tweetPhoto"><img alt="Sunset over the bay" src="https://pbs.twimg.com/media/SUNSET9?format=jpg&amp;name=small"/></div>
+    <a href="/johndoe/status/900"><time datetime="2026-01-01T18:00:00.000Z">6:00 PM · Jan 1, 2026</time></a>
+</article>
+</body>
+</html>
+`
+}
+
+// GenerateMixedMediaTweet creates HTML fixture for a tweet with a photo,
+// a video, a GIF and a second photo, quoting a tweet with its own photo.
+func GenerateMixedMediaTweet() string {
+	return `
+<!DOCTYPE html>
+<html>
+<head><title>Tweet</title></head>
+<body>
+<article data-testid="tweet">
+    <div data-testid="User-Name"><div><div><span>John Doe</span><span>@johndoe</span></div></div></div>
+    <div data-testid="tweetText" dir="ltr">Highlights from the launch</div>
+    <div data-testid="tweetPhoto"><img alt="The crowd at the venue" src="https://pbs.twimg.com/media/CROWD1?format=jpg&amp;name=small"/></div>
+    <div data-testid="tweetPhoto"><div data-testid="videoPlayer"><video preload="none" playsinline="" poster="https://pbs.twimg.com/ext_tw_video_thumb/900/pu/img/keynote.jpg" src="blob:https://x.com/6c1f0e"></video></div></div>
+    <div data-testid="tweetPhoto"><div data-testid="videoPlayer"><video preload="auto" playsinline="" loop="" poster="https://pbs.twimg.com/tweet_video_thumb/CONFETTI.jpg"><source type="video/mp4" src="https://video.twimg.com/tweet_video/CONFETTI.mp4"></video></div></div>
+    <div data-testid="tweetPhoto"><img alt="Image" src="https://pbs.twimg.com/media/STAGE2?format=jpg&amp;name=small"/></div>
+    <div data-testid="tweetPhoto"><img alt="The crowd at the venue" src="https://pbs.twimg.com/media/CROWD1?format=jpg&amp;name=large"/></div>
+    <div data-testid="quoteTweet"><div>
+        <div data-testid="User-Name"><div><div><span>Event Team</span><span>@eventteam</span></div></div></div>
+        <div data-testid="tweetText" dir="ltr"><span>Doors open at 9</span></div>
+        <div data-testid="tweetPhoto"><img alt="Image" src="https://pbs.twimg.com/media/DOORS3.jpg"/></div>
+    </div></div>
+    <a href="/johndoe/status/1"><time datetime="2026-01-01T12:00:00.000Z">12:00 PM · Jan 1, 2026</time></a>
+</article>
+</body>
+</html>
+`
+}
+
+// GenerateTweetWithSizedMedia creates HTML fixture for a tweet whose media
+// gives its size in img attributes, in the CDN URL, or not at all.
+func GenerateTweetWithSizedMedia() string {
+	return `
+<!DOCTYPE html>
+<html>
+<head><title>Tweet</title></head>
+<body>
+<article data-testid="tweet">
+    <div data-testid="User-Name"><div><div><span>John Doe</span><span>@johndoe</span></div></div></div>
+    <div data-testid="tweetText" dir="ltr">Sizes of things</div>
+    <div data-testid="tweetPhoto"><img alt="Image" width="1600" height="900" src="https://pbs.twimg.com/media/WIDE01?format=jpg&amp;name=large"/></div>
+    <div data-testid="tweetPhoto"><img alt="Image" src="https://pbs.twimg.com/media/TALL02?format=jpg&amp;name=675x1200"/></div>
+    <div data-testid="tweetPhoto"><div data-testid="videoPlayer"><video poster="https://pbs.twimg.com/ext_tw_video_thumb/901/pu/img/clip.jpg"><source type="video/mp4" src="https://video.twimg.com/ext_tw_video/901/pu/vid/avc1/1280x720/clip.mp4"></video></div></div>
+    <div data-testid="tweetPhoto"><img alt="Image" src="https://pbs.twimg.com/media/PLAIN3?format=jpg&amp;name=small"/></div>
+    <a href="/johndoe/status/1"><time datetime="2026-01-01T12:00:00.000Z">12:00 PM · Jan 1, 2026</time></a>
+</article>
+</body>
+</html>
+`
+}
+
+// GenerateLargeTweetPage creates a page sized like a real rendered tweet
+// (a few hundred KB): inline styles and scripts, navigation, a primary tweet
+// with links, mentions, hashtags, emoji and a quote, then a long reply thread.
+// Used by benchmarks; the primary tweet is by @janeroe with ID 1001.
+func GenerateLargeTweetPage() string {
+	var b strings.Builder
+
+	b.WriteString(`<!DOCTYPE html>
+<html dir="ltr" lang="en">
+<head><title>Jane Roe on X</title>
+<meta property="og:url" content="https://x.com/janeroe/status/1001"/>
+`)
+	for i := 0; i < 60; i++ {
+		fmt.Fprintf(&b, "<style>.r-%d{display:flex;flex-direction:column;margin:0 %dpx;padding:%dpx 12px;border-width:0;box-sizing:border-box}</style>\n", i, i%16, i%8)
+	}
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(&b, "<script type=\"application/json\">{\"chunk\":%d,\"modules\":[%s]}</script>\n", i, strings.Repeat(`"ondemand.s.a1b2c3",`, 40)+`"end"`)
+	}
+	b.WriteString(`</head>
+<body>
+<nav aria-label="Primary" role="navigation">
+`)
+	for _, item := range []string{"Home", "Explore", "Notifications", "Messages", "Grok", "Lists", "Bookmarks", "Communities", "Premium", "Profile", "More"} {
+		fmt.Fprintf(&b, `<a href="/%s" role="link" class="css-175oi2r r-6koalj r-eqz5dr"><div class="css-175oi2r"><span class="css-1jxf684">%s</span></div></a>
+`, strings.ToLower(item), item)
+	}
+	b.WriteString(`</nav>
+<main role="main"><div class="css-175oi2r r-f8sm7e r-13qz1uu r-1ye8kvj">
+<article data-testid="tweet" tabindex="-1" role="article" class="css-175oi2r r-18u37iz r-1udh08x">
+    <div data-testid="Tweet-User-Avatar"><div class="css-175oi2r r-1adg3ll"><div><img alt="" draggable="true" src="https://pbs.twimg.com/profile_images/1001/jane_normal.jpg" class="css-9pa8cd"/></div></div></div>
+    <div data-testid="User-Name"><div class="css-175oi2r r-1wbh5a2"><div class="css-175oi2r r-1awozwy"><span class="css-1jxf684">Jane Roe</span><svg viewBox="0 0 22 22" aria-label="Verified account" role="img" data-testid="icon-verified"><g><path d="M20.396 11c-.018-.646"></path></g></svg><span class="css-1jxf684">@janeroe</span></div></div></div>
+    <div data-testid="tweetText" dir="ltr" lang="en" class="css-146c3p1 r-bcqeeo r-1ttztb7"><span class="css-1jxf684">Shipping the new release today </span><img alt="🚀" draggable="false" src="https://abs-0.twimg.com/emoji/v2/svg/1f680.svg" class="r-4qtqp9"/><span class="css-1jxf684">
+Big thanks to </span><span class="r-18u37iz"><a dir="ltr" href="/teammate" role="link" class="css-1jxf684 r-bcqeeo">@teammate</a></span><span class="css-1jxf684"> and everyone who tested the betas.
+
+Changelog: </span><a dir="ltr" href="https://t.co/AbCdEf123" rel="noopener noreferrer nofollow" target="_blank" role="link" class="css-1jxf684 r-bcqeeo"><span aria-hidden="true" class="css-1jxf684">https://</span>example.com/releases/v2<span aria-hidden="true" class="css-1jxf684">.0/notes</span><span class="css-1jxf684">…</span></a><span class="css-1jxf684"> </span><span class="r-18u37iz"><a dir="ltr" href="/hashtag/golang?src=hashtag_click" role="link" class="css-1jxf684 r-bcqeeo">#golang</a></span><span class="css-1jxf684"> </span><img alt="🎉" draggable="false" src="https://abs-0.twimg.com/emoji/v2/svg/1f389.svg" class="r-4qtqp9"/></div>
+    <div data-testid="quoteTweet"><div class="css-175oi2r r-adacv r-1udh08x">
+        <div data-testid="Tweet-User-Avatar"><div><img src="https://pbs.twimg.com/profile_images/2002/project_normal.jpg"/></div></div>
+        <div data-testid="User-Name"><div><div><span>Project Account</span><span>@project</span></div></div></div>
+        <div data-testid="tweetText" dir="ltr"><span>v2.0 is tagged. Release notes are up.</span></div>
+        <div data-testid="tweetPhoto"><img alt="Image" src="https://pbs.twimg.com/media/release.jpg"/></div>
+    </div></div>
+    <a href="/janeroe/status/1001" role="link"><time datetime="2026-01-01T12:00:00.000Z">12:00 PM · Jan 1, 2026</time></a>
+    <a href="/janeroe/status/1001/analytics" aria-label="1,234,567 views. View post analytics"><span>1.2M</span></a>
+    <div role="group" aria-label="321 replies, 1,024 reposts, 9,876 likes, 456 bookmarks, 1,234,567 views">
+        <button data-testid="reply" aria-label="321 Replies. Reply"><span>321</span></button>
+        <button data-testid="retweet" aria-label="1024 reposts. Repost"><span>1K</span></button>
+        <button data-testid="like" aria-label="9876 Likes. Like"><span>9.8K</span></button>
+        <button data-testid="bookmark" aria-label="456 Bookmarks. Bookmark"><span>456</span></button>
+    </div>
+</article>
+`)
+	for i := 0; i < 150; i++ {
+		fmt.Fprintf(&b, `<div data-testid="cellInnerDiv" style="transform: translateY(%dpx); position: absolute; width: 100%%;"><div class="css-175oi2r r-1igl3o0 r-qklmqi r-1adg3ll r-1ny4l3l">
+<article data-testid="tweet" tabindex="0" role="article" class="css-175oi2r r-18u37iz r-1ny4l3l r-1udh08x">
+    <div data-testid="Tweet-User-Avatar"><div><img alt="" src="https://pbs.twimg.com/profile_images/%d/reply_normal.jpg"/></div></div>
+    <div data-testid="User-Name"><div><div><span>Replier %d</span><span>@replier%d</span></div></div></div>
+    <div data-testid="tweetText" dir="ltr" lang="en"><span>Congrats on the release! Replying to </span><a href="/janeroe" role="link">@janeroe</a><span> with thought number %d about </span><a href="https://t.co/reply%d" rel="noopener noreferrer nofollow" target="_blank" role="link"><span aria-hidden="true">https://</span>example.org/thread/%d</a></div>
+    <a href="/replier%d/status/%d"><time datetime="2026-01-01T12:%02d:00.000Z">%dm</time></a>
+    <div role="group" aria-label="%d replies, %d reposts, %d likes, %d views"><button data-testid="reply"><span>%d</span></button><button data-testid="like"><span>%d</span></button></div>
+</article>
+</div></div>
+`, i*180, 3000+i, i, i, i, i, i, i, 5000+i, i%60, i%60+1, i%7, i%3, i*4, i*100, i%7, i*4)
+	}
+	b.WriteString(`</div></main>
+</body>
+</html>
+`)
+
+	return b.String()
+}